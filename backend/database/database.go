@@ -10,6 +10,21 @@ import (
 	"gorm.io/gorm"
 )
 
+// Models lists every table in the schema, so the app and the tests migrate the same set
+var Models = []any{
+	&models.User{}, &models.UserPreferences{}, &models.UrgencySettings{},
+	&models.Goal{}, &models.GoalStatusTransition{},
+	&models.Task{}, &models.TaskSeries{}, &models.TaskDependency{}, &models.ChecklistItem{}, &models.TaskCompletionEvent{}, &models.UrgencyHistory{},
+	&models.DailyPlan{}, &models.DailyPlanItem{}, &models.BusyBlock{},
+	&models.HabitCheckIn{}, &models.LearningLogEntry{},
+	&models.ChatMessage{}, &models.MessageFeedback{}, &models.ActionOutcome{},
+}
+
+// Migrate creates or updates the tables for every model in Models
+func Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(Models...)
+}
+
 func ConnectDB() (*gorm.DB, error) {
 
 	HOST := os.Getenv("DB_HOST")
@@ -31,7 +46,11 @@ func ConnectDB() (*gorm.DB, error) {
 
 	log.Println("Database connection established")
 
-	db.AutoMigrate(&models.User{}, &models.Goal{}, &models.Task{}, &models.ChatMessage{})
+	if err := Migrate(db); err != nil {
+		log.Printf("Failed to migrate database: %v", err)
+
+		return &gorm.DB{}, fmt.Errorf("Failed to migrate database")
+	}
 
 	return db, nil
 }
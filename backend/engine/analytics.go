@@ -0,0 +1,233 @@
+package engine
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+// WeeklyVelocity counts the completion events of one local week, starting on Monday
+type WeeklyVelocity struct {
+	WeekStart string `json:"week_start"`
+	Completed int    `json:"completed"`
+	Reopened  int    `json:"reopened"`
+	Net       int    `json:"net"` // Completed minus reopened
+}
+
+// CompletionVelocity buckets completion events into the given number of weeks ending
+// with the local week of now
+func CompletionVelocity(events []models.TaskCompletionEvent, now time.Time, weeks int, loc *time.Location) []WeeklyVelocity {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	first := weekStart(startOfLocalDay(now, loc)).AddDate(0, 0, -7*(weeks-1))
+	velocity := make([]WeeklyVelocity, weeks)
+	for i := range velocity {
+		velocity[i].WeekStart = first.AddDate(0, 0, 7*i).Format("2006-01-02")
+	}
+
+	for _, event := range events {
+		day := startOfLocalDay(event.OccurredAt, loc)
+		if day.Before(first) {
+			continue
+		}
+		week := int(weekStart(day).Sub(first).Hours()/24+0.5) / 7
+		if week >= weeks {
+			continue
+		}
+		switch event.Event {
+		case CompletionCompleted:
+			velocity[week].Completed++
+			velocity[week].Net++
+		case CompletionReopened:
+			velocity[week].Reopened++
+			velocity[week].Net--
+		}
+		// A cancelled task was still done when it was completed, so it stays counted
+	}
+
+	return velocity
+}
+
+// EstimateAccuracy compares estimates with tracked time for tasks that have both
+type EstimateAccuracy struct {
+	Tasks             int     `json:"tasks"`
+	EstimatedHours    float64 `json:"estimated_hours"`
+	TrackedHours      float64 `json:"tracked_hours"`
+	Ratio             float64 `json:"ratio"`               // Tracked over estimated hours; above 1 means work ran over
+	MeanAbsoluteError float64 `json:"mean_absolute_error"` // Average hours each estimate was off by
+}
+
+// CompletionStats summarises how completed tasks were finished
+type CompletionStats struct {
+	Completed        int               `json:"completed"`
+	OnTime           int               `json:"on_time"`
+	Late             int               `json:"late"`
+	NoDeadline       int               `json:"no_deadline"`
+	OnTimeRate       float64           `json:"on_time_rate"` // Share of completed tasks with a deadline finished by it
+	AverageUrgency   float64           `json:"average_urgency"`
+	EstimateAccuracy *EstimateAccuracy `json:"estimate_accuracy,omitempty"`
+}
+
+// CompletionRates measures completed tasks against their effective deadlines, the
+// urgency they had when they were finished and, where tracked, their estimates.
+// Urgency at completion is the latest recorded sample before it, or the task's
+// current score when none was recorded.
+func CompletionRates(tasks []models.Task, goals map[uuid.UUID]models.Goal, samples []UrgencySample) CompletionStats {
+	byTask := make(map[uuid.UUID][]UrgencySample)
+	for _, sample := range samples {
+		byTask[sample.TaskID] = append(byTask[sample.TaskID], sample)
+	}
+
+	var stats CompletionStats
+	var accuracy EstimateAccuracy
+	var absError float64
+	urgencyTotal, scored := 0, 0
+
+	for _, task := range tasks {
+		completedAt, ok := CompletionTime(task)
+		if !ok {
+			continue
+		}
+		stats.Completed++
+
+		deadline := EffectiveDeadline(task, goals[task.GoalID])
+		switch {
+		case deadline.IsZero():
+			stats.NoDeadline++
+		case completedAt.After(deadline):
+			stats.Late++
+		default:
+			stats.OnTime++
+		}
+
+		if urgency := urgencyAt(byTask[task.ID], completedAt, task.AIUrgency); urgency > 0 {
+			urgencyTotal += urgency
+			scored++
+		}
+
+		if task.EstimatedHours != nil && *task.EstimatedHours > 0 && task.TrackedHours != nil {
+			accuracy.Tasks++
+			accuracy.EstimatedHours += *task.EstimatedHours
+			accuracy.TrackedHours += *task.TrackedHours
+			absError += math.Abs(*task.TrackedHours - *task.EstimatedHours)
+		}
+	}
+
+	if withDeadline := stats.OnTime + stats.Late; withDeadline > 0 {
+		stats.OnTimeRate = float64(stats.OnTime) / float64(withDeadline)
+	}
+	if scored > 0 {
+		stats.AverageUrgency = float64(urgencyTotal) / float64(scored)
+	}
+	if accuracy.Tasks > 0 {
+		accuracy.Ratio = accuracy.TrackedHours / accuracy.EstimatedHours
+		accuracy.MeanAbsoluteError = absError / float64(accuracy.Tasks)
+		stats.EstimateAccuracy = &accuracy
+	}
+	return stats
+}
+
+// urgencyAt is the latest sampled urgency at or before a time, or fallback without one
+func urgencyAt(samples []UrgencySample, at time.Time, fallback int) int {
+	urgency := fallback
+	var latest time.Time
+	for _, sample := range samples {
+		if !sample.At.After(at) && !sample.At.Before(latest) {
+			urgency = sample.Urgency
+			latest = sample.At
+		}
+	}
+	return urgency
+}
+
+// ProductivityDistribution counts completions by local hour of day and day of week
+type ProductivityDistribution struct {
+	Total     int   `json:"total"`
+	ByHour    []int `json:"by_hour"`    // Index 0 is midnight to 1am
+	ByWeekday []int `json:"by_weekday"` // Index 0 is Monday
+}
+
+// CompletionDistribution spreads completion events over the hours and weekdays they
+// happened on in the user's timezone. Reopened tasks are not counted.
+func CompletionDistribution(events []models.TaskCompletionEvent, loc *time.Location) ProductivityDistribution {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	dist := ProductivityDistribution{ByHour: make([]int, 24), ByWeekday: make([]int, 7)}
+	for _, event := range events {
+		if event.Event != CompletionCompleted {
+			continue
+		}
+		local := event.OccurredAt.In(loc)
+		dist.Total++
+		dist.ByHour[local.Hour()]++
+		dist.ByWeekday[weekdayOffset(local)]++
+	}
+	return dist
+}
+
+// BurndownPoint is the state of a goal's tasks at the end of one local day
+type BurndownPoint struct {
+	Date      string   `json:"date"`
+	Total     int      `json:"total"`
+	Completed int      `json:"completed"`
+	Remaining int      `json:"remaining"`
+	Ideal     *float64 `json:"ideal,omitempty"` // Remaining tasks on a straight line from the goal's creation to its deadline
+}
+
+// GoalBurndown replays a goal's tasks into one point per day for the given number of
+// days ending on the local day of now. Callers leave cancelled tasks out.
+func GoalBurndown(goal models.Goal, tasks []models.Task, now time.Time, days int, loc *time.Location) []BurndownPoint {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	created := make([]time.Time, 0, len(tasks))
+	var completed []time.Time
+	for _, task := range tasks {
+		created = append(created, task.CreatedAt)
+		if at, ok := CompletionTime(task); ok {
+			completed = append(completed, at)
+		}
+	}
+	sort.Slice(created, func(i, j int) bool { return created[i].Before(created[j]) })
+	sort.Slice(completed, func(i, j int) bool { return completed[i].Before(completed[j]) })
+
+	first := startOfLocalDay(now, loc).AddDate(0, 0, -(days - 1))
+	points := make([]BurndownPoint, 0, days)
+	nextCreated, nextCompleted := 0, 0
+
+	for i := range days {
+		day := first.AddDate(0, 0, i)
+		end := day.AddDate(0, 0, 1)
+
+		for nextCreated < len(created) && created[nextCreated].Before(end) {
+			nextCreated++
+		}
+		for nextCompleted < len(completed) && completed[nextCompleted].Before(end) {
+			nextCompleted++
+		}
+
+		point := BurndownPoint{
+			Date:      day.Format("2006-01-02"),
+			Total:     nextCreated,
+			Completed: nextCompleted,
+			Remaining: max(nextCreated-nextCompleted, 0),
+		}
+		if goal.Deadline != nil && goal.Deadline.After(goal.CreatedAt) {
+			span := goal.Deadline.Sub(goal.CreatedAt).Hours()
+			elapsed := math.Min(math.Max(end.Sub(goal.CreatedAt).Hours(), 0), span)
+			ideal := float64(len(tasks)) * (1 - elapsed/span)
+			point.Ideal = &ideal
+		}
+		points = append(points, point)
+	}
+
+	return points
+}
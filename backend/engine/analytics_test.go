@@ -0,0 +1,132 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+func TestCompletionVelocity(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	now := time.Date(2026, 3, 11, 12, 0, 0, 0, loc) // Wednesday
+
+	event := func(kind string, at time.Time) models.TaskCompletionEvent {
+		return models.TaskCompletionEvent{Event: kind, OccurredAt: at}
+	}
+	events := []models.TaskCompletionEvent{
+		event(CompletionCompleted, time.Date(2026, 3, 9, 8, 0, 0, 0, loc)),
+		event(CompletionCompleted, time.Date(2026, 3, 10, 8, 0, 0, 0, loc)),
+		event(CompletionReopened, time.Date(2026, 3, 10, 9, 0, 0, 0, loc)),
+		event(CompletionCancelled, time.Date(2026, 3, 10, 10, 0, 0, 0, loc)), // Not a reopen
+		// Sunday 11pm locally is already Monday in UTC, but belongs to the week before
+		event(CompletionCompleted, time.Date(2026, 3, 8, 23, 0, 0, 0, loc)),
+		event(CompletionCompleted, time.Date(2026, 2, 1, 8, 0, 0, 0, loc)), // Outside the window
+	}
+
+	got := CompletionVelocity(events, now, 2, loc)
+	want := []WeeklyVelocity{
+		{WeekStart: "2026-03-02", Completed: 1, Net: 1},
+		{WeekStart: "2026-03-09", Completed: 2, Reopened: 1, Net: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("velocity = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("week %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCompletionRates(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(days int) *time.Time {
+		t := now.AddDate(0, 0, days)
+		return &t
+	}
+	hours := func(h float64) *float64 { return &h }
+
+	goalDeadline := now.AddDate(0, 0, 5)
+	goal := models.Goal{ID: uuid.New(), Deadline: &goalDeadline}
+
+	onTime := models.Task{ID: uuid.New(), IsCompleted: true, CompletedAt: at(-2), Deadline: now.AddDate(0, 0, -1), AIUrgency: 4,
+		EstimatedHours: hours(2), TrackedHours: hours(3)}
+	late := models.Task{ID: uuid.New(), IsCompleted: true, CompletedAt: at(-1), Deadline: now.AddDate(0, 0, -3), AIUrgency: 2,
+		EstimatedHours: hours(4), TrackedHours: hours(4)}
+	inherited := models.Task{ID: uuid.New(), GoalID: goal.ID, IsCompleted: true, CompletedAt: at(0)}
+	open := models.Task{ID: uuid.New(), Deadline: now, AIUrgency: 9}
+
+	// The late task scored 8 just before it was finished; the later 1 does not count
+	samples := []UrgencySample{
+		{TaskID: late.ID, Urgency: 8, At: now.AddDate(0, 0, -2)},
+		{TaskID: late.ID, Urgency: 1, At: now},
+	}
+
+	stats := CompletionRates([]models.Task{onTime, late, inherited, open}, map[uuid.UUID]models.Goal{goal.ID: goal}, samples)
+	if stats.Completed != 3 || stats.OnTime != 2 || stats.Late != 1 || stats.NoDeadline != 0 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	if stats.OnTimeRate < 0.66 || stats.OnTimeRate > 0.67 {
+		t.Errorf("on-time rate = %v, want 2/3", stats.OnTimeRate)
+	}
+	if stats.AverageUrgency != 6 {
+		t.Errorf("average urgency = %v, want 6 from the sampled 8 and the current 4", stats.AverageUrgency)
+	}
+	accuracy := stats.EstimateAccuracy
+	if accuracy == nil || accuracy.Tasks != 2 || accuracy.Ratio != 7.0/6.0 || accuracy.MeanAbsoluteError != 0.5 {
+		t.Errorf("estimate accuracy = %+v", accuracy)
+	}
+}
+
+func TestCompletionDistribution(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	events := []models.TaskCompletionEvent{
+		{Event: CompletionCompleted, OccurredAt: time.Date(2026, 3, 9, 8, 30, 0, 0, time.UTC)},  // Monday 9:30
+		{Event: CompletionCompleted, OccurredAt: time.Date(2026, 3, 15, 23, 0, 0, 0, time.UTC)}, // Monday 0:00
+		{Event: CompletionReopened, OccurredAt: time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)},
+	}
+
+	dist := CompletionDistribution(events, loc)
+	if dist.Total != 2 || dist.ByHour[9] != 1 || dist.ByHour[0] != 1 || dist.ByWeekday[0] != 2 {
+		t.Errorf("distribution = %+v", dist)
+	}
+}
+
+func TestGoalBurndown(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	created := now.AddDate(0, 0, -2)
+	deadline := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)
+	goal := models.Goal{CreatedAt: time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC), Deadline: &deadline} // Four days to burn four tasks
+	done := now.AddDate(0, 0, -1)
+
+	tasks := []models.Task{
+		{CreatedAt: created},
+		{CreatedAt: created, IsCompleted: true, CompletedAt: &done},
+		{CreatedAt: now},
+		{CreatedAt: created, IsCompleted: true, UpdatedAt: now}, // Completed before CompletedAt existed
+	}
+
+	points := GoalBurndown(goal, tasks, now, 3, time.UTC)
+	want := []struct {
+		total, completed, remaining int
+		ideal                       float64
+	}{
+		{3, 0, 3, 3},
+		{3, 1, 2, 2},
+		{4, 2, 2, 1},
+	}
+	if len(points) != len(want) {
+		t.Fatalf("burndown = %+v", points)
+	}
+	for i, w := range want {
+		p := points[i]
+		if p.Ideal == nil {
+			t.Fatalf("day %s has no ideal line", p.Date)
+		}
+		if p.Total != w.total || p.Completed != w.completed || p.Remaining != w.remaining || *p.Ideal != w.ideal {
+			t.Errorf("day %s = %d/%d/%d ideal %v, want %+v", p.Date, p.Total, p.Completed, p.Remaining, *p.Ideal, w)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Deadline rules reported in a Breakdown
+const (
+	RuleTaskOverdue       = "task_overdue"
+	RuleTaskCritical      = "task_due_very_soon"
+	RuleTaskWarning       = "task_due_soon"
+	RuleTaskElapsed       = "task_timeline_elapsed"
+	RuleGoalCritical      = "goal_due_very_soon"
+	RuleGoalWarning       = "goal_due_soon"
+	RuleGoalElapsed       = "goal_timeline_elapsed"
+	RuleEmptyTimeline     = "deadline_before_creation"
+	RuleNoDeadline        = "no_deadline"
+	RuleOverCapacity      = "not_enough_hours"
+	RuleTightCapacity     = "few_hours_to_spare"
+	RuleHabitBehind       = "habit_behind_target"
+	RuleExplorationIdle   = "exploration_idle"
+	RuleExplorationBehind = "exploration_behind_budget"
+)
+
+// Breakdown explains how a Scorer arrived at a task's urgency
+type Breakdown struct {
+	Strategy            string   `json:"strategy"`
+	BasePriority        int      `json:"base_priority"`
+	DeadlinePressure    int      `json:"deadline_pressure"`
+	DeadlineRule        string   `json:"deadline_rule"`
+	DaysLeft            *int     `json:"days_left,omitempty"`
+	TimelineUsed        *float64 `json:"timeline_used,omitempty"`   // Share of the deadline window already elapsed
+	CapacityLoad        *float64 `json:"capacity_load,omitempty"`   // Effort due by the deadline over the working hours left
+	HabitRemaining      *int     `json:"habit_remaining,omitempty"` // Check-ins the habit still needs this week
+	IdleDays            *int     `json:"idle_days,omitempty"`       // Days an exploration goal has gone untouched
+	BudgetUsed          *float64 `json:"budget_used,omitempty"`     // Share of an exploration goal's weekly hours spent
+	GoalLag             int      `json:"goal_lag"`
+	CompletionRate      float64  `json:"completion_rate"`
+	ProjectedCompletion *string  `json:"projected_completion,omitempty"` // Forecast finish date of the goal, YYYY-MM-DD in the user's timezone
+	Staleness           int      `json:"staleness"`
+	IdleRatio           float64  `json:"idle_ratio"`
+	JobSize             float64  `json:"job_size,omitempty"`         // Only set by the wsjf strategy
+	BlockingUrgency     int      `json:"blocking_urgency,omitempty"` // Urgency inherited from the most urgent task this one holds up
+	RawScore            float64  `json:"raw_score"`
+	Urgency             int      `json:"urgency"`
+	Clamped             bool     `json:"clamped"` // RawScore fell outside 1-10 and was clamped
+}
+
+func (b *Breakdown) applyDeadline(result deadlineResult) {
+	b.DeadlinePressure = result.pressure
+	b.DeadlineRule = result.rule
+	b.DaysLeft = result.daysLeft
+	b.TimelineUsed = result.timeElapsed
+	b.CapacityLoad = result.capacityLoad
+	b.HabitRemaining = result.habitRemaining
+	b.IdleDays = result.idleDays
+	b.BudgetUsed = result.budgetUsed
+}
+
+// finish rounds and clamps the raw score into the final urgency
+func (b *Breakdown) finish() {
+	rounded := int(math.Round(b.RawScore))
+	b.Urgency = clamp(rounded, 1, 10)
+	b.Clamped = b.Urgency != rounded
+}
+
+var ruleDescriptions = map[string]string{
+	RuleTaskOverdue:       "task is overdue",
+	RuleTaskCritical:      "task due within %d day(s)",
+	RuleTaskWarning:       "task due in %d days",
+	RuleTaskElapsed:       "task deadline in %d days",
+	RuleGoalCritical:      "goal due within %d day(s)",
+	RuleGoalWarning:       "goal due in %d days",
+	RuleGoalElapsed:       "goal deadline in %d days",
+	RuleEmptyTimeline:     "deadline set before the task was created",
+	RuleNoDeadline:        "no deadline",
+	RuleOverCapacity:      "not enough working hours before the deadline in %d days",
+	RuleTightCapacity:     "few working hours to spare before the deadline in %d days",
+	RuleHabitBehind:       "habit check-ins left this week",
+	RuleExplorationIdle:   "exploration untouched for %d days",
+	RuleExplorationBehind: "exploration behind its weekly hours",
+}
+
+// Summary renders the breakdown as one short human readable line
+func (b Breakdown) Summary() string {
+	deadline := ruleDescriptions[b.DeadlineRule]
+	if b.DaysLeft != nil && strings.Contains(deadline, "%d") {
+		deadline = fmt.Sprintf(deadline, *b.DaysLeft)
+	}
+	if b.IdleDays != nil && strings.Contains(deadline, "%d") {
+		deadline = fmt.Sprintf(deadline, *b.IdleDays)
+	}
+	if b.BudgetUsed != nil {
+		deadline += fmt.Sprintf(", %.0f%% of the weekly hours spent", *b.BudgetUsed*100)
+	}
+	if b.HabitRemaining != nil && b.DaysLeft != nil {
+		deadline = fmt.Sprintf("habit needs %d more check-in(s) in %d day(s)", *b.HabitRemaining, *b.DaysLeft)
+	}
+	if b.TimelineUsed != nil {
+		deadline += fmt.Sprintf(", %.0f%% of the time used", *b.TimelineUsed*100)
+	}
+	if b.CapacityLoad != nil {
+		deadline += fmt.Sprintf(", schedule %.0f%% booked", *b.CapacityLoad*100)
+	}
+
+	parts := []string{
+		fmt.Sprintf("priority +%d", b.BasePriority),
+		fmt.Sprintf("%s +%d", deadline, b.DeadlinePressure),
+		fmt.Sprintf("goal %.0f%% done +%d", b.CompletionRate*100, b.GoalLag),
+		fmt.Sprintf("idle %.0f%% +%d", b.IdleRatio*100, b.Staleness),
+	}
+	if b.ProjectedCompletion != nil {
+		parts[2] = fmt.Sprintf("goal %.0f%% done, projected to finish %s +%d", b.CompletionRate*100, *b.ProjectedCompletion, b.GoalLag)
+	}
+	if b.JobSize > 0 {
+		parts = append(parts, fmt.Sprintf("job size %.0f", b.JobSize))
+	}
+	if b.BlockingUrgency > 0 {
+		parts = append(parts, fmt.Sprintf("blocks a task at urgency %d", b.BlockingUrgency))
+	}
+	if b.Clamped {
+		parts = append(parts, "clamped to 1-10")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+)
+
+func TestExplainOverdueTask(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	task := models.Task{
+		UserPriority: 3,
+		Deadline:     now.AddDate(0, 0, -1),
+		CreatedAt:    now.AddDate(0, 0, -10),
+		UpdatedAt:    now.AddDate(0, 0, -10),
+	}
+
+	b := DefaultScorer{Config: DefaultConfig()}.Explain(Input{Task: task, TotalTasks: 4, CompletedTasks: 1, Now: now})
+
+	if b.DeadlineRule != RuleTaskOverdue || b.DeadlinePressure != 4 {
+		t.Errorf("deadline = %s +%d, want %s +4", b.DeadlineRule, b.DeadlinePressure, RuleTaskOverdue)
+	}
+	if b.GoalLag != 2 || b.CompletionRate != 0.25 {
+		t.Errorf("goal lag = +%d at %.2f, want +2 at 0.25", b.GoalLag, b.CompletionRate)
+	}
+	if b.RawScore != 9 || b.Urgency != 9 || b.Clamped {
+		t.Errorf("raw=%.1f urgency=%d clamped=%t, want 9, 9, false", b.RawScore, b.Urgency, b.Clamped)
+	}
+}
+
+func TestExplainReportsClampingAndTimeline(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	task := models.Task{
+		UserPriority: 3,
+		Deadline:     now.AddDate(0, 0, 10),
+		CreatedAt:    now.AddDate(0, 0, -30),
+		UpdatedAt:    now.AddDate(0, 0, -30),
+	}
+
+	cfg := DefaultConfig()
+	cfg.PriorityWeight = 3
+	b := DefaultScorer{Config: cfg}.Explain(Input{Task: task, TotalTasks: 4, CompletedTasks: 0, Now: now})
+
+	if b.DeadlineRule != RuleTaskElapsed || b.TimelineUsed == nil || *b.TimelineUsed != 0.75 {
+		t.Fatalf("deadline rule = %s, timeline used = %v; want %s at 0.75", b.DeadlineRule, b.TimelineUsed, RuleTaskElapsed)
+	}
+	if !b.Clamped || b.Urgency != 10 {
+		t.Errorf("urgency=%d clamped=%t, want 10 clamped", b.Urgency, b.Clamped)
+	}
+	if summary := b.Summary(); !strings.Contains(summary, "75% of the time used") || !strings.Contains(summary, "clamped") {
+		t.Errorf("Summary() = %q", summary)
+	}
+}
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+// briefingTopTasks caps how many of the most urgent tasks a briefing lists
+const briefingTopTasks = 5
+
+// BriefingInput is what DailyBriefing needs to look at the user's day
+type BriefingInput struct {
+	Now      time.Time
+	Location *time.Location
+	Goals    []models.Goal
+	Tasks    []models.Task
+	Urgency  map[uuid.UUID]Breakdown  // Current breakdown of open tasks, keyed by task ID
+	Habits   map[uuid.UUID]HabitStats // Check-in progress of habit goals, keyed by goal ID
+}
+
+// BriefingTask is a task as listed in a briefing
+type BriefingTask struct {
+	TaskID      uuid.UUID  `json:"task_id"`
+	GoalID      uuid.UUID  `json:"goal_id"`
+	Title       string     `json:"title"`
+	Deadline    *time.Time `json:"deadline,omitempty"`
+	Urgency     int        `json:"urgency"`
+	DaysOverdue int        `json:"days_overdue,omitempty"` // Whole local days since the deadline, for overdue tasks
+}
+
+// BriefingHabit is a habit that falls short of this week's target unless the user checks in soon
+type BriefingHabit struct {
+	GoalID        uuid.UUID `json:"goal_id"`
+	Title         string    `json:"title"`
+	Remaining     int       `json:"remaining"` // Check-ins still needed this week
+	DaysLeft      int       `json:"days_left"`
+	CurrentStreak int       `json:"current_streak"` // Weeks in a row the target was met, lost if this week falls short
+	Pressure      int       `json:"pressure"`
+}
+
+// Briefing is the start-of-day summary the assistant writes up
+type Briefing struct {
+	Date         string          `json:"date"` // YYYY-MM-DD in the user's timezone
+	TopTasks     []BriefingTask  `json:"top_tasks"`
+	Overdue      []BriefingTask  `json:"overdue"`
+	HabitsAtRisk []BriefingHabit `json:"habits_at_risk"`
+}
+
+// DailyBriefing picks out the most urgent open tasks, the ones already past their
+// deadline, oldest first, and the habits falling behind this week, most pressing first
+func DailyBriefing(in BriefingInput) Briefing {
+	if in.Location == nil {
+		in.Location = time.UTC
+	}
+
+	briefing := Briefing{
+		Date:         in.Now.In(in.Location).Format("2006-01-02"),
+		TopTasks:     []BriefingTask{},
+		Overdue:      []BriefingTask{},
+		HabitsAtRisk: []BriefingHabit{},
+	}
+	today := startOfLocalDay(in.Now, in.Location)
+
+	for _, task := range in.Tasks {
+		if !TaskOpen(task) {
+			continue
+		}
+		entry := BriefingTask{TaskID: task.ID, GoalID: task.GoalID, Title: task.Title, Urgency: task.AIUrgency}
+		if b, ok := in.Urgency[task.ID]; ok {
+			entry.Urgency = b.Urgency
+		}
+		if !task.Deadline.IsZero() {
+			deadline := task.Deadline
+			entry.Deadline = &deadline
+		}
+
+		if entry.Deadline != nil && task.Deadline.Before(in.Now) {
+			entry.DaysOverdue = int(today.Sub(startOfLocalDay(task.Deadline, in.Location)).Hours()/24 + 0.5)
+			briefing.Overdue = append(briefing.Overdue, entry)
+		} else {
+			briefing.TopTasks = append(briefing.TopTasks, entry)
+		}
+	}
+
+	sort.SliceStable(briefing.Overdue, func(i, j int) bool {
+		return briefing.Overdue[i].Deadline.Before(*briefing.Overdue[j].Deadline)
+	})
+	sort.SliceStable(briefing.TopTasks, func(i, j int) bool {
+		return briefing.TopTasks[i].Urgency > briefing.TopTasks[j].Urgency
+	})
+	if len(briefing.TopTasks) > briefingTopTasks {
+		briefing.TopTasks = briefing.TopTasks[:briefingTopTasks]
+	}
+
+	for _, goal := range in.Goals {
+		stats, ok := in.Habits[goal.ID]
+		if !ok || stats.Pressure == 0 || stats.Remaining == 0 {
+			continue
+		}
+		briefing.HabitsAtRisk = append(briefing.HabitsAtRisk, BriefingHabit{
+			GoalID:        goal.ID,
+			Title:         goal.Title,
+			Remaining:     stats.Remaining,
+			DaysLeft:      stats.DaysLeft,
+			CurrentStreak: stats.CurrentStreak,
+			Pressure:      stats.Pressure,
+		})
+	}
+	sort.SliceStable(briefing.HabitsAtRisk, func(i, j int) bool {
+		return briefing.HabitsAtRisk[i].Pressure > briefing.HabitsAtRisk[j].Pressure
+	})
+
+	return briefing
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+func TestDailyBriefing(t *testing.T) {
+	now := time.Date(2026, 3, 11, 8, 0, 0, 0, time.UTC) // Wednesday
+	day := func(d, h int) time.Time { return time.Date(2026, 3, d, h, 0, 0, 0, time.UTC) }
+
+	goalID := uuid.New()
+	var tasks []models.Task
+	urgency := map[uuid.UUID]Breakdown{}
+	add := func(title string, deadline time.Time, score int) models.Task {
+		task := models.Task{ID: uuid.New(), GoalID: goalID, Title: title, Deadline: deadline}
+		tasks = append(tasks, task)
+		urgency[task.ID] = Breakdown{Urgency: score}
+		return task
+	}
+	add("Overdue a day", day(10, 23), 95)
+	add("Overdue a week", day(4, 23), 99)
+	for i, score := range []int{10, 70, 40, 90, 60, 20} {
+		add("Open "+string(rune('A'+i)), day(20, 23), score)
+	}
+	tasks = append(tasks,
+		models.Task{ID: uuid.New(), GoalID: goalID, Title: "Done", IsCompleted: true, Status: TaskDone, Deadline: day(1, 23)},
+		models.Task{ID: uuid.New(), GoalID: goalID, Title: "Cancelled", Status: TaskCancelled, Deadline: day(1, 23)},
+	)
+
+	steady := models.Goal{ID: uuid.New(), Title: "Read"}
+	slipping := models.Goal{ID: uuid.New(), Title: "Run"}
+	behind := models.Goal{ID: uuid.New(), Title: "Swim"}
+	habits := map[uuid.UUID]HabitStats{
+		steady.ID:   {Frequency: 3, ThisWeek: 3},
+		slipping.ID: {Frequency: 3, ThisWeek: 1, Remaining: 2, DaysLeft: 5, CurrentStreak: 4, Pressure: 1},
+		behind.ID:   {Frequency: 5, ThisWeek: 0, Remaining: 5, DaysLeft: 5, CurrentStreak: 2, Pressure: 3},
+	}
+
+	briefing := DailyBriefing(BriefingInput{
+		Now:      now,
+		Location: time.UTC,
+		Goals:    []models.Goal{steady, slipping, behind},
+		Tasks:    tasks,
+		Urgency:  urgency,
+		Habits:   habits,
+	})
+
+	if briefing.Date != "2026-03-11" {
+		t.Errorf("date = %s, want 2026-03-11", briefing.Date)
+	}
+
+	if len(briefing.Overdue) != 2 || briefing.Overdue[0].Title != "Overdue a week" || briefing.Overdue[1].Title != "Overdue a day" {
+		t.Fatalf("overdue = %+v, want the week-old task before the day-old one", briefing.Overdue)
+	}
+	if briefing.Overdue[0].DaysOverdue != 7 || briefing.Overdue[1].DaysOverdue != 1 {
+		t.Errorf("days overdue = %d, %d, want 7, 1", briefing.Overdue[0].DaysOverdue, briefing.Overdue[1].DaysOverdue)
+	}
+
+	want := []string{"Open D", "Open B", "Open E", "Open C", "Open F"}
+	if len(briefing.TopTasks) != len(want) {
+		t.Fatalf("top tasks = %+v, want %v", briefing.TopTasks, want)
+	}
+	for i, title := range want {
+		if briefing.TopTasks[i].Title != title {
+			t.Errorf("top task %d = %s, want %s", i, briefing.TopTasks[i].Title, title)
+		}
+	}
+	if briefing.TopTasks[0].Urgency != 90 {
+		t.Errorf("top urgency = %d, want the breakdown's 90", briefing.TopTasks[0].Urgency)
+	}
+
+	if len(briefing.HabitsAtRisk) != 2 || briefing.HabitsAtRisk[0].GoalID != behind.ID || briefing.HabitsAtRisk[1].GoalID != slipping.ID {
+		t.Fatalf("habits at risk = %+v, want Swim then Run", briefing.HabitsAtRisk)
+	}
+	if got := briefing.HabitsAtRisk[1]; got.Remaining != 2 || got.CurrentStreak != 4 {
+		t.Errorf("Run = %+v, want 2 check-ins left on a 4 week streak", got)
+	}
+}
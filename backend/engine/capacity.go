@@ -0,0 +1,215 @@
+package engine
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+const (
+	// TightLoad is the share of available hours above which a deadline counts as tight
+	TightLoad = 0.8
+	// maxLoad caps the load reported when no working hours are left at all
+	maxLoad = 10
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// WorkingHours is the weekly window in which the user works on tasks
+type WorkingHours struct {
+	StartHour int
+	EndHour   int
+	Days      map[time.Weekday]bool
+}
+
+// WorkingHoursFromPreferences reads the working window from the user's preferences
+func WorkingHoursFromPreferences(p models.UserPreferences) WorkingHours {
+	w := WorkingHours{StartHour: p.WorkStartHour, EndHour: p.WorkEndHour, Days: map[time.Weekday]bool{}}
+	for _, day := range strings.Split(p.WorkDays, ",") {
+		if weekday, ok := weekdays[strings.TrimSpace(day)]; ok {
+			w.Days[weekday] = true
+		}
+	}
+	return w
+}
+
+// HoursPerWeek is the total working time in a full week
+func (w WorkingHours) HoursPerWeek() float64 {
+	return float64(len(w.Days) * max(w.EndHour-w.StartHour, 0))
+}
+
+// Interval is a span of time between Start and End
+type Interval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Hours is the length of the interval in hours
+func (i Interval) Hours() float64 {
+	return i.End.Sub(i.Start).Hours()
+}
+
+// Windows lists the working periods between from and to, with days and hours read in loc
+func (w WorkingHours) Windows(from, to time.Time, loc *time.Location) []Interval {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if !to.After(from) {
+		return nil
+	}
+
+	local := from.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	var windows []Interval
+	for day.Before(to) {
+		if w.Days[day.Weekday()] {
+			open := time.Date(day.Year(), day.Month(), day.Day(), w.StartHour, 0, 0, 0, loc)
+			end := time.Date(day.Year(), day.Month(), day.Day(), w.EndHour, 0, 0, 0, loc)
+			if open.Before(from) {
+				open = from
+			}
+			if end.After(to) {
+				end = to
+			}
+			if end.After(open) {
+				windows = append(windows, Interval{Start: open, End: end})
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return windows
+}
+
+// AvailableHours sums the working time between from and to, with days and hours read in loc
+func (w WorkingHours) AvailableHours(from, to time.Time, loc *time.Location) float64 {
+	total := 0.0
+	for _, window := range w.Windows(from, to, loc) {
+		total += window.Hours()
+	}
+	return total
+}
+
+// CapacityLoad compares the effort due by a deadline with the working time left before it
+type CapacityLoad struct {
+	Deadline       time.Time `json:"deadline"`
+	RequiredHours  float64   `json:"required_hours"` // Estimated hours of every open task due by Deadline, including overdue ones
+	AvailableHours float64   `json:"available_hours"`
+	Load           float64   `json:"load"` // RequiredHours / AvailableHours, 1 or more means the deadline cannot be met
+}
+
+// Overloaded reports whether the work due by the deadline exceeds the hours left
+func (l CapacityLoad) Overloaded() bool {
+	return l.Load >= 1
+}
+
+// Tight reports whether the deadline leaves little slack
+func (l CapacityLoad) Tight() bool {
+	return l.Load >= TightLoad
+}
+
+// EffectiveDeadline is the task's own deadline, or its goal's when the task has none
+func EffectiveDeadline(task models.Task, goal models.Goal) time.Time {
+	if !task.Deadline.IsZero() {
+		return task.Deadline
+	}
+	if goal.Deadline != nil {
+		return *goal.Deadline
+	}
+	return time.Time{}
+}
+
+// TaskHours is the task's estimate, or defaultHours when it has none
+func TaskHours(task models.Task, defaultHours float64) float64 {
+	if task.EstimatedHours != nil && *task.EstimatedHours > 0 {
+		return *task.EstimatedHours
+	}
+	return defaultHours
+}
+
+// PlanCapacity walks the open tasks in deadline order and, for every task with a
+// deadline, compares all effort due by that deadline with the working hours left
+// until then. Overdue work is treated as due now, so it eats into every later deadline.
+func PlanCapacity(tasks []models.Task, goals map[uuid.UUID]models.Goal, now time.Time, loc *time.Location, hours WorkingHours, defaultHours float64) map[uuid.UUID]CapacityLoad {
+	type dueTask struct {
+		id       uuid.UUID
+		deadline time.Time
+		hours    float64
+	}
+
+	var due []dueTask
+	for _, task := range tasks {
+		if !TaskOpen(task) {
+			continue
+		}
+		deadline := EffectiveDeadline(task, goals[task.GoalID])
+		if deadline.IsZero() {
+			continue
+		}
+		due = append(due, dueTask{id: task.ID, deadline: deadline, hours: TaskHours(task, defaultHours)})
+	}
+
+	sort.SliceStable(due, func(i, j int) bool { return due[i].deadline.Before(due[j].deadline) })
+
+	loads := make(map[uuid.UUID]CapacityLoad, len(due))
+	required := 0.0
+	for i := 0; i < len(due); {
+		// Tasks sharing a deadline compete for the same hours
+		j := i
+		for j < len(due) && due[j].deadline.Equal(due[i].deadline) {
+			required += due[j].hours
+			j++
+		}
+
+		deadline := due[i].deadline
+		if deadline.After(now) {
+			load := CapacityLoad{
+				Deadline:       deadline,
+				RequiredHours:  required,
+				AvailableHours: hours.AvailableHours(now, deadline, loc),
+			}
+			load.Load = maxLoad
+			if load.AvailableHours > 0 {
+				load.Load = min(required/load.AvailableHours, maxLoad)
+			}
+			for _, task := range due[i:j] {
+				loads[task.id] = load
+			}
+		}
+		i = j
+	}
+
+	return loads
+}
+
+// applyCapacity raises deadline pressure when the deadline cannot be met in the
+// remaining working hours; it never lowers the calendar based pressure
+func applyCapacity(result deadlineResult, capacity *CapacityLoad) deadlineResult {
+	if capacity == nil || result.rule == RuleTaskOverdue {
+		return result
+	}
+
+	load := capacity.Load
+	result.capacityLoad = &load
+
+	switch {
+	case capacity.Overloaded() && result.pressure < 4:
+		result.pressure = 4
+		result.rule = RuleOverCapacity
+	case capacity.Tight() && result.pressure < 3:
+		result.pressure = 3
+		result.rule = RuleTightCapacity
+	}
+	return result
+}
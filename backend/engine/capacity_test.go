@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+func TestAvailableHoursSkipsWeekends(t *testing.T) {
+	hours := WorkingHoursFromPreferences(models.DefaultUserPreferences(uuid.New()))
+	from := time.Date(2026, 3, 6, 16, 0, 0, 0, time.UTC) // Friday
+	to := time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC)   // Monday
+
+	if got := hours.AvailableHours(from, to, time.UTC); got != 2 {
+		t.Errorf("AvailableHours = %v, want 2", got)
+	}
+	if got := hours.HoursPerWeek(); got != 40 {
+		t.Errorf("HoursPerWeek = %v, want 40", got)
+	}
+}
+
+func TestInfeasibleScheduleRaisesPressure(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC) // Monday
+	hours := WorkingHoursFromPreferences(models.DefaultUserPreferences(uuid.New()))
+	goal := models.Goal{ID: uuid.New(), CreatedAt: now.AddDate(0, 0, -1)}
+
+	big := models.Task{
+		ID: uuid.New(), GoalID: goal.ID, UserPriority: 1, EstimatedHours: floatPtr(40),
+		Deadline: time.Date(2026, 3, 6, 23, 59, 59, 0, time.UTC), CreatedAt: now, UpdatedAt: now,
+	}
+	small := models.Task{
+		ID: uuid.New(), GoalID: goal.ID, UserPriority: 1, EstimatedHours: floatPtr(0.25),
+		Deadline: time.Date(2026, 3, 13, 23, 59, 59, 0, time.UTC), CreatedAt: now, UpdatedAt: now,
+	}
+
+	loads := PlanCapacity([]models.Task{small, big}, map[uuid.UUID]models.Goal{goal.ID: goal}, now, time.UTC, hours, 2)
+
+	bigLoad := loads[big.ID]
+	if bigLoad.AvailableHours != 37 || !bigLoad.Overloaded() {
+		t.Fatalf("big task load = %+v, want 37 available hours and overloaded", bigLoad)
+	}
+	smallLoad := loads[small.ID]
+	if smallLoad.RequiredHours != 40.25 || smallLoad.Tight() {
+		t.Fatalf("small task load = %+v, want 40.25 required hours and slack", smallLoad)
+	}
+
+	scorer := DefaultScorer{Config: DefaultConfig()}
+	b := scorer.Explain(Input{Task: big, Goal: goal, TotalTasks: 2, Now: now, Capacity: &bigLoad})
+	if b.DeadlinePressure != 4 || b.DeadlineRule != RuleOverCapacity || b.CapacityLoad == nil {
+		t.Errorf("big task breakdown = %+v, want capacity rule with pressure 4", b)
+	}
+
+	b = scorer.Explain(Input{Task: small, Goal: goal, TotalTasks: 2, Now: now, Capacity: &smallLoad})
+	if b.DeadlinePressure != 0 || b.DeadlineRule != RuleTaskElapsed {
+		t.Errorf("small task breakdown = %+v, want untouched elapsed rule", b)
+	}
+}
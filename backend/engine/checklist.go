@@ -0,0 +1,26 @@
+package engine
+
+// TaskProgress is the share of a task that is done: all of it once completed, otherwise
+// the share of its checklist that is ticked
+func TaskProgress(completed bool, itemsDone, itemsTotal int) float64 {
+	if completed {
+		return 1
+	}
+	if itemsTotal == 0 {
+		return 0
+	}
+	return float64(itemsDone) / float64(itemsTotal)
+}
+
+// GoalProgress averages the progress of a goal's tasks, so half-finished checklists
+// count towards the goal before their tasks are completed
+func GoalProgress(taskProgress []float64) float64 {
+	if len(taskProgress) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, p := range taskProgress {
+		sum += p
+	}
+	return sum / float64(len(taskProgress))
+}
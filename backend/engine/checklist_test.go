@@ -0,0 +1,38 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTaskProgress(t *testing.T) {
+	tests := []struct {
+		name      string
+		completed bool
+		done      int
+		total     int
+		want      float64
+	}{
+		{"no checklist", false, 0, 0, 0},
+		{"completed without checklist", true, 0, 0, 1},
+		{"partly ticked", false, 1, 4, 0.25},
+		{"completed with items left", true, 1, 4, 1},
+		{"all ticked but not completed", false, 3, 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TaskProgress(tt.completed, tt.done, tt.total); got != tt.want {
+				t.Errorf("TaskProgress = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoalProgress(t *testing.T) {
+	if got := GoalProgress(nil); got != 0 {
+		t.Errorf("empty goal progress = %v, want 0", got)
+	}
+	if got := GoalProgress([]float64{1, 0.5, 0}); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("goal progress = %v, want 0.5", got)
+	}
+}
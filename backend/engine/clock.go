@@ -0,0 +1,25 @@
+package engine
+
+import "time"
+
+// Clock tells the engine and handlers what time it is, so urgency can be
+// computed for any moment instead of only for the wall clock
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock reads the wall clock
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock always returns the same instant, useful for tests and projections
+type FixedClock struct {
+	Time time.Time
+}
+
+func (f FixedClock) Now() time.Time {
+	return f.Time
+}
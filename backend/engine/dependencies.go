@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"github.com/google/uuid"
+)
+
+// DependencyEdge says that TaskID cannot start until DependsOnID is completed
+type DependencyEdge struct {
+	TaskID      uuid.UUID
+	DependsOnID uuid.UUID
+}
+
+// CreatesCycle reports whether adding "taskID depends on dependsOnID" to the edges
+// would make a task wait on itself, directly or through a chain of dependencies
+func CreatesCycle(edges []DependencyEdge, taskID, dependsOnID uuid.UUID) bool {
+	if taskID == dependsOnID {
+		return true
+	}
+
+	dependsOn := make(map[uuid.UUID][]uuid.UUID)
+	for _, e := range edges {
+		dependsOn[e.TaskID] = append(dependsOn[e.TaskID], e.DependsOnID)
+	}
+
+	// The new edge closes a cycle if dependsOnID already waits on taskID
+	visited := map[uuid.UUID]bool{dependsOnID: true}
+	stack := []uuid.UUID{dependsOnID}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, next := range dependsOn[current] {
+			if next == taskID {
+				return true
+			}
+			if !visited[next] {
+				visited[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+	return false
+}
+
+// BlockingUrgency returns, for every task that holds up a more urgent one directly or
+// through a chain, the highest urgency it holds up. blockers maps each task to the
+// open tasks it is waiting on.
+func BlockingUrgency(urgency map[uuid.UUID]int, blockers map[uuid.UUID][]uuid.UUID) map[uuid.UUID]int {
+	inherited := make(map[uuid.UUID]int)
+	for taskID, score := range urgency {
+		visited := map[uuid.UUID]bool{taskID: true}
+		stack := []uuid.UUID{taskID}
+		for len(stack) > 0 {
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, blocker := range blockers[current] {
+				if visited[blocker] {
+					continue
+				}
+				visited[blocker] = true
+				stack = append(stack, blocker)
+				if score > urgency[blocker] && score > inherited[blocker] {
+					inherited[blocker] = score
+				}
+			}
+		}
+	}
+	return inherited
+}
+
+// ApplyBlocking raises the urgency to that of the most urgent task this one holds up
+func (b *Breakdown) ApplyBlocking(urgency int) {
+	if urgency > b.Urgency {
+		b.BlockingUrgency = urgency
+		b.Urgency = urgency
+	}
+}
+
+// PathTask is an open task considered for a critical path
+type PathTask struct {
+	ID    uuid.UUID
+	Hours float64
+}
+
+// CriticalPath finds the chain of dependent tasks with the most estimated hours, in the
+// order they have to be done, together with its total. Only dependencies between the
+// given tasks count. Ties go to the task listed first.
+func CriticalPath(tasks []PathTask, blockers map[uuid.UUID][]uuid.UUID) ([]uuid.UUID, float64) {
+	index := make(map[uuid.UUID]int, len(tasks))
+	for i, t := range tasks {
+		index[t.ID] = i
+	}
+
+	// longest[i] is the heaviest chain ending at task i, previous[i] the task before it
+	longest := make([]float64, len(tasks))
+	previous := make([]int, len(tasks))
+	state := make([]int, len(tasks)) // 0 unvisited, 1 in progress, 2 done
+
+	var visit func(i int)
+	visit = func(i int) {
+		state[i] = 1
+		previous[i] = -1
+		best := 0.0
+		for _, blocker := range blockers[tasks[i].ID] {
+			j, ok := index[blocker]
+			if !ok || state[j] == 1 {
+				continue
+			}
+			if state[j] == 0 {
+				visit(j)
+			}
+			if longest[j] > best || (longest[j] == best && previous[i] >= 0 && j < previous[i]) {
+				best = longest[j]
+				previous[i] = j
+			}
+		}
+		longest[i] = best + tasks[i].Hours
+		state[i] = 2
+	}
+
+	end, total := -1, 0.0
+	for i := range tasks {
+		if state[i] == 0 {
+			visit(i)
+		}
+		if end < 0 || longest[i] > total {
+			end, total = i, longest[i]
+		}
+	}
+	if end < 0 {
+		return nil, 0
+	}
+
+	var path []uuid.UUID
+	for i := end; i >= 0; i = previous[i] {
+		path = append(path, tasks[i].ID)
+	}
+	for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+		path[l], path[r] = path[r], path[l]
+	}
+	return path, total
+}
@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCreatesCycle(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	edges := []DependencyEdge{{TaskID: b, DependsOnID: a}, {TaskID: c, DependsOnID: b}} // a -> b -> c
+
+	if !CreatesCycle(edges, a, c) {
+		t.Error("a depending on c should close the cycle a -> b -> c -> a")
+	}
+	if !CreatesCycle(edges, a, a) {
+		t.Error("a task depending on itself is a cycle")
+	}
+	if CreatesCycle(edges, c, a) {
+		t.Error("c depending on a directly as well is not a cycle")
+	}
+}
+
+func TestBlockingUrgency(t *testing.T) {
+	design, build, release, docs := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	blockers := map[uuid.UUID][]uuid.UUID{
+		build:   {design},
+		release: {build, docs},
+	}
+	urgency := map[uuid.UUID]int{design: 3, build: 5, release: 9, docs: 10}
+
+	inherited := BlockingUrgency(urgency, blockers)
+	if inherited[design] != 9 || inherited[build] != 9 {
+		t.Errorf("inherited = %v, want design and build raised to 9", inherited)
+	}
+	if _, ok := inherited[docs]; ok {
+		t.Error("docs is already more urgent than release and should not inherit")
+	}
+	if _, ok := inherited[release]; ok {
+		t.Error("release blocks nothing and should not inherit")
+	}
+
+	b := Breakdown{Urgency: 3}
+	b.ApplyBlocking(inherited[design])
+	if b.Urgency != 9 || b.BlockingUrgency != 9 {
+		t.Errorf("breakdown = %+v, want urgency 9 inherited", b)
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	design, build, test, release, docs := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	tasks := []PathTask{
+		{ID: design, Hours: 2},
+		{ID: build, Hours: 8},
+		{ID: test, Hours: 3},
+		{ID: release, Hours: 1},
+		{ID: docs, Hours: 5},
+	}
+	blockers := map[uuid.UUID][]uuid.UUID{
+		build:   {design},
+		test:    {build},
+		release: {test, docs},
+	}
+
+	path, total := CriticalPath(tasks, blockers)
+	if want := []uuid.UUID{design, build, test, release}; !slices.Equal(path, want) || total != 14 {
+		t.Errorf("path = %v (%v hours), want design, build, test, release (14 hours)", path, total)
+	}
+
+	if path, total := CriticalPath(nil, blockers); path != nil || total != 0 {
+		t.Errorf("empty goal path = %v (%v hours), want none", path, total)
+	}
+
+	path, _ = CriticalPath([]PathTask{{ID: docs, Hours: 1}, {ID: design, Hours: 1}}, nil)
+	if !slices.Equal(path, []uuid.UUID{docs}) {
+		t.Errorf("tie path = %v, want the first listed task", path)
+	}
+}
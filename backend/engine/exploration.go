@@ -0,0 +1,111 @@
+package engine
+
+import (
+	"time"
+)
+
+// Idle days after which an exploration goal's pressure steps up to 1, 2 and 3
+var explorationIdleDays = [3]int{3, 7, 14}
+
+// ExplorationEntry is one learning log entry as the engine sees it
+type ExplorationEntry struct {
+	At    time.Time
+	Hours float64
+}
+
+// ExplorationStats measures an exploration goal by time invested and recent activity
+// instead of task completion. Weeks run Monday to Sunday in the user's timezone.
+type ExplorationStats struct {
+	WeeklyBudget    *float64 `json:"weekly_hours_budget,omitempty"`
+	HoursThisWeek   float64  `json:"hours_this_week"`
+	BudgetUsed      *float64 `json:"budget_used,omitempty"` // Share of this week's budget already spent
+	EntriesThisWeek int      `json:"entries_this_week"`
+	ActiveWeeks     int      `json:"active_weeks"` // Of the last AdherenceWeeks full weeks, how many had any logged activity
+	TotalHours      float64  `json:"total_hours"`
+	TotalEntries    int      `json:"total_entries"`
+	IdleDays        int      `json:"idle_days"` // Days since the goal was last worked on
+	Pressure        int      `json:"pressure"`  // 0-3, never as pressing as a missed deadline
+	IdleRule        bool     `json:"-"`         // Pressure comes from idleness rather than the budget
+}
+
+// ExplorationProgress summarises the learning log of an exploration goal. lastActive is
+// the latest moment the goal was touched some other way (creation, task work).
+func ExplorationProgress(budget *float64, entries []ExplorationEntry, lastActive, now time.Time, loc *time.Location) ExplorationStats {
+	if loc == nil {
+		loc = time.UTC
+	}
+	stats := ExplorationStats{WeeklyBudget: budget}
+
+	today := startOfLocalDay(now, loc)
+	thisWeek := weekStart(today)
+	oldestWeek := thisWeek.AddDate(0, 0, -7*AdherenceWeeks)
+
+	activeWeeks := map[time.Time]bool{}
+	for _, entry := range entries {
+		if entry.At.After(now) {
+			continue
+		}
+		stats.TotalEntries++
+		stats.TotalHours += entry.Hours
+		if entry.At.After(lastActive) {
+			lastActive = entry.At
+		}
+
+		week := weekStart(startOfLocalDay(entry.At, loc))
+		switch {
+		case week.Equal(thisWeek):
+			stats.EntriesThisWeek++
+			stats.HoursThisWeek += entry.Hours
+		case !week.Before(oldestWeek):
+			activeWeeks[week] = true
+		}
+	}
+	stats.ActiveWeeks = len(activeWeeks)
+
+	if !lastActive.IsZero() {
+		stats.IdleDays = max(daysUntil(lastActive, now, loc), 0)
+	}
+
+	idlePressure := 0
+	for i, days := range explorationIdleDays {
+		if stats.IdleDays >= days {
+			idlePressure = i + 1
+		}
+	}
+
+	budgetPressure := 0
+	if budget != nil && *budget > 0 {
+		used := stats.HoursThisWeek / *budget
+		stats.BudgetUsed = &used
+
+		// How far behind an even spread of the budget over the week the goal is
+		expected := *budget * float64(weekdayOffset(today)) / 7
+		behind := (expected - stats.HoursThisWeek) / *budget
+		switch {
+		case behind >= 0.5:
+			budgetPressure = 3
+		case behind >= 0.25:
+			budgetPressure = 2
+		case behind > 0:
+			budgetPressure = 1
+		}
+	}
+
+	stats.Pressure = max(idlePressure, budgetPressure)
+	stats.IdleRule = idlePressure >= budgetPressure && idlePressure > 0
+	return stats
+}
+
+// applyExploration gives tasks of an exploration goal without their own deadline the goal's pressure
+func applyExploration(result deadlineResult, exploration *ExplorationStats) deadlineResult {
+	if exploration == nil || result.rule != RuleNoDeadline || exploration.Pressure == 0 {
+		return result
+	}
+
+	idleDays := exploration.IdleDays
+	rule := RuleExplorationBehind
+	if exploration.IdleRule {
+		rule = RuleExplorationIdle
+	}
+	return deadlineResult{pressure: exploration.Pressure, rule: rule, idleDays: &idleDays, budgetUsed: exploration.BudgetUsed}
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+)
+
+func TestExplorationProgress(t *testing.T) {
+	thursday := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+	created := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	budget := 7.0
+	entries := []ExplorationEntry{
+		{At: time.Date(2026, 2, 10, 18, 0, 0, 0, time.UTC), Hours: 2},
+		{At: time.Date(2026, 2, 24, 18, 0, 0, 0, time.UTC), Hours: 1},
+		{At: time.Date(2026, 3, 3, 18, 0, 0, 0, time.UTC), Hours: 3},
+		{At: time.Date(2026, 3, 9, 18, 0, 0, 0, time.UTC), Hours: 5}, // future, ignored
+	}
+
+	stats := ExplorationProgress(&budget, entries, created, thursday, time.UTC)
+
+	if stats.TotalEntries != 3 || stats.TotalHours != 6 || stats.HoursThisWeek != 3 || stats.EntriesThisWeek != 1 {
+		t.Errorf("totals = %+v, want 3 entries, 6h total, 3h this week", stats)
+	}
+	if stats.ActiveWeeks != 2 {
+		t.Errorf("active weeks = %d, want 2", stats.ActiveWeeks)
+	}
+	if stats.IdleDays != 2 {
+		t.Errorf("idle days = %d, want 2", stats.IdleDays)
+	}
+	// 3 of the 3h expected by Thursday morning: on pace
+	if stats.Pressure != 0 {
+		t.Errorf("pressure = %d, want 0 when on pace", stats.Pressure)
+	}
+
+	sunday := time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)
+	if stats := ExplorationProgress(&budget, entries, created, sunday, time.UTC); stats.Pressure != 2 || stats.IdleRule {
+		t.Errorf("Sunday = %+v, want budget pressure 2 with 3 of 6h expected", stats)
+	}
+
+	later := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	if stats := ExplorationProgress(nil, entries, created, later, time.UTC); stats.Pressure != 2 || !stats.IdleRule || stats.IdleDays != 11 {
+		t.Errorf("idle = %+v, want idle pressure 2 after 11 days", stats)
+	}
+}
+
+func TestExplorationPressureFeedsUrgency(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	exploration := ExplorationProgress(nil, nil, now.AddDate(0, 0, -10), now, time.UTC)
+
+	in := Input{Task: models.Task{UserPriority: 1, CreatedAt: now, UpdatedAt: now}, Now: now, Exploration: &exploration}
+	b := DefaultScorer{Config: DefaultConfig()}.Explain(in)
+
+	if b.DeadlineRule != RuleExplorationIdle || b.DeadlinePressure != 2 || b.IdleDays == nil || *b.IdleDays != 10 {
+		t.Errorf("breakdown = %+v, want exploration idle rule with pressure 2 after 10 days", b)
+	}
+}
@@ -0,0 +1,24 @@
+package engine
+
+type UrgencyPoint struct {
+	Date    string `json:"date"` // YYYY-MM-DD in the user's timezone
+	Urgency int    `json:"urgency"`
+}
+
+// ForecastUrgency projects a task's urgency for each of the next days starting at in.Now,
+// assuming nobody touches the task, the goal's progress stays where it is and the
+// capacity load stays at today's value
+func ForecastUrgency(scorer Scorer, in Input, days int) []UrgencyPoint {
+	in = normalizeInput(in)
+	from := in.Now
+
+	points := make([]UrgencyPoint, 0, days)
+	for i := 0; i < days; i++ {
+		in.Now = from.In(in.Location).AddDate(0, 0, i)
+		points = append(points, UrgencyPoint{
+			Date:    in.Now.Format("2006-01-02"),
+			Urgency: scorer.Score(in),
+		})
+	}
+	return points
+}
@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"math"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+)
+
+const (
+	// forecastWeeks is how many recent weeks of completions set a goal's pace
+	forecastWeeks = 4
+	// forecastHorizon caps how far ahead the working hours are searched for the remaining effort
+	forecastHorizon = 2 * 365 * 24 * time.Hour
+)
+
+// ForecastInput is what ForecastGoal needs to project a goal's completion
+type ForecastInput struct {
+	Goal         models.Goal
+	Completions  []time.Time   // When the goal's completed tasks were finished
+	Remaining    []models.Task // The goal's open tasks
+	Now          time.Time
+	Location     *time.Location
+	Hours        WorkingHours
+	DefaultHours float64 // Effort assumed for tasks without an estimate
+	Share        float64 // Share of the working hours the goal can count on, or 0 for all of them
+}
+
+// GoalForecast projects when a goal's remaining tasks will be done
+type GoalForecast struct {
+	RemainingTasks int        `json:"remaining_tasks"`
+	RemainingHours float64    `json:"remaining_hours"`
+	TasksPerWeek   float64    `json:"tasks_per_week"` // Recent completion pace
+	Projected      *time.Time `json:"projected"`      // Nil when there is neither a pace nor working hours to go on
+	Earliest       *time.Time `json:"earliest"`
+	Latest         *time.Time `json:"latest"` // Nil when the pace varies enough that the goal could stall
+	Deadline       *time.Time `json:"deadline,omitempty"`
+	AtRisk         bool       `json:"at_risk"` // Projected to finish after the deadline
+}
+
+// ForecastGoal projects a goal's completion from two limits. The pace is the goal's
+// completions per week over the last few weeks; the confidence range moves it one
+// standard deviation of the weekly counts either way. The effort limit is when the
+// remaining estimated hours fit into the goal's share of the user's working hours.
+// Every date is the later of the two; without a pace only the effort limit is known.
+func ForecastGoal(in ForecastInput) GoalForecast {
+	if in.Location == nil {
+		in.Location = time.UTC
+	}
+
+	f := GoalForecast{RemainingTasks: len(in.Remaining), Deadline: in.Goal.Deadline}
+	for _, task := range in.Remaining {
+		f.RemainingHours += TaskHours(task, in.DefaultHours)
+	}
+
+	if f.RemainingTasks == 0 {
+		var done time.Time
+		for _, at := range in.Completions {
+			if at.After(done) {
+				done = at
+			}
+		}
+		if done.IsZero() {
+			done = in.Now
+		}
+		f.Projected, f.Earliest, f.Latest = &done, &done, &done
+		return f
+	}
+
+	weeks := int(math.Ceil(in.Now.Sub(in.Goal.CreatedAt).Hours() / (24 * 7)))
+	weeks = clamp(weeks, 1, forecastWeeks)
+	counts := make([]float64, weeks)
+	for _, at := range in.Completions {
+		if at.After(in.Now) {
+			continue
+		}
+		week := int(in.Now.Sub(at).Hours() / (24 * 7))
+		if week < weeks {
+			counts[week]++
+		}
+	}
+	mean, stddev := meanAndStddev(counts)
+	f.TasksPerWeek = mean
+
+	hours := f.RemainingHours
+	if in.Share > 0 && in.Share < 1 {
+		hours /= in.Share
+	}
+	effort := finishByHours(hours, in.Now, in.Hours, in.Location)
+	atPace := func(perWeek float64) *time.Time {
+		if perWeek <= 0 {
+			return nil
+		}
+		at := in.Now.Add(time.Duration(float64(f.RemainingTasks) / perWeek * 7 * 24 * float64(time.Hour)))
+		return &at
+	}
+
+	if mean > 0 {
+		f.Projected = later(atPace(mean), effort)
+		f.Earliest = later(atPace(mean+stddev), effort)
+		if latest := atPace(mean - stddev); latest != nil {
+			f.Latest = later(latest, effort)
+		}
+	} else {
+		f.Projected, f.Earliest = effort, effort
+	}
+
+	if f.Deadline != nil {
+		f.AtRisk = f.Projected == nil || f.Projected.After(*f.Deadline)
+	}
+	return f
+}
+
+// finishByHours is when the given hours of work are done if every working window from
+// now on is spent on them, or nil when they do not fit within the forecast horizon
+func finishByHours(hours float64, now time.Time, working WorkingHours, loc *time.Location) *time.Time {
+	if hours <= 0 {
+		return &now
+	}
+	left := hours
+	for _, window := range working.Windows(now, now.Add(forecastHorizon), loc) {
+		if window.Hours() >= left {
+			at := window.Start.Add(time.Duration(left * float64(time.Hour)))
+			return &at
+		}
+		left -= window.Hours()
+	}
+	return nil
+}
+
+// later returns the later of two times, treating nil as unknown rather than early
+func later(a, b *time.Time) *time.Time {
+	switch {
+	case a == nil:
+		return b
+	case b == nil || a.After(*b):
+		return a
+	default:
+		return b
+	}
+}
+
+func meanAndStddev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+)
+
+func TestForecastGoal(t *testing.T) {
+	now := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC) // Wednesday
+	everyDay := WorkingHours{StartHour: 9, EndHour: 17, Days: map[time.Weekday]bool{}}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		everyDay.Days[day] = true
+	}
+	weekdays := WorkingHours{StartHour: 9, EndHour: 17, Days: map[time.Weekday]bool{
+		time.Monday: true, time.Tuesday: true, time.Wednesday: true, time.Thursday: true, time.Friday: true,
+	}}
+	hours := func(h float64) *float64 { return &h }
+	tasks := func(n int, estimate float64) []models.Task {
+		out := make([]models.Task, n)
+		for i := range out {
+			out[i].EstimatedHours = hours(estimate)
+		}
+		return out
+	}
+	// completed returns completion times with the given count in each week before now, most recent first
+	completed := func(perWeek ...int) []time.Time {
+		var out []time.Time
+		for week, count := range perWeek {
+			for range count {
+				out = append(out, now.AddDate(0, 0, -7*week-1))
+			}
+		}
+		return out
+	}
+	date := func(y int, m time.Month, d, h int) time.Time { return time.Date(y, m, d, h, 0, 0, 0, time.UTC) }
+	deadline := date(2026, 3, 20, 23)
+	goal := models.Goal{GoalType: "deadline", Deadline: &deadline, CreatedAt: now.AddDate(0, 0, -60)}
+
+	t.Run("steady pace past the deadline", func(t *testing.T) {
+		f := ForecastGoal(ForecastInput{Goal: goal, Completions: completed(2, 2, 2, 2), Remaining: tasks(4, 1), Now: now, Hours: everyDay})
+		want := date(2026, 3, 25, 12)
+		if f.TasksPerWeek != 2 || f.Projected == nil || !f.Projected.Equal(want) || !f.Earliest.Equal(want) || f.Latest == nil || !f.Latest.Equal(want) {
+			t.Errorf("forecast = %+v, want every date on %v", f, want)
+		}
+		if !f.AtRisk {
+			t.Error("expected the goal to be at risk")
+		}
+	})
+
+	t.Run("uneven pace could still make it", func(t *testing.T) {
+		f := ForecastGoal(ForecastInput{Goal: goal, Completions: completed(4, 0, 4, 0), Remaining: tasks(4, 1), Now: now, Hours: everyDay})
+		if f.Earliest == nil || !f.Earliest.Equal(date(2026, 3, 18, 12)) {
+			t.Errorf("earliest = %v, want a week out at four tasks a week", f.Earliest)
+		}
+		if f.Latest != nil {
+			t.Errorf("latest = %v, want none when the pace could drop to zero", f.Latest)
+		}
+		if !f.AtRisk {
+			t.Error("expected the goal to be at risk")
+		}
+	})
+
+	t.Run("no history falls back to the estimates", func(t *testing.T) {
+		early := date(2026, 3, 12, 23)
+		f := ForecastGoal(ForecastInput{Goal: models.Goal{Deadline: &early, CreatedAt: now.AddDate(0, 0, -1)}, Remaining: tasks(2, 8), Now: now, Hours: weekdays})
+		// 5h left on Wednesday, 8h on Thursday, the last 3h on Friday morning
+		if f.RemainingHours != 16 || f.Projected == nil || !f.Projected.Equal(date(2026, 3, 13, 12)) || !f.AtRisk {
+			t.Errorf("forecast = %+v", f)
+		}
+	})
+
+	t.Run("competing goals share the hours", func(t *testing.T) {
+		early := date(2026, 3, 12, 23)
+		f := ForecastGoal(ForecastInput{Goal: models.Goal{Deadline: &early, CreatedAt: now.AddDate(0, 0, -1)}, Remaining: tasks(1, 4), Now: now, Hours: weekdays, Share: 0.5})
+		// Half of every day is 8h of the calendar: 5h left on Wednesday, the last 3h on Thursday
+		if f.Projected == nil || !f.Projected.Equal(date(2026, 3, 12, 12)) || f.AtRisk {
+			t.Errorf("forecast = %+v, want Thursday noon with half the hours", f)
+		}
+	})
+
+	t.Run("estimates slow down a fast pace", func(t *testing.T) {
+		f := ForecastGoal(ForecastInput{Goal: goal, Completions: completed(20, 20, 20, 20), Remaining: tasks(2, 8), Now: now, Hours: weekdays})
+		if f.Projected == nil || !f.Projected.Equal(date(2026, 3, 13, 12)) || f.AtRisk {
+			t.Errorf("forecast = %+v, want the effort limit on Friday", f)
+		}
+	})
+
+	t.Run("finished goal", func(t *testing.T) {
+		last := now.AddDate(0, 0, -3)
+		f := ForecastGoal(ForecastInput{Goal: goal, Completions: []time.Time{now.AddDate(0, 0, -9), last}, Now: now, Hours: everyDay})
+		if f.RemainingTasks != 0 || f.Projected == nil || !f.Projected.Equal(last) || f.AtRisk {
+			t.Errorf("forecast = %+v, want finished at the last completion", f)
+		}
+	})
+}
+
+func TestForecastGoalLag(t *testing.T) {
+	now := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)
+	deadline := now.AddDate(0, 0, 2)
+	goal := models.Goal{GoalType: "deadline", Deadline: &deadline, CreatedAt: now.AddDate(0, 0, -30)}
+	task := models.Task{UserPriority: 1, Deadline: deadline, CreatedAt: goal.CreatedAt, UpdatedAt: now}
+	in := Input{Task: task, Goal: goal, TotalTasks: 4, CompletedTasks: 1, Now: now}
+	scorer := DefaultScorer{Config: DefaultConfig()}
+
+	if b := scorer.Explain(in); b.GoalLag != 2 || b.ProjectedCompletion != nil {
+		t.Fatalf("without a forecast goal lag = +%d, want +2 from the 25%% completion rate", b.GoalLag)
+	}
+
+	onTrack := now.AddDate(0, 0, 1)
+	late := now.AddDate(0, 0, 5)
+	tests := []struct {
+		name     string
+		forecast GoalForecast
+		want     int
+	}{
+		{"on track", GoalForecast{TasksPerWeek: 2, Projected: &onTrack, Earliest: &onTrack, Deadline: &deadline}, 0},
+		{"could make it", GoalForecast{TasksPerWeek: 2, Projected: &late, Earliest: &onTrack, Deadline: &deadline, AtRisk: true}, 1},
+		{"will miss it", GoalForecast{TasksPerWeek: 2, Projected: &late, Earliest: &late, Deadline: &deadline, AtRisk: true}, 2},
+		// Nothing done yet: the estimates alone look fine, but the completion rate's +2 stands
+		{"no pace yet", GoalForecast{Projected: &onTrack, Earliest: &onTrack, Deadline: &deadline}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in.Forecast = &tt.forecast
+			b := scorer.Explain(in)
+			if b.GoalLag != tt.want {
+				t.Errorf("goal lag = +%d, want +%d", b.GoalLag, tt.want)
+			}
+			if b.ProjectedCompletion == nil || *b.ProjectedCompletion != tt.forecast.Projected.Format("2006-01-02") {
+				t.Errorf("projected completion = %v", b.ProjectedCompletion)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package engine
+
+import (
+	"time"
+)
+
+// AdherenceWeeks is how many full weeks habit adherence looks back over
+const AdherenceWeeks = 4
+
+// HabitStats summarises a habit goal's check-ins against its weekly target.
+// Weeks run Monday to Sunday in the user's timezone.
+type HabitStats struct {
+	Frequency     int     `json:"frequency"`      // Target check-ins per week
+	ThisWeek      int     `json:"this_week"`      // Check-ins so far this week
+	Remaining     int     `json:"remaining"`      // Check-ins still needed this week
+	DaysLeft      int     `json:"days_left"`      // Days left this week, including today
+	CheckedToday  bool    `json:"checked_today"`  // Whether today already has a check-in
+	CurrentStreak int     `json:"current_streak"` // Consecutive weeks that met the target
+	LongestStreak int     `json:"longest_streak"`
+	Adherence     float64 `json:"adherence"` // Share of the target met over the last full weeks
+	Pressure      int     `json:"pressure"`  // 0-4, rises as the week runs out with check-ins missing
+}
+
+// HabitProgress computes streaks, adherence and pressure for a habit with the given
+// weekly frequency from its check-in dates (YYYY-MM-DD in loc). Weeks before the goal
+// was created are ignored, and the partial week it was created in never breaks a streak.
+func HabitProgress(frequency int, dates []string, createdAt, now time.Time, loc *time.Location) HabitStats {
+	if loc == nil {
+		loc = time.UTC
+	}
+	stats := HabitStats{Frequency: frequency}
+	if frequency <= 0 {
+		return stats
+	}
+
+	today := startOfLocalDay(now, loc)
+	thisWeek := weekStart(today)
+	firstWeek := weekStart(startOfLocalDay(createdAt, loc))
+
+	perWeek := map[time.Time]int{}
+	seen := map[string]bool{}
+	for _, date := range dates {
+		if seen[date] {
+			continue
+		}
+		seen[date] = true
+
+		day, err := time.ParseInLocation("2006-01-02", date, loc)
+		if err != nil || day.After(today) {
+			continue
+		}
+		perWeek[weekStart(day)]++
+		if day.Equal(today) {
+			stats.CheckedToday = true
+		}
+	}
+
+	stats.ThisWeek = perWeek[thisWeek]
+	stats.Remaining = max(frequency-stats.ThisWeek, 0)
+	stats.DaysLeft = 7 - weekdayOffset(today)
+
+	// Walk the full weeks since creation for streaks and adherence
+	streak := 0
+	adherenceWeeks, adherenceHits := 0, 0
+	for week := firstWeek; week.Before(thisWeek); week = week.AddDate(0, 0, 7) {
+		count := perWeek[week]
+		if count >= frequency {
+			streak++
+			stats.LongestStreak = max(stats.LongestStreak, streak)
+		} else if !week.Equal(firstWeek) {
+			streak = 0
+		}
+
+		if !week.Before(thisWeek.AddDate(0, 0, -7*AdherenceWeeks)) {
+			adherenceWeeks++
+			adherenceHits += min(count, frequency)
+		}
+	}
+
+	if stats.Remaining == 0 {
+		streak++
+		stats.LongestStreak = max(stats.LongestStreak, streak)
+	}
+	stats.CurrentStreak = streak
+
+	if adherenceWeeks > 0 {
+		stats.Adherence = float64(adherenceHits) / float64(frequency*adherenceWeeks)
+	} else {
+		stats.Adherence = float64(min(stats.ThisWeek, frequency)) / float64(frequency)
+	}
+
+	stats.Pressure = habitPressure(stats, weekdayOffset(today))
+	return stats
+}
+
+// habitPressure compares the check-ins still needed with the days left to do them
+func habitPressure(stats HabitStats, daysPassed int) int {
+	if stats.Remaining == 0 {
+		return 0
+	}
+
+	available := stats.DaysLeft
+	if stats.CheckedToday {
+		available--
+	}
+	if available <= 0 {
+		return 4
+	}
+
+	ratio := float64(stats.Remaining) / float64(available)
+	switch {
+	case ratio >= 1:
+		return 4
+	case ratio >= 0.75:
+		return 3
+	case ratio >= 0.5:
+		return 2
+	case float64(stats.ThisWeek) < float64(stats.Frequency*daysPassed)/7:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// applyHabit gives tasks of a habit goal without their own deadline the habit's pressure
+func applyHabit(result deadlineResult, habit *HabitStats) deadlineResult {
+	if habit == nil || result.rule != RuleNoDeadline {
+		return result
+	}
+	daysLeft := habit.DaysLeft
+	remaining := habit.Remaining
+	return deadlineResult{pressure: habit.Pressure, rule: RuleHabitBehind, daysLeft: &daysLeft, habitRemaining: &remaining}
+}
+
+func startOfLocalDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// weekdayOffset counts days since Monday
+func weekdayOffset(day time.Time) int {
+	return (int(day.Weekday()) + 6) % 7
+}
+
+func weekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -weekdayOffset(day))
+}
@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+)
+
+func TestHabitProgress(t *testing.T) {
+	createdAt := time.Date(2026, 2, 9, 8, 0, 0, 0, time.UTC) // Monday
+	dates := []string{
+		"2026-02-09", "2026-02-10", "2026-02-11", // met
+		"2026-02-16", "2026-02-17", // missed
+		"2026-02-23", "2026-02-24", "2026-02-25", // met
+		"2026-03-02", "2026-03-02", // this week, duplicate ignored
+	}
+
+	wednesday := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	stats := HabitProgress(3, dates, createdAt, wednesday, time.UTC)
+
+	if stats.ThisWeek != 1 || stats.Remaining != 2 || stats.DaysLeft != 5 {
+		t.Errorf("week progress = %+v, want 1 done, 2 remaining, 5 days left", stats)
+	}
+	if stats.CurrentStreak != 1 || stats.LongestStreak != 1 {
+		t.Errorf("streaks = %d/%d, want 1/1", stats.CurrentStreak, stats.LongestStreak)
+	}
+	if math.Abs(stats.Adherence-8.0/9.0) > 1e-9 {
+		t.Errorf("adherence = %v, want 8/9", stats.Adherence)
+	}
+	if stats.Pressure != 0 {
+		t.Errorf("pressure = %d, want 0 with plenty of days left", stats.Pressure)
+	}
+
+	saturday := time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC)
+	if stats := HabitProgress(3, dates, createdAt, saturday, time.UTC); stats.Pressure != 4 {
+		t.Errorf("pressure on Saturday = %d, want 4 with 2 check-ins left in 2 days", stats.Pressure)
+	}
+
+	met := append(dates, "2026-03-03", "2026-03-04")
+	if stats := HabitProgress(3, met, createdAt, wednesday, time.UTC); stats.Pressure != 0 || stats.CurrentStreak != 2 {
+		t.Errorf("met week = %+v, want no pressure and the streak extended", stats)
+	}
+}
+
+func TestHabitPressureFeedsUrgency(t *testing.T) {
+	now := time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC)
+	habit := HabitProgress(3, []string{"2026-03-02"}, now.AddDate(0, 0, -30), now, time.UTC)
+
+	in := Input{Task: models.Task{UserPriority: 1, CreatedAt: now, UpdatedAt: now}, Now: now, Habit: &habit}
+	b := DefaultScorer{Config: DefaultConfig()}.Explain(in)
+
+	if b.DeadlineRule != RuleHabitBehind || b.DeadlinePressure != 4 || b.HabitRemaining == nil || *b.HabitRemaining != 2 {
+		t.Errorf("breakdown = %+v, want habit rule with pressure 4 and 2 check-ins remaining", b)
+	}
+}
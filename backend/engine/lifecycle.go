@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"time"
+)
+
+// Goal statuses. Overdue is only ever set by the lifecycle itself.
+const (
+	GoalNotStarted = "not_started"
+	GoalInProgress = "in_progress"
+	GoalOverdue    = "overdue"
+	GoalCompleted  = "completed"
+	GoalAbandoned  = "abandoned"
+)
+
+// What caused a goal to change status
+const (
+	TriggerUser           = "user"
+	TriggerChat           = "chat"
+	TriggerTaskActivity   = "task_activity"
+	TriggerDeadlinePassed = "deadline_passed"
+	TriggerDeadlineMoved  = "deadline_moved"
+)
+
+// goalTransitions lists the statuses a user may move a goal to from each status
+var goalTransitions = map[string]map[string]bool{
+	GoalNotStarted: {GoalInProgress: true, GoalCompleted: true, GoalAbandoned: true},
+	GoalInProgress: {GoalNotStarted: true, GoalCompleted: true, GoalAbandoned: true},
+	GoalOverdue:    {GoalInProgress: true, GoalCompleted: true, GoalAbandoned: true},
+	GoalCompleted:  {GoalInProgress: true},
+	GoalAbandoned:  {GoalNotStarted: true, GoalInProgress: true},
+}
+
+// NormalizeGoalStatus maps the legacy column default "active" and empty values to not_started
+func NormalizeGoalStatus(status string) string {
+	if status == "" || status == "active" {
+		return GoalNotStarted
+	}
+	return status
+}
+
+// CanTransitionGoal reports whether a user may move a goal from one status to another
+func CanTransitionGoal(from, to string) bool {
+	return goalTransitions[NormalizeGoalStatus(from)][to]
+}
+
+// GoalState is what the lifecycle needs to know about a goal
+type GoalState struct {
+	Status         string
+	Deadline       *time.Time
+	TotalTasks     int
+	CompletedTasks int
+	StartedTasks   int    // Open tasks that were started or moved out of todo
+	Active         bool   // Touched outside of tasks, e.g. a habit check-in or learning log entry
+	BeforeOverdue  string // Status the goal had when it was flagged overdue, if known
+}
+
+// LifecycleStep is the status a goal should be in now and why it changed, if it did
+type LifecycleStep struct {
+	Status            string
+	Trigger           string // Empty when the status stays the same
+	ProposeCompletion bool   // Every task is done but the goal is still open
+}
+
+// NextGoalStatus applies the automatic transitions: the first task activity starts a
+// goal, a passed deadline with work left makes it overdue, and moving the deadline out
+// again lifts that. Completion is only ever proposed; the user confirms it.
+func NextGoalStatus(state GoalState, now time.Time) LifecycleStep {
+	status := NormalizeGoalStatus(state.Status)
+	step := LifecycleStep{Status: status}
+	if status == GoalCompleted || status == GoalAbandoned {
+		return step
+	}
+
+	allDone := state.TotalTasks > 0 && state.CompletedTasks == state.TotalTasks
+	step.ProposeCompletion = allDone
+	started := state.Active || state.CompletedTasks > 0 || state.StartedTasks > 0
+	deadlinePassed := state.Deadline != nil && now.After(*state.Deadline)
+
+	switch {
+	case deadlinePassed && !allDone && status != GoalOverdue:
+		step.Status, step.Trigger = GoalOverdue, TriggerDeadlinePassed
+	case !deadlinePassed && status == GoalOverdue:
+		step.Status, step.Trigger = GoalNotStarted, TriggerDeadlineMoved
+		if started || state.BeforeOverdue == GoalInProgress {
+			step.Status = GoalInProgress
+		}
+	case status == GoalNotStarted && started:
+		step.Status, step.Trigger = GoalInProgress, TriggerTaskActivity
+	}
+	return step
+}
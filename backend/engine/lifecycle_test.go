@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextGoalStatus(t *testing.T) {
+	now := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+	past := now.AddDate(0, 0, -1)
+	future := now.AddDate(0, 0, 7)
+
+	tests := []struct {
+		name    string
+		state   GoalState
+		status  string
+		trigger string
+		propose bool
+	}{
+		{"untouched goal stays put", GoalState{Status: GoalNotStarted, Deadline: &future, TotalTasks: 2}, GoalNotStarted, "", false},
+		{"legacy default is not started", GoalState{Status: "active", TotalTasks: 1}, GoalNotStarted, "", false},
+		{"first completed task starts the goal", GoalState{Status: GoalNotStarted, TotalTasks: 2, CompletedTasks: 1}, GoalInProgress, TriggerTaskActivity, false},
+		{"a task in progress starts the goal", GoalState{Status: GoalNotStarted, TotalTasks: 2, StartedTasks: 1}, GoalInProgress, TriggerTaskActivity, false},
+		{"a check-in starts the goal", GoalState{Status: GoalNotStarted, Active: true}, GoalInProgress, TriggerTaskActivity, false},
+		{"all tasks done proposes completion", GoalState{Status: GoalInProgress, TotalTasks: 2, CompletedTasks: 2}, GoalInProgress, "", true},
+		{"passed deadline with work left is overdue", GoalState{Status: GoalInProgress, Deadline: &past, TotalTasks: 2, CompletedTasks: 1}, GoalOverdue, TriggerDeadlinePassed, false},
+		{"passed deadline with everything done is not overdue", GoalState{Status: GoalInProgress, Deadline: &past, TotalTasks: 1, CompletedTasks: 1}, GoalInProgress, "", true},
+		{"moved deadline lifts overdue", GoalState{Status: GoalOverdue, Deadline: &future, TotalTasks: 2, CompletedTasks: 1}, GoalInProgress, TriggerDeadlineMoved, false},
+		{"moved deadline restores the earlier status", GoalState{Status: GoalOverdue, Deadline: &future, TotalTasks: 2, BeforeOverdue: GoalInProgress}, GoalInProgress, TriggerDeadlineMoved, false},
+		{"moved deadline on an untouched goal", GoalState{Status: GoalOverdue, Deadline: &future, TotalTasks: 2}, GoalNotStarted, TriggerDeadlineMoved, false},
+		{"completed goals are left alone", GoalState{Status: GoalCompleted, Deadline: &past, TotalTasks: 2}, GoalCompleted, "", false},
+		{"abandoned goals are left alone", GoalState{Status: GoalAbandoned, Deadline: &past, TotalTasks: 2, CompletedTasks: 2}, GoalAbandoned, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := NextGoalStatus(tt.state, now)
+			if step.Status != tt.status || step.Trigger != tt.trigger || step.ProposeCompletion != tt.propose {
+				t.Errorf("step = %+v, want status %s, trigger %q, propose %v", step, tt.status, tt.trigger, tt.propose)
+			}
+		})
+	}
+}
+
+func TestCanTransitionGoal(t *testing.T) {
+	allowed := [][2]string{
+		{GoalNotStarted, GoalInProgress},
+		{GoalOverdue, GoalCompleted},
+		{GoalCompleted, GoalInProgress},
+		{GoalAbandoned, GoalNotStarted},
+		{"active", GoalCompleted},
+	}
+	for _, pair := range allowed {
+		if !CanTransitionGoal(pair[0], pair[1]) {
+			t.Errorf("%s -> %s should be allowed", pair[0], pair[1])
+		}
+	}
+
+	forbidden := [][2]string{
+		{GoalInProgress, GoalOverdue}, // only the lifecycle sets overdue
+		{GoalCompleted, GoalAbandoned},
+		{GoalAbandoned, GoalCompleted},
+		{GoalNotStarted, "done"},
+	}
+	for _, pair := range forbidden {
+		if CanTransitionGoal(pair[0], pair[1]) {
+			t.Errorf("%s -> %s should not be allowed", pair[0], pair[1])
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+// minPartialHours is the smallest block worth planning for a task that doesn't fit whole
+const minPartialHours = 1
+
+// PlanCandidate is an open task that may be picked for today's plan
+type PlanCandidate struct {
+	Task     models.Task
+	Deadline time.Time // Task deadline or its goal's, zero if neither has one
+	Hours    float64   // Estimated hours, or the default estimate
+	Pinned   bool
+}
+
+// PlanPick is a task chosen for the day together with why it was chosen
+type PlanPick struct {
+	TaskID  uuid.UUID `json:"task_id"`
+	Hours   float64   `json:"hours"` // Hours planned today, less than the estimate when only part of the task fits
+	Reason  string    `json:"reason"`
+	Pinned  bool      `json:"pinned"`
+	Partial bool      `json:"partial"`
+}
+
+// DailyPlan is the proposed set of tasks for one day
+type DailyPlan struct {
+	AvailableHours float64    `json:"available_hours"`
+	PlannedHours   float64    `json:"planned_hours"`
+	Picks          []PlanPick `json:"picks"`
+}
+
+// BuildDailyPlan fills the available hours with the most pressing tasks. Pinned tasks are
+// always included first; the rest are ranked by whether they are overdue or due today,
+// then by urgency, deadline and size, and added while they fit. When the next task is too
+// big for what is left, a partial block of the remaining time is planned for it instead.
+func BuildDailyPlan(candidates []PlanCandidate, availableHours float64, now time.Time, loc *time.Location) DailyPlan {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	plan := DailyPlan{AvailableHours: availableHours, Picks: []PlanPick{}}
+
+	var ranked []PlanCandidate
+	for _, c := range candidates {
+		if c.Pinned {
+			plan.Picks = append(plan.Picks, PlanPick{TaskID: c.Task.ID, Hours: c.Hours, Reason: planReason(c, now, loc, "pinned by you"), Pinned: true})
+			plan.PlannedHours += c.Hours
+			continue
+		}
+		ranked = append(ranked, c)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if dueA, dueB := dueByToday(a, now, loc), dueByToday(b, now, loc); dueA != dueB {
+			return dueA
+		}
+		if a.Task.AIUrgency != b.Task.AIUrgency {
+			return a.Task.AIUrgency > b.Task.AIUrgency
+		}
+		if !a.Deadline.Equal(b.Deadline) {
+			if a.Deadline.IsZero() || b.Deadline.IsZero() {
+				return b.Deadline.IsZero()
+			}
+			return a.Deadline.Before(b.Deadline)
+		}
+		return a.Hours < b.Hours
+	})
+
+	for _, c := range ranked {
+		remaining := availableHours - plan.PlannedHours
+		if remaining <= 0 {
+			break
+		}
+
+		if c.Hours <= remaining {
+			plan.Picks = append(plan.Picks, PlanPick{TaskID: c.Task.ID, Hours: c.Hours, Reason: planReason(c, now, loc, "")})
+			plan.PlannedHours += c.Hours
+			continue
+		}
+
+		if remaining >= minPartialHours {
+			note := fmt.Sprintf("%s of %s today", formatHours(remaining), formatHours(c.Hours))
+			plan.Picks = append(plan.Picks, PlanPick{TaskID: c.Task.ID, Hours: remaining, Reason: planReason(c, now, loc, note), Partial: true})
+			plan.PlannedHours += remaining
+			break
+		}
+	}
+
+	return plan
+}
+
+func dueByToday(c PlanCandidate, now time.Time, loc *time.Location) bool {
+	return !c.Deadline.IsZero() && daysUntil(now, c.Deadline, loc) <= 0
+}
+
+// planReason explains a pick in a few words, e.g. "urgency 9, due tomorrow, about 2h"
+func planReason(c PlanCandidate, now time.Time, loc *time.Location, note string) string {
+	var parts []string
+	if note != "" && c.Pinned {
+		parts = append(parts, note)
+	}
+
+	parts = append(parts, fmt.Sprintf("urgency %d", c.Task.AIUrgency))
+
+	if !c.Deadline.IsZero() {
+		switch days := daysUntil(now, c.Deadline, loc); {
+		case c.Deadline.Before(now):
+			parts = append(parts, "overdue")
+		case days == 0:
+			parts = append(parts, "due today")
+		case days == 1:
+			parts = append(parts, "due tomorrow")
+		default:
+			parts = append(parts, fmt.Sprintf("due in %d days", days))
+		}
+	}
+
+	if note != "" && !c.Pinned {
+		parts = append(parts, note)
+	} else {
+		parts = append(parts, "about "+formatHours(c.Hours))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func formatHours(hours float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", hours), "0"), ".") + "h"
+}
@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+func TestBuildDailyPlan(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	endOfToday := time.Date(2026, 3, 2, 23, 59, 59, 0, time.UTC)
+
+	candidate := func(urgency int, deadline time.Time, hours float64, pinned bool) PlanCandidate {
+		return PlanCandidate{Task: models.Task{ID: uuid.New(), AIUrgency: urgency}, Deadline: deadline, Hours: hours, Pinned: pinned}
+	}
+
+	urgent := candidate(9, endOfToday.AddDate(0, 0, 1), 3, false)
+	someday := candidate(5, time.Time{}, 2, false)
+	dueToday := candidate(3, endOfToday, 1, false)
+	pinned := candidate(1, time.Time{}, 1, true)
+	big := candidate(8, time.Time{}, 10, false)
+
+	plan := BuildDailyPlan([]PlanCandidate{urgent, someday, dueToday, pinned, big}, 6, now, time.UTC)
+
+	wantOrder := []uuid.UUID{pinned.Task.ID, dueToday.Task.ID, urgent.Task.ID, big.Task.ID}
+	if len(plan.Picks) != len(wantOrder) {
+		t.Fatalf("got %d picks, want %d: %+v", len(plan.Picks), len(wantOrder), plan.Picks)
+	}
+	for i, id := range wantOrder {
+		if plan.Picks[i].TaskID != id {
+			t.Errorf("pick %d = %s, want %s", i, plan.Picks[i].TaskID, id)
+		}
+	}
+
+	last := plan.Picks[3]
+	if !last.Partial || last.Hours != 1 {
+		t.Errorf("oversized task should get a partial 1h block, got %+v", last)
+	}
+	if plan.PlannedHours != 6 {
+		t.Errorf("PlannedHours = %v, want 6", plan.PlannedHours)
+	}
+	if got := plan.Picks[2].Reason; got != "urgency 9, due tomorrow, about 3h" {
+		t.Errorf("reason = %q", got)
+	}
+	if got := plan.Picks[0].Reason; got != "pinned by you, urgency 1, about 1h" {
+		t.Errorf("pinned reason = %q", got)
+	}
+}
@@ -0,0 +1,255 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence frequencies, as in RFC 5545
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+)
+
+// maxOccurrenceScan bounds how many candidate days or months are examined when
+// looking for an occurrence, so a rule that never matches cannot loop forever
+const maxOccurrenceScan = 20000
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Recurrence is the subset of an RFC 5545 RRULE that tasks support: FREQ of DAILY,
+// WEEKLY or MONTHLY with INTERVAL, BYDAY (plain weekdays, daily and weekly only),
+// and COUNT or UNTIL. Monthly rules repeat on the first occurrence's day of the month
+// and skip months that don't have it.
+type Recurrence struct {
+	Freq     string
+	Interval int
+	ByDay    []time.Weekday
+	Count    int        // Total occurrences including the first; 0 means unlimited
+	Until    *time.Time // Last moment an occurrence may fall on
+}
+
+// ParseRRule parses a rule such as "FREQ=WEEKLY;BYDAY=FR;COUNT=10", with or without
+// the "RRULE:" prefix. A date-only UNTIL covers that whole day in loc.
+func ParseRRule(value string, loc *time.Location) (Recurrence, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	r := Recurrence{Interval: 1}
+
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return r, errors.New("recurrence rule is empty")
+	}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return r, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 || interval > 365 {
+				return r, errors.New("INTERVAL must be between 1 and 365")
+			}
+			r.Interval = interval
+		case "BYDAY":
+			for _, day := range strings.Split(strings.ToUpper(val), ",") {
+				weekday, ok := rruleWeekdays[day]
+				if !ok {
+					return r, fmt.Errorf("unsupported BYDAY value %q", day)
+				}
+				if !slices.Contains(r.ByDay, weekday) {
+					r.ByDay = append(r.ByDay, weekday)
+				}
+			}
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return r, errors.New("COUNT must be a positive number")
+			}
+			r.Count = count
+		case "UNTIL":
+			until, err := parseRRuleUntil(val, loc)
+			if err != nil {
+				return r, err
+			}
+			r.Until = &until
+		default:
+			return r, fmt.Errorf("unsupported recurrence rule part %q", key)
+		}
+	}
+
+	switch r.Freq {
+	case FreqDaily, FreqWeekly:
+	case FreqMonthly:
+		if len(r.ByDay) > 0 {
+			return r, errors.New("BYDAY is only supported with DAILY or WEEKLY")
+		}
+	case "":
+		return r, errors.New("FREQ is required")
+	default:
+		return r, fmt.Errorf("unsupported FREQ %q", r.Freq)
+	}
+
+	if r.Count > 0 && r.Until != nil {
+		return r, errors.New("COUNT and UNTIL cannot be combined")
+	}
+	return r, nil
+}
+
+func parseRRuleUntil(value string, loc *time.Location) (time.Time, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, nil
+	}
+	if day, err := time.ParseInLocation("20060102", value, loc); err == nil {
+		return day.AddDate(0, 0, 1).Add(-time.Second), nil
+	}
+	return time.Time{}, errors.New("UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ")
+}
+
+// String renders the rule in canonical RRULE form, without the prefix
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, code := range []string{"MO", "TU", "WE", "TH", "FR", "SA", "SU"} {
+			if slices.Contains(r.ByDay, rruleWeekdays[code]) {
+				days = append(days, code)
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Align moves t forward to the first day a series may start on: for rules with BYDAY,
+// the first listed weekday on or after t. The time of day is kept.
+func (r Recurrence) Align(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	t = t.In(loc)
+	if len(r.ByDay) == 0 {
+		return t
+	}
+	for i := 0; i < 7; i++ {
+		day := addLocalDays(t, i, loc)
+		if slices.Contains(r.ByDay, day.Weekday()) {
+			return day
+		}
+	}
+	return t
+}
+
+// OccurrenceAfter finds the first occurrence of a series starting at start that falls
+// strictly after t, with its 1-based position in the series. The start itself is
+// always the first occurrence. ok is false once COUNT or UNTIL has been reached.
+func (r Recurrence) OccurrenceAfter(start, t time.Time, loc *time.Location) (occurrence time.Time, index int, ok bool) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	start = start.In(loc)
+
+	current, index := start, 1
+	for scanned := 0; scanned < maxOccurrenceScan; scanned++ {
+		if r.Count > 0 && index > r.Count {
+			return time.Time{}, 0, false
+		}
+		if r.Until != nil && current.After(*r.Until) {
+			return time.Time{}, 0, false
+		}
+		if current.After(t) {
+			return current, index, true
+		}
+
+		next, found := r.next(start, current, loc)
+		if !found {
+			return time.Time{}, 0, false
+		}
+		current = next
+		index++
+	}
+	return time.Time{}, 0, false
+}
+
+// next steps from one occurrence to the following one
+func (r Recurrence) next(start, prev time.Time, loc *time.Location) (time.Time, bool) {
+	interval := max(r.Interval, 1)
+
+	if r.Freq == FreqMonthly {
+		y, m, _ := prev.Date()
+		for step := 1; step <= maxOccurrenceScan; step++ {
+			candidate := time.Date(y, m+time.Month(step), start.Day(), start.Hour(), start.Minute(), start.Second(), 0, loc)
+			months := (candidate.Year()-start.Year())*12 + int(candidate.Month()-start.Month())
+			if candidate.Day() == start.Day() && months%interval == 0 {
+				return candidate, true
+			}
+		}
+		return time.Time{}, false
+	}
+
+	startDay := startOfLocalDay(start, loc)
+	for i := 1; i <= maxOccurrenceScan; i++ {
+		candidate := addLocalDays(prev, i, loc)
+		day := startOfLocalDay(candidate, loc)
+
+		var inPeriod bool
+		switch r.Freq {
+		case FreqDaily:
+			inPeriod = daysUntil(startDay, day, loc)%interval == 0
+		case FreqWeekly:
+			inPeriod = (daysUntil(weekStart(startDay), weekStart(day), loc)/7)%interval == 0
+		}
+		if !inPeriod {
+			continue
+		}
+
+		switch {
+		case len(r.ByDay) > 0:
+			if slices.Contains(r.ByDay, candidate.Weekday()) {
+				return candidate, true
+			}
+		case r.Freq == FreqWeekly:
+			if candidate.Weekday() == start.Weekday() {
+				return candidate, true
+			}
+		default:
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// addLocalDays moves t by whole calendar days in loc, keeping the wall-clock time across DST changes
+func addLocalDays(t time.Time, days int, loc *time.Location) time.Time {
+	t = t.In(loc)
+	y, m, d := t.Date()
+	return time.Date(y, m, d+days, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
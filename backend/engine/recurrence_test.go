@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRRule(t *testing.T) {
+	r, err := ParseRRule("RRULE:freq=weekly;BYDAY=FR,MO,FR;COUNT=4", time.UTC)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if got := r.String(); got != "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=4" {
+		t.Errorf("String() = %q", got)
+	}
+
+	invalid := []string{
+		"",
+		"BYDAY=MO",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYDAY=MO",
+		"FREQ=DAILY;COUNT=3;UNTIL=20260401",
+		"FREQ=DAILY;BYMONTH=3",
+	}
+	for _, value := range invalid {
+		if _, err := ParseRRule(value, time.UTC); err == nil {
+			t.Errorf("ParseRRule(%q) should fail", value)
+		}
+	}
+}
+
+func TestOccurrenceAfter(t *testing.T) {
+	start := time.Date(2026, 3, 6, 23, 59, 59, 0, time.UTC) // Friday
+
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		after time.Time
+		want  time.Time
+		index int
+		ok    bool
+	}{
+		{"start is the first occurrence", "FREQ=DAILY", start, start.Add(-time.Hour), start, 1, true},
+		{"daily", "FREQ=DAILY", start, start, start.AddDate(0, 0, 1), 2, true},
+		{"every other day", "FREQ=DAILY;INTERVAL=2", start, start.AddDate(0, 0, 1), start.AddDate(0, 0, 2), 2, true},
+		{"weekly keeps the weekday", "FREQ=WEEKLY", start, start, start.AddDate(0, 0, 7), 2, true},
+		{"weekly on several days", "FREQ=WEEKLY;BYDAY=MO,FR", start, start, start.AddDate(0, 0, 3), 2, true},
+		{"fortnightly on several days", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", start, start, start.AddDate(0, 0, 10), 2, true},
+		{"missed occurrences are skipped", "FREQ=DAILY", start, start.AddDate(0, 0, 4).Add(time.Hour), start.AddDate(0, 0, 5), 6, true},
+		{"monthly skips short months", "FREQ=MONTHLY", time.Date(2026, 1, 31, 18, 0, 0, 0, time.UTC), time.Date(2026, 1, 31, 18, 0, 0, 0, time.UTC), time.Date(2026, 3, 31, 18, 0, 0, 0, time.UTC), 2, true},
+		{"count runs out", "FREQ=DAILY;COUNT=3", start, start.AddDate(0, 0, 2), time.Time{}, 0, false},
+		{"until runs out", "FREQ=WEEKLY;UNTIL=20260315", start, start.AddDate(0, 0, 7), time.Time{}, 0, false},
+		{"until includes its whole day", "FREQ=WEEKLY;UNTIL=20260313", start, start, start.AddDate(0, 0, 7), 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule, time.UTC)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+			got, index, ok := rule.OccurrenceAfter(tt.start, tt.after, time.UTC)
+			if ok != tt.ok || !got.Equal(tt.want) || index != tt.index {
+				t.Errorf("OccurrenceAfter = %s #%d (%v), want %s #%d (%v)", got, index, ok, tt.want, tt.index, tt.ok)
+			}
+		})
+	}
+}
+
+func TestAlignKeepsLocalTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("timezone data unavailable")
+	}
+	rule, _ := ParseRRule("FREQ=WEEKLY;BYDAY=MO", loc)
+
+	saturday := time.Date(2026, 3, 7, 23, 59, 59, 0, loc) // DST starts on Sunday the 8th
+	got := rule.Align(saturday, loc)
+	if want := time.Date(2026, 3, 9, 23, 59, 59, 0, loc); !got.Equal(want) {
+		t.Errorf("Align = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+// ReviewInput is what WeeklyReview needs to look back over a range of local days
+type ReviewInput struct {
+	From     time.Time // Start of the first local day
+	To       time.Time // Start of the day after the last one
+	Now      time.Time
+	Location *time.Location
+	Goals    []models.Goal
+	Tasks    []models.Task
+	Urgency  map[uuid.UUID]Breakdown // Current breakdown of open tasks, keyed by task ID
+	CheckIns map[uuid.UUID][]string  // Habit check-in dates (YYYY-MM-DD), keyed by goal ID
+}
+
+// ReviewTask is a task as listed in a review
+type ReviewTask struct {
+	TaskID      uuid.UUID  `json:"task_id"`
+	GoalID      uuid.UUID  `json:"goal_id"`
+	Title       string     `json:"title"`
+	Deadline    *time.Time `json:"deadline,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Urgency     int        `json:"urgency"`
+	IdleRatio   float64    `json:"idle_ratio,omitempty"` // Share of the task's window spent untouched, for stale tasks
+}
+
+// ReviewHabit is a habit goal that fell short of its target over the review range
+type ReviewHabit struct {
+	GoalID   uuid.UUID `json:"goal_id"`
+	Title    string    `json:"title"`
+	CheckIns int       `json:"check_ins"`
+	Expected int       `json:"expected"` // Weekly frequency scaled to the days of the range the goal existed
+}
+
+// Review looks back over a range of days
+type Review struct {
+	From            string        `json:"from"` // YYYY-MM-DD in the user's timezone
+	To              string        `json:"to"`   // Last day included
+	Completed       []ReviewTask  `json:"completed"`
+	Slipped         []ReviewTask  `json:"slipped"` // Deadlines in the range that passed before the task was done
+	Stale           []ReviewTask  `json:"stale"`   // Open tasks the urgency engine counts as stale
+	NeglectedHabits []ReviewHabit `json:"neglected_habits"`
+	NewGoals        []models.Goal `json:"new_goals"`
+}
+
+// WeeklyReview collects what was completed, what slipped and what was neglected
+// between in.From and in.To. Cancelled tasks are left out.
+func WeeklyReview(in ReviewInput) Review {
+	if in.Location == nil {
+		in.Location = time.UTC
+	}
+
+	review := Review{
+		From:            in.From.In(in.Location).Format("2006-01-02"),
+		To:              in.To.In(in.Location).AddDate(0, 0, -1).Format("2006-01-02"),
+		Completed:       []ReviewTask{},
+		Slipped:         []ReviewTask{},
+		Stale:           []ReviewTask{},
+		NeglectedHabits: []ReviewHabit{},
+		NewGoals:        []models.Goal{},
+	}
+	inRange := func(t time.Time) bool { return !t.Before(in.From) && t.Before(in.To) }
+
+	for _, task := range in.Tasks {
+		if task.Status == TaskCancelled {
+			continue
+		}
+		entry := ReviewTask{TaskID: task.ID, GoalID: task.GoalID, Title: task.Title, Urgency: task.AIUrgency}
+		if !task.Deadline.IsZero() {
+			deadline := task.Deadline
+			entry.Deadline = &deadline
+		}
+		completedAt, completed := CompletionTime(task)
+		if completed {
+			entry.CompletedAt = &completedAt
+		}
+
+		if completed && inRange(completedAt) {
+			review.Completed = append(review.Completed, entry)
+		}
+		if entry.Deadline != nil && inRange(task.Deadline) && task.Deadline.Before(in.Now) &&
+			(!completed || completedAt.After(task.Deadline)) {
+			review.Slipped = append(review.Slipped, entry)
+		}
+		if b, ok := in.Urgency[task.ID]; ok && TaskOpen(task) && b.Staleness > 0 {
+			entry.Urgency = b.Urgency
+			entry.IdleRatio = b.IdleRatio
+			review.Stale = append(review.Stale, entry)
+		}
+	}
+	sort.SliceStable(review.Stale, func(i, j int) bool { return review.Stale[i].IdleRatio > review.Stale[j].IdleRatio })
+
+	for _, goal := range in.Goals {
+		if inRange(goal.CreatedAt) {
+			review.NewGoals = append(review.NewGoals, goal)
+		}
+		if habit, ok := neglectedHabit(goal, in); ok {
+			review.NeglectedHabits = append(review.NeglectedHabits, habit)
+		}
+	}
+
+	return review
+}
+
+// neglectedHabit compares an active habit's check-ins over the review range with its
+// weekly frequency, counting only the days the goal existed
+func neglectedHabit(goal models.Goal, in ReviewInput) (ReviewHabit, bool) {
+	status := NormalizeGoalStatus(goal.Status)
+	if goal.GoalType != "habit" || goal.Frequency == nil || status == GoalCompleted || status == GoalAbandoned {
+		return ReviewHabit{}, false
+	}
+
+	from := in.From
+	if created := startOfLocalDay(goal.CreatedAt, in.Location); created.After(from) {
+		from = created
+	}
+	to := in.To
+	if today := startOfLocalDay(in.Now, in.Location).AddDate(0, 0, 1); today.Before(to) {
+		to = today
+	}
+	days := int(to.Sub(from).Hours()/24 + 0.5)
+	if days <= 0 {
+		return ReviewHabit{}, false
+	}
+
+	habit := ReviewHabit{GoalID: goal.ID, Title: goal.Title, Expected: *goal.Frequency * days / 7}
+	seen := map[string]bool{}
+	for _, date := range in.CheckIns[goal.ID] {
+		day, err := time.ParseInLocation("2006-01-02", date, in.Location)
+		if err != nil || seen[date] || day.Before(from) || !day.Before(to) {
+			continue
+		}
+		seen[date] = true
+		habit.CheckIns++
+	}
+	return habit, habit.CheckIns < habit.Expected
+}
@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+func TestWeeklyReview(t *testing.T) {
+	now := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC) // Wednesday
+	from := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)
+	day := func(d, h int) time.Time { return time.Date(2026, 3, d, h, 0, 0, 0, time.UTC) }
+	at := func(t time.Time) *time.Time { return &t }
+	three := 3
+
+	oldGoal := models.Goal{ID: uuid.New(), Title: "Ship", GoalType: "deadline", Status: GoalInProgress, CreatedAt: day(1, 9)}
+	newGoal := models.Goal{ID: uuid.New(), Title: "Learn Go", GoalType: "exploration", Status: GoalNotStarted, CreatedAt: day(9, 9)}
+	habit := models.Goal{ID: uuid.New(), Title: "Run", GoalType: "habit", Status: GoalInProgress, Frequency: &three, CreatedAt: day(1, 9)}
+	onTrack := models.Goal{ID: uuid.New(), Title: "Read", GoalType: "habit", Status: GoalInProgress, Frequency: &three, CreatedAt: day(1, 9)}
+	// Created yesterday, so one check-in short of three a week is not neglect yet
+	young := models.Goal{ID: uuid.New(), Title: "Stretch", GoalType: "habit", Status: GoalInProgress, Frequency: &three, CreatedAt: day(10, 9)}
+
+	done := models.Task{ID: uuid.New(), GoalID: oldGoal.ID, Title: "Done on time", IsCompleted: true, Status: TaskDone, Deadline: day(10, 23), CompletedAt: at(day(9, 10))}
+	late := models.Task{ID: uuid.New(), GoalID: oldGoal.ID, Title: "Done late", IsCompleted: true, Status: TaskDone, Deadline: day(6, 23), CompletedAt: at(day(8, 10))}
+	missed := models.Task{ID: uuid.New(), GoalID: oldGoal.ID, Title: "Still open", Deadline: day(7, 23)}
+	stale := models.Task{ID: uuid.New(), GoalID: oldGoal.ID, Title: "Untouched", Deadline: day(20, 23)}
+	staler := models.Task{ID: uuid.New(), GoalID: oldGoal.ID, Title: "Long untouched", Deadline: day(25, 23)}
+	earlier := models.Task{ID: uuid.New(), GoalID: oldGoal.ID, Title: "Done last week", IsCompleted: true, Status: TaskDone, CompletedAt: at(day(2, 10))}
+	dropped := models.Task{ID: uuid.New(), GoalID: oldGoal.ID, Title: "Dropped", Status: TaskCancelled, Deadline: day(7, 23)}
+	notDue := models.Task{ID: uuid.New(), GoalID: oldGoal.ID, Title: "Due tonight", Deadline: day(11, 23)}
+
+	review := WeeklyReview(ReviewInput{
+		From:     from,
+		To:       to,
+		Now:      now,
+		Location: time.UTC,
+		Goals:    []models.Goal{oldGoal, newGoal, habit, onTrack, young},
+		Tasks:    []models.Task{done, late, missed, stale, staler, earlier, dropped, notDue},
+		Urgency: map[uuid.UUID]Breakdown{
+			stale.ID:   {Urgency: 40, Staleness: 5, IdleRatio: 0.4},
+			staler.ID:  {Urgency: 30, Staleness: 8, IdleRatio: 0.7},
+			missed.ID:  {Urgency: 90},
+			dropped.ID: {Urgency: 10, Staleness: 5, IdleRatio: 0.9},
+		},
+		CheckIns: map[uuid.UUID][]string{
+			habit.ID:   {"2026-03-06", "2026-03-01"},
+			onTrack.ID: {"2026-03-05", "2026-03-07", "2026-03-10"},
+			young.ID:   {},
+		},
+	})
+
+	if review.From != "2026-03-05" || review.To != "2026-03-11" {
+		t.Errorf("range = %s to %s, want 2026-03-05 to 2026-03-11", review.From, review.To)
+	}
+
+	titles := func(tasks []ReviewTask) []string {
+		out := make([]string, len(tasks))
+		for i, task := range tasks {
+			out[i] = task.Title
+		}
+		return out
+	}
+	check := func(name string, got []string, want ...string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Errorf("%s = %v, want %v", name, got, want)
+			return
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s = %v, want %v", name, got, want)
+				return
+			}
+		}
+	}
+	check("completed", titles(review.Completed), "Done on time", "Done late")
+	check("slipped", titles(review.Slipped), "Done late", "Still open")
+	check("stale", titles(review.Stale), "Long untouched", "Untouched")
+	if review.Stale[0].Urgency != 30 {
+		t.Errorf("stale urgency = %d, want the breakdown's 30", review.Stale[0].Urgency)
+	}
+
+	if len(review.NeglectedHabits) != 1 || review.NeglectedHabits[0].GoalID != habit.ID {
+		t.Fatalf("neglected habits = %+v, want only Run", review.NeglectedHabits)
+	}
+	if got := review.NeglectedHabits[0]; got.CheckIns != 1 || got.Expected != 3 {
+		t.Errorf("Run = %d of %d check-ins, want 1 of 3", got.CheckIns, got.Expected)
+	}
+
+	if len(review.NewGoals) != 2 || review.NewGoals[0].ID != newGoal.ID || review.NewGoals[1].ID != young.ID {
+		t.Errorf("new goals = %+v, want Learn Go and Stretch", review.NewGoals)
+	}
+}
@@ -0,0 +1,162 @@
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+// minBlock is the shortest slot worth putting a task into
+const minBlock = 15 * time.Minute
+
+// ScheduleTask is an open task waiting to be placed on the calendar
+type ScheduleTask struct {
+	Task     models.Task
+	Deadline time.Time // Task deadline or its goal's, zero if neither has one
+	Hours    float64   // Estimated hours, or the default estimate
+}
+
+// ScheduledBlock is a concrete period reserved for working on a task
+type ScheduledBlock struct {
+	TaskID uuid.UUID `json:"task_id"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+}
+
+// UnscheduledTask is work that did not fit before the end of the schedule
+type UnscheduledTask struct {
+	TaskID         uuid.UUID `json:"task_id"`
+	RemainingHours float64   `json:"remaining_hours"`
+}
+
+// Schedule is the result of BuildSchedule
+type Schedule struct {
+	Blocks      []ScheduledBlock  `json:"blocks"`
+	Unscheduled []UnscheduledTask `json:"unscheduled"`
+	Late        []uuid.UUID       `json:"late"` // Tasks whose last block ends after their deadline
+}
+
+// BuildSchedule places tasks into the free parts of the user's working hours between
+// from and to. Tasks go earliest deadline first, then by urgency and priority, and a
+// task may be split over several slots. Busy blocks are never scheduled over. A task
+// with open blockers goes after its blockers' blocks, and stays unscheduled when one
+// of them does not fit.
+func BuildSchedule(tasks []ScheduleTask, blockers map[uuid.UUID][]uuid.UUID, hours WorkingHours, busy []Interval, from, to time.Time, loc *time.Location) Schedule {
+	schedule := Schedule{Blocks: []ScheduledBlock{}, Unscheduled: []UnscheduledTask{}, Late: []uuid.UUID{}}
+	free := subtractIntervals(hours.Windows(from, to, loc), busy)
+
+	ordered := make([]ScheduleTask, len(tasks))
+	copy(ordered, tasks)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if !a.Deadline.Equal(b.Deadline) {
+			if a.Deadline.IsZero() || b.Deadline.IsZero() {
+				return b.Deadline.IsZero()
+			}
+			return a.Deadline.Before(b.Deadline)
+		}
+		if a.Task.AIUrgency != b.Task.AIUrgency {
+			return a.Task.AIUrgency > b.Task.AIUrgency
+		}
+		return a.Task.UserPriority > b.Task.UserPriority
+	})
+
+	// fitted records every task placed so far and whether all of its work fitted
+	fitted := make(map[uuid.UUID]bool, len(ordered))
+	slot := 0
+	for len(ordered) > 0 {
+		next := nextReady(ordered, blockers, fitted)
+		t := ordered[next]
+		ordered = append(ordered[:next], ordered[next+1:]...)
+
+		remaining := time.Duration(t.Hours * float64(time.Hour))
+		var finished time.Time
+
+		startable := true
+		for _, id := range blockers[t.Task.ID] {
+			startable = startable && fitted[id]
+		}
+
+		for startable && remaining > 0 && slot < len(free) {
+			window := &free[slot]
+			available := window.End.Sub(window.Start)
+			if available < minBlock && available < remaining {
+				slot++
+				continue
+			}
+
+			length := min(available, remaining)
+			block := ScheduledBlock{TaskID: t.Task.ID, Start: window.Start, End: window.Start.Add(length)}
+			schedule.Blocks = append(schedule.Blocks, block)
+
+			window.Start = block.End
+			remaining -= length
+			finished = block.End
+			if !window.End.After(window.Start) {
+				slot++
+			}
+		}
+
+		fitted[t.Task.ID] = remaining <= 0
+		if remaining > 0 {
+			schedule.Unscheduled = append(schedule.Unscheduled, UnscheduledTask{TaskID: t.Task.ID, RemainingHours: remaining.Hours()})
+			if !t.Deadline.IsZero() && t.Deadline.Before(to) {
+				schedule.Late = append(schedule.Late, t.Task.ID)
+			}
+			continue
+		}
+
+		if !t.Deadline.IsZero() && finished.After(t.Deadline) {
+			schedule.Late = append(schedule.Late, t.Task.ID)
+		}
+	}
+
+	return schedule
+}
+
+// nextReady is the index of the first task whose blockers have all been placed. When
+// every task is still waiting, e.g. on a blocker outside the schedule, it is the first.
+func nextReady(pending []ScheduleTask, blockers map[uuid.UUID][]uuid.UUID, placed map[uuid.UUID]bool) int {
+	for i, t := range pending {
+		ready := true
+		for _, id := range blockers[t.Task.ID] {
+			if _, ok := placed[id]; !ok {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			return i
+		}
+	}
+	return 0
+}
+
+// subtractIntervals removes the busy periods from the sorted windows
+func subtractIntervals(windows []Interval, busy []Interval) []Interval {
+	sorted := make([]Interval, len(busy))
+	copy(sorted, busy)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	var free []Interval
+	for _, window := range windows {
+		start := window.Start
+		for _, b := range sorted {
+			if !b.End.After(start) || !b.Start.Before(window.End) {
+				continue
+			}
+			if b.Start.After(start) {
+				free = append(free, Interval{Start: start, End: b.Start})
+			}
+			if b.End.After(start) {
+				start = b.End
+			}
+		}
+		if window.End.After(start) {
+			free = append(free, Interval{Start: start, End: window.End})
+		}
+	}
+	return free
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+func TestBuildScheduleAroundBusyBlocks(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) // Monday
+	at := func(day, hour int) time.Time { return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC) }
+	hours := WorkingHoursFromPreferences(models.DefaultUserPreferences(uuid.New()))
+
+	dueNoon := ScheduleTask{Task: models.Task{ID: uuid.New(), AIUrgency: 3}, Deadline: at(2, 12), Hours: 2}
+	dueTonight := ScheduleTask{Task: models.Task{ID: uuid.New(), AIUrgency: 5}, Deadline: at(2, 23), Hours: 3}
+	noDeadline := ScheduleTask{Task: models.Task{ID: uuid.New(), AIUrgency: 9}, Hours: 2}
+
+	busy := []Interval{{Start: at(2, 10), End: at(2, 12)}}
+	schedule := BuildSchedule([]ScheduleTask{noDeadline, dueTonight, dueNoon}, nil, hours, busy, now, at(3, 23), time.UTC)
+
+	want := []ScheduledBlock{
+		{TaskID: dueNoon.Task.ID, Start: at(2, 9), End: at(2, 10)},
+		{TaskID: dueNoon.Task.ID, Start: at(2, 12), End: at(2, 13)},
+		{TaskID: dueTonight.Task.ID, Start: at(2, 13), End: at(2, 16)},
+		{TaskID: noDeadline.Task.ID, Start: at(2, 16), End: at(2, 17)},
+		{TaskID: noDeadline.Task.ID, Start: at(3, 9), End: at(3, 10)},
+	}
+	if len(schedule.Blocks) != len(want) {
+		t.Fatalf("got %d blocks, want %d: %+v", len(schedule.Blocks), len(want), schedule.Blocks)
+	}
+	for i, block := range schedule.Blocks {
+		if block != want[i] {
+			t.Errorf("block %d = %+v, want %+v", i, block, want[i])
+		}
+	}
+
+	if len(schedule.Late) != 1 || schedule.Late[0] != dueNoon.Task.ID {
+		t.Errorf("late = %v, want only the task due at noon", schedule.Late)
+	}
+	if len(schedule.Unscheduled) != 0 {
+		t.Errorf("unscheduled = %v, want none", schedule.Unscheduled)
+	}
+}
+
+func TestBuildScheduleReportsOverflow(t *testing.T) {
+	now := time.Date(2026, 3, 2, 16, 0, 0, 0, time.UTC)
+	hours := WorkingHoursFromPreferences(models.DefaultUserPreferences(uuid.New()))
+	task := ScheduleTask{Task: models.Task{ID: uuid.New()}, Hours: 3}
+
+	schedule := BuildSchedule([]ScheduleTask{task}, nil, hours, nil, now, time.Date(2026, 3, 2, 23, 0, 0, 0, time.UTC), time.UTC)
+
+	if len(schedule.Unscheduled) != 1 || schedule.Unscheduled[0].RemainingHours != 2 {
+		t.Errorf("unscheduled = %+v, want 2 hours left over", schedule.Unscheduled)
+	}
+}
+
+func TestBuildScheduleWaitsForBlockers(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) // Monday
+	at := func(day, hour int) time.Time { return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC) }
+	hours := WorkingHoursFromPreferences(models.DefaultUserPreferences(uuid.New()))
+
+	// Launch is due first but waits on design, and cleanup waits on a task too big to fit
+	design := ScheduleTask{Task: models.Task{ID: uuid.New()}, Deadline: at(6, 17), Hours: 2}
+	launch := ScheduleTask{Task: models.Task{ID: uuid.New()}, Deadline: at(2, 12), Hours: 1}
+	migrate := ScheduleTask{Task: models.Task{ID: uuid.New()}, Hours: 20}
+	cleanup := ScheduleTask{Task: models.Task{ID: uuid.New()}, Hours: 1}
+	blockers := map[uuid.UUID][]uuid.UUID{
+		launch.Task.ID:  {design.Task.ID},
+		cleanup.Task.ID: {migrate.Task.ID},
+	}
+
+	schedule := BuildSchedule([]ScheduleTask{launch, design, cleanup, migrate}, blockers, hours, nil, now, at(3, 23), time.UTC)
+
+	want := []ScheduledBlock{
+		{TaskID: design.Task.ID, Start: at(2, 9), End: at(2, 11)},
+		{TaskID: launch.Task.ID, Start: at(2, 11), End: at(2, 12)},
+		{TaskID: migrate.Task.ID, Start: at(2, 12), End: at(2, 17)},
+		{TaskID: migrate.Task.ID, Start: at(3, 9), End: at(3, 17)},
+	}
+	if len(schedule.Blocks) != len(want) {
+		t.Fatalf("got %d blocks, want %d: %+v", len(schedule.Blocks), len(want), schedule.Blocks)
+	}
+	for i, block := range schedule.Blocks {
+		if block != want[i] {
+			t.Errorf("block %d = %+v, want %+v", i, block, want[i])
+		}
+	}
+
+	if len(schedule.Unscheduled) != 2 || schedule.Unscheduled[1].TaskID != cleanup.Task.ID || schedule.Unscheduled[1].RemainingHours != 1 {
+		t.Errorf("unscheduled = %+v, want the rest of migrate and all of cleanup", schedule.Unscheduled)
+	}
+}
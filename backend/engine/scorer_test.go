@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestDefaultSettingsMatchCalculateUrgency(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	task := models.Task{
+		UserPriority: 2,
+		Deadline:     now.AddDate(0, 0, 5),
+		CreatedAt:    now.AddDate(0, 0, -10),
+		UpdatedAt:    now.AddDate(0, 0, -6),
+	}
+	goal := models.Goal{CreatedAt: now.AddDate(0, 0, -20)}
+
+	scorer := NewScorer(DefaultSettings(uuid.New()))
+	got := scorer.Score(Input{Task: task, Goal: goal, TotalTasks: 4, CompletedTasks: 1, Now: now})
+	want := CalculateUrgency(task, goal, 4, 1, now, time.UTC)
+
+	if got != want {
+		t.Errorf("default settings scored %d, CalculateUrgency scored %d", got, want)
+	}
+}
+
+func TestCustomThresholdsAndWeights(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	task := models.Task{
+		UserPriority: 1,
+		Deadline:     now.AddDate(0, 0, 5),
+		CreatedAt:    now.AddDate(0, 0, -5),
+		UpdatedAt:    now,
+	}
+	in := Input{Task: task, Goal: models.Goal{CreatedAt: task.CreatedAt}, TotalTasks: 2, CompletedTasks: 2, Now: now}
+
+	settings := DefaultSettings(uuid.New())
+	base := NewScorer(settings).Score(in) // 1 + 2 (half the timeline used)
+
+	settings.WarningDays = 7
+	warned := NewScorer(settings).Score(in) // 5 days left is now inside the warning window
+
+	settings.DeadlineWeight = 0
+	ignored := NewScorer(settings).Score(in)
+
+	if base != 3 || warned != 4 || ignored != 1 {
+		t.Errorf("got base=%d warned=%d ignored=%d, want 3, 4, 1", base, warned, ignored)
+	}
+}
+
+func TestWSJFPrefersSmallerJobs(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	goal := models.Goal{Deadline: timePtr(now.AddDate(0, 0, 2)), CreatedAt: now.AddDate(0, 0, -10)}
+
+	settings := DefaultSettings(uuid.New())
+	settings.Strategy = StrategyWSJF
+	scorer := NewScorer(settings)
+
+	quick := models.Task{UserPriority: 2, EstimatedHours: floatPtr(0.25), CreatedAt: now, UpdatedAt: now}
+	big := models.Task{UserPriority: 2, EstimatedHours: floatPtr(40), CreatedAt: now, UpdatedAt: now}
+
+	quickScore := scorer.Score(Input{Task: quick, Goal: goal, TotalTasks: 4, CompletedTasks: 1, Now: now})
+	bigScore := scorer.Score(Input{Task: big, Goal: goal, TotalTasks: 4, CompletedTasks: 1, Now: now})
+
+	if quickScore <= bigScore {
+		t.Errorf("quick task scored %d, 40h task scored %d; want quick task ranked higher", quickScore, bigScore)
+	}
+	if quickScore < 1 || quickScore > 10 || bigScore < 1 || bigScore > 10 {
+		t.Errorf("scores out of range: %d, %d", quickScore, bigScore)
+	}
+}
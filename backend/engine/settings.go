@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+const (
+	StrategyDefault = "default"
+	StrategyWSJF    = "wsjf"
+)
+
+// Strategies lists the scoring strategies a user can pick
+var Strategies = map[string]bool{
+	StrategyDefault: true,
+	StrategyWSJF:    true,
+}
+
+// DefaultSettings returns the settings used until a user customizes their scoring
+func DefaultSettings(userID uuid.UUID) models.UrgencySettings {
+	cfg := DefaultConfig()
+	return models.UrgencySettings{
+		UserID:               userID,
+		Strategy:             StrategyDefault,
+		PriorityWeight:       cfg.PriorityWeight,
+		DeadlineWeight:       cfg.DeadlineWeight,
+		GoalLagWeight:        cfg.GoalLagWeight,
+		StalenessWeight:      cfg.StalenessWeight,
+		ElapsedThreshold1:    cfg.ElapsedThresholds[0],
+		ElapsedThreshold2:    cfg.ElapsedThresholds[1],
+		ElapsedThreshold3:    cfg.ElapsedThresholds[2],
+		ElapsedThreshold4:    cfg.ElapsedThresholds[3],
+		CriticalDays:         cfg.CriticalDays,
+		WarningDays:          cfg.WarningDays,
+		StaleIdleRatio:       cfg.StaleIdleRatio,
+		DefaultEstimateHours: 2,
+	}
+}
+
+// ConfigFromSettings converts persisted settings into an engine Config
+func ConfigFromSettings(s models.UrgencySettings) Config {
+	return Config{
+		PriorityWeight:    s.PriorityWeight,
+		DeadlineWeight:    s.DeadlineWeight,
+		GoalLagWeight:     s.GoalLagWeight,
+		StalenessWeight:   s.StalenessWeight,
+		ElapsedThresholds: [4]float64{s.ElapsedThreshold1, s.ElapsedThreshold2, s.ElapsedThreshold3, s.ElapsedThreshold4},
+		CriticalDays:      s.CriticalDays,
+		WarningDays:       s.WarningDays,
+		StaleIdleRatio:    s.StaleIdleRatio,
+	}
+}
+
+// NewScorer builds the Scorer selected by the user's settings, falling back to the default formula
+func NewScorer(s models.UrgencySettings) Scorer {
+	cfg := ConfigFromSettings(s)
+	switch s.Strategy {
+	case StrategyWSJF:
+		return WSJFScorer{Config: cfg, DefaultEstimateHours: s.DefaultEstimateHours}
+	default:
+		return DefaultScorer{Config: cfg}
+	}
+}
@@ -0,0 +1,95 @@
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CriticalUrgency is the score at which a task is considered critical
+const CriticalUrgency = 9
+
+// UrgencySample is one recorded urgency value for a task
+type UrgencySample struct {
+	TaskID  uuid.UUID
+	Urgency int
+	At      time.Time
+}
+
+// TrendPoint aggregates the urgency of a goal's tasks as of the end of one local day
+type TrendPoint struct {
+	Date           string  `json:"date"`
+	AverageUrgency float64 `json:"average_urgency"`
+	MaxUrgency     int     `json:"max_urgency"`
+	CriticalTasks  int     `json:"critical_tasks"`
+	ScoredTasks    int     `json:"scored_tasks"`
+}
+
+// UrgencyTrend replays urgency samples into one point per day for the given number of
+// days ending on the local day of now. Each task contributes its latest score recorded
+// before the end of that day; tasks without a score yet are left out.
+func UrgencyTrend(samples []UrgencySample, now time.Time, days int, loc *time.Location) []TrendPoint {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	sorted := make([]UrgencySample, len(samples))
+	copy(sorted, samples)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].At.Before(sorted[j].At) })
+
+	local := now.In(loc)
+	first := time.Date(local.Year(), local.Month(), local.Day()-(days-1), 0, 0, 0, 0, loc)
+
+	latest := make(map[uuid.UUID]int)
+	next := 0
+	points := make([]TrendPoint, 0, days)
+
+	for i := range days {
+		day := first.AddDate(0, 0, i)
+		end := day.AddDate(0, 0, 1)
+
+		for next < len(sorted) && sorted[next].At.Before(end) {
+			latest[sorted[next].TaskID] = sorted[next].Urgency
+			next++
+		}
+
+		point := TrendPoint{Date: day.Format("2006-01-02"), ScoredTasks: len(latest)}
+		total := 0
+		for _, urgency := range latest {
+			total += urgency
+			point.MaxUrgency = max(point.MaxUrgency, urgency)
+			if urgency >= CriticalUrgency {
+				point.CriticalTasks++
+			}
+		}
+		if len(latest) > 0 {
+			point.AverageUrgency = float64(total) / float64(len(latest))
+		}
+
+		points = append(points, point)
+	}
+
+	return points
+}
+
+// CriticalSince returns when a task entered its current critical streak, or nil if
+// its latest recorded score is below CriticalUrgency
+func CriticalSince(samples []UrgencySample) *time.Time {
+	sorted := make([]UrgencySample, len(samples))
+	copy(sorted, samples)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].At.Before(sorted[j].At) })
+
+	var since *time.Time
+	for _, sample := range sorted {
+		if sample.Urgency < CriticalUrgency {
+			since = nil
+			continue
+		}
+		if since == nil {
+			at := sample.At
+			since = &at
+		}
+	}
+	return since
+}
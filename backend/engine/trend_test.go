@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestUrgencyTrend(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	a, b := uuid.New(), uuid.New()
+
+	samples := []UrgencySample{
+		{TaskID: a, Urgency: 4, At: now.AddDate(0, 0, -2)},
+		{TaskID: b, Urgency: 9, At: now.AddDate(0, 0, -1)},
+		{TaskID: a, Urgency: 6, At: now.AddDate(0, 0, -1)},
+		{TaskID: a, Urgency: 10, At: now},
+	}
+
+	points := UrgencyTrend(samples, now, 3, time.UTC)
+	if len(points) != 3 {
+		t.Fatalf("got %d points, want 3", len(points))
+	}
+
+	want := []TrendPoint{
+		{Date: "2026-03-02", AverageUrgency: 4, MaxUrgency: 4, CriticalTasks: 0, ScoredTasks: 1},
+		{Date: "2026-03-03", AverageUrgency: 7.5, MaxUrgency: 9, CriticalTasks: 1, ScoredTasks: 2},
+		{Date: "2026-03-04", AverageUrgency: 9.5, MaxUrgency: 10, CriticalTasks: 2, ScoredTasks: 2},
+	}
+	for i, point := range points {
+		if point != want[i] {
+			t.Errorf("day %d = %+v, want %+v", i, point, want[i])
+		}
+	}
+}
+
+func TestCriticalSince(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	id := uuid.New()
+
+	samples := []UrgencySample{
+		{TaskID: id, Urgency: 9, At: start},
+		{TaskID: id, Urgency: 7, At: start.AddDate(0, 0, 1)},
+		{TaskID: id, Urgency: 9, At: start.AddDate(0, 0, 2)},
+		{TaskID: id, Urgency: 10, At: start.AddDate(0, 0, 3)},
+	}
+
+	since := CriticalSince(samples)
+	if since == nil || !since.Equal(start.AddDate(0, 0, 2)) {
+		t.Errorf("CriticalSince = %v, want %v", since, start.AddDate(0, 0, 2))
+	}
+
+	if CriticalSince(samples[:2]) != nil {
+		t.Error("task that dropped below critical should have no critical streak")
+	}
+}
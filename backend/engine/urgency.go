@@ -6,6 +6,79 @@ import (
 	"github.com/Pranay0205/velo/backend/models"
 )
 
+// Input is everything a Scorer needs to score one task at one moment
+type Input struct {
+	Task           models.Task
+	Goal           models.Goal
+	TotalTasks     int
+	CompletedTasks int
+	Now            time.Time
+	Location       *time.Location    // User's timezone, used to count calendar days
+	Capacity       *CapacityLoad     // Effort due by the task's deadline against the hours left, nil when unknown
+	Habit          *HabitStats       // Check-in progress of the goal when it is a habit
+	Exploration    *ExplorationStats // Time invested in the goal when it is an exploration
+	Forecast       *GoalForecast     // Projected completion of the goal when it has a deadline
+}
+
+// Scorer turns an Input into a 1-10 urgency score and can explain how it got there
+type Scorer interface {
+	Score(in Input) int
+	Explain(in Input) Breakdown
+}
+
+// Config holds the weights and thresholds of the urgency formula
+type Config struct {
+	PriorityWeight    float64
+	DeadlineWeight    float64
+	GoalLagWeight     float64
+	StalenessWeight   float64
+	ElapsedThresholds [4]float64 // Share of the timeline used at which deadline pressure steps up to 1, 2, 3 and 4
+	CriticalDays      int        // Days left at or below which deadline pressure is 4
+	WarningDays       int        // Days left at or below which deadline pressure is 3
+	StaleIdleRatio    float64    // Share of the time since the last update over which a task counts as stale
+}
+
+// DefaultConfig is the original hard-coded formula
+func DefaultConfig() Config {
+	return Config{
+		PriorityWeight:    1,
+		DeadlineWeight:    1,
+		GoalLagWeight:     1,
+		StalenessWeight:   1,
+		ElapsedThresholds: [4]float64{0.25, 0.50, 0.75, 0.90},
+		CriticalDays:      1,
+		WarningDays:       3,
+		StaleIdleRatio:    0.25,
+	}
+}
+
+// DefaultScorer sums user priority, deadline pressure, goal lag and staleness
+type DefaultScorer struct {
+	Config Config
+}
+
+func (s DefaultScorer) Score(in Input) int {
+	return s.Explain(in).Urgency
+}
+
+func (s DefaultScorer) Explain(in Input) Breakdown {
+	in = normalizeInput(in)
+	cfg := s.Config
+
+	b := Breakdown{Strategy: StrategyDefault, BasePriority: in.Task.UserPriority}
+	b.applyDeadline(inputPressure(in, cfg))
+	b.applyGoalLag(in)
+	b.Staleness, b.IdleRatio = staleness(in.Task, in.Now, cfg)
+
+	b.RawScore = cfg.PriorityWeight*float64(b.BasePriority) +
+		cfg.DeadlineWeight*float64(b.DeadlinePressure) +
+		cfg.GoalLagWeight*float64(b.GoalLag) +
+		cfg.StalenessWeight*float64(b.Staleness)
+
+	b.finish()
+	return b
+}
+
 func clamp(value, min, max int) int {
 	if value < min {
 		return min
@@ -16,100 +89,169 @@ func clamp(value, min, max int) int {
 	return value
 }
 
-func CalculateUrgency(task models.Task, goal models.Goal, totalTasks int, completedTasks int) int {
+func normalizeInput(in Input) Input {
+	if in.Location == nil {
+		in.Location = time.UTC
+	}
+	return in
+}
+
+// daysUntil counts calendar days between now and deadline as seen in loc,
+// so a deadline later today is 0 days away and one tomorrow is 1 regardless of the hour
+func daysUntil(now, deadline time.Time, loc *time.Location) int {
+	y1, m1, d1 := now.In(loc).Date()
+	y2, m2, d2 := deadline.In(loc).Date()
+	today := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+	dueDay := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+	return int(dueDay.Sub(today).Hours() / 24)
+}
 
-	baseUrgency := task.UserPriority
-	deadlinePressure := deadlinePressure(task, goal)
-	goalLag := goalLag(totalTasks, completedTasks, deadlinePressure)
-	stalenessScore := staleness(task)
+// CalculateUrgency scores a task from 1-10 as of now with the default formula,
+// counting days in the user's location
+func CalculateUrgency(task models.Task, goal models.Goal, totalTasks int, completedTasks int, now time.Time, loc *time.Location) int {
+	return DefaultScorer{Config: DefaultConfig()}.Score(Input{
+		Task:           task,
+		Goal:           goal,
+		TotalTasks:     totalTasks,
+		CompletedTasks: completedTasks,
+		Now:            now,
+		Location:       loc,
+	})
+}
 
-	urgency := baseUrgency + deadlinePressure + goalLag + stalenessScore
+// deadlineResult is the deadline pressure together with the rule that produced it
+type deadlineResult struct {
+	pressure       int
+	rule           string
+	daysLeft       *int
+	timeElapsed    *float64
+	capacityLoad   *float64
+	habitRemaining *int
+	idleDays       *int
+	budgetUsed     *float64
+}
 
-	return clamp(urgency, 1, 10)
+// inputPressure is the deadline pressure of a task including its habit and capacity adjustments
+func inputPressure(in Input, cfg Config) deadlineResult {
+	result := deadlinePressure(in.Task, in.Goal, in.Now, in.Location, cfg)
+	result = applyHabit(result, in.Habit)
+	result = applyExploration(result, in.Exploration)
+	return applyCapacity(result, in.Capacity)
 }
 
-func deadlinePressure(task models.Task, goal models.Goal) int {
-	if !task.Deadline.IsZero() && task.Deadline.Before(time.Now()) {
-		return 4
+func deadlinePressure(task models.Task, goal models.Goal, now time.Time, loc *time.Location, cfg Config) deadlineResult {
+	if !task.Deadline.IsZero() && task.Deadline.Before(now) {
+		return deadlineResult{pressure: 4, rule: RuleTaskOverdue}
 	}
 
 	var totalDuration, timeElapsed float64
+	var daysLeft int
+	var elapsedRule string
 
 	if !task.Deadline.IsZero() {
 
-		daysLeft := time.Until(task.Deadline).Hours() / 24
-		if daysLeft <= 1 {
-			return 4
+		daysLeft = daysUntil(now, task.Deadline, loc)
+		if daysLeft <= cfg.CriticalDays {
+			return deadlineResult{pressure: 4, rule: RuleTaskCritical, daysLeft: &daysLeft}
 		}
-		if daysLeft <= 3 {
-			return 3
+		if daysLeft <= cfg.WarningDays {
+			return deadlineResult{pressure: 3, rule: RuleTaskWarning, daysLeft: &daysLeft}
 		}
 
 		totalDuration = task.Deadline.Sub(task.CreatedAt).Hours()
-		timeElapsed = time.Since(task.CreatedAt).Hours()
+		timeElapsed = now.Sub(task.CreatedAt).Hours()
+		elapsedRule = RuleTaskElapsed
 
 	} else if goal.Deadline != nil {
-		daysLeft := time.Until(*goal.Deadline).Hours() / 24
-		if daysLeft <= 1 {
-			return 4
+		daysLeft = daysUntil(now, *goal.Deadline, loc)
+		if daysLeft <= cfg.CriticalDays {
+			return deadlineResult{pressure: 4, rule: RuleGoalCritical, daysLeft: &daysLeft}
 		}
-		if daysLeft <= 3 {
-			return 3
+		if daysLeft <= cfg.WarningDays {
+			return deadlineResult{pressure: 3, rule: RuleGoalWarning, daysLeft: &daysLeft}
 		}
 
 		// Otherwise use percentage
 		totalDuration = (*goal.Deadline).Sub(goal.CreatedAt).Hours()
-		timeElapsed = time.Since(goal.CreatedAt).Hours()
+		timeElapsed = now.Sub(goal.CreatedAt).Hours()
+		elapsedRule = RuleGoalElapsed
 	} else {
-		return 0
+		return deadlineResult{pressure: 0, rule: RuleNoDeadline}
 	}
 
 	if totalDuration <= 0 {
-		return 4
+		return deadlineResult{pressure: 4, rule: RuleEmptyTimeline, daysLeft: &daysLeft}
 	}
 
 	percentUsed := timeElapsed / totalDuration
+	result := deadlineResult{pressure: 4, rule: elapsedRule, daysLeft: &daysLeft, timeElapsed: &percentUsed}
 
-	if percentUsed < 0.25 {
-		return 0
-	} else if percentUsed < 0.50 {
-		return 1
-	} else if percentUsed < 0.75 {
-		return 2
-	} else if percentUsed < 0.90 {
-		return 3
+	for pressure, threshold := range cfg.ElapsedThresholds {
+		if percentUsed < threshold {
+			result.pressure = pressure
+			break
+		}
 	}
-	return 4
+	return result
 }
 
-func goalLag(totalTasks int, completedTasks int, deadlinePressure int) int {
-	if deadlinePressure == 0 {
-		return 0
+// applyGoalLag sets the goal lag points. With a forecast for a goal with a deadline
+// they follow whether the goal is projected to make it, otherwise the completion rate.
+// A forecast without a completion pace rests on the estimates alone, which assume the
+// work gets done as fast as the hours allow, so the completion rate decides then too.
+func (b *Breakdown) applyGoalLag(in Input) {
+	b.GoalLag, b.CompletionRate = goalLag(in.TotalTasks, in.CompletedTasks, b.DeadlinePressure)
+	f := in.Forecast
+	if f == nil || f.Deadline == nil || b.DeadlinePressure == 0 {
+		return
+	}
+
+	if f.Projected != nil {
+		projected := f.Projected.In(in.Location).Format("2006-01-02")
+		b.ProjectedCompletion = &projected
+	}
+	switch {
+	case f.TasksPerWeek == 0:
+		// Keep the completion rate's lag
+	case !f.AtRisk:
+		b.GoalLag = 0
+	case f.Earliest != nil && !f.Earliest.After(*f.Deadline):
+		b.GoalLag = 1
+	default:
+		b.GoalLag = 2
 	}
+}
+
+// goalLag returns the lag points and the goal's completion rate
+func goalLag(totalTasks int, completedTasks int, deadlinePressure int) (int, float64) {
 	if totalTasks == 0 {
-		return 0
+		return 0, 0
 	}
 	completionRate := float64(completedTasks) / float64(totalTasks)
+	if deadlinePressure == 0 {
+		return 0, completionRate
+	}
 	if completionRate >= 0.75 {
-		return 0
+		return 0, completionRate
 	}
 	if completionRate >= 0.50 {
-		return 1
+		return 1, completionRate
 	}
-	return 2
+	return 2, completionRate
 }
 
-func staleness(task models.Task) int {
-	if task.Deadline.IsZero() || task.Deadline.Before(time.Now()) {
-		return 0
+// staleness returns the staleness points and the share of the task's remaining window spent idle
+func staleness(task models.Task, now time.Time, cfg Config) (int, float64) {
+	if task.Deadline.IsZero() || task.Deadline.Before(now) {
+		return 0, 0
 	}
 
-	idleDays := time.Since(task.UpdatedAt).Hours() / 24
-	daysLeft := time.Until(task.Deadline).Hours() / 24
+	idleDays := now.Sub(task.UpdatedAt).Hours() / 24
+	daysLeft := task.Deadline.Sub(now).Hours() / 24
 	idleRatio := idleDays / (idleDays + daysLeft)
 
-	if idleRatio >= 0.25 {
-		return 1
+	if idleRatio >= cfg.StaleIdleRatio {
+		return 1, idleRatio
 	}
-	return 0
+	return 0, idleRatio
 }
@@ -13,6 +13,8 @@ func timePtr(t time.Time) *time.Time {
 }
 
 func TestCalculateUrgency(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name           string
 		task           models.Task
@@ -26,11 +28,11 @@ func TestCalculateUrgency(t *testing.T) {
 			name: "Low priority, no deadline, goal barely started",
 			task: models.Task{
 				UserPriority: 1,
-				CreatedAt:    time.Now().AddDate(0, 0, -7),
-				UpdatedAt:    time.Now(),
+				CreatedAt:    now.AddDate(0, 0, -7),
+				UpdatedAt:    now,
 			},
 			goal: models.Goal{
-				CreatedAt: time.Now().AddDate(0, 0, -7),
+				CreatedAt: now.AddDate(0, 0, -7),
 			},
 			totalTasks:     10,
 			completedTasks: 1,
@@ -41,13 +43,13 @@ func TestCalculateUrgency(t *testing.T) {
 			name: "High priority, deadline in 3 days, goal half done",
 			task: models.Task{
 				UserPriority: 3,
-				Deadline:     time.Now().AddDate(0, 0, 3),
-				CreatedAt:    time.Now().AddDate(0, 0, -14),
-				UpdatedAt:    time.Now(),
+				Deadline:     now.AddDate(0, 0, 3),
+				CreatedAt:    now.AddDate(0, 0, -14),
+				UpdatedAt:    now,
 			},
 			goal: models.Goal{
-				Deadline:  timePtr(time.Now().AddDate(0, 0, 14)),
-				CreatedAt: time.Now().AddDate(0, -1, 0),
+				Deadline:  timePtr(now.AddDate(0, 0, 14)),
+				CreatedAt: now.AddDate(0, -1, 0),
 			},
 			totalTasks:     8,
 			completedTasks: 4,
@@ -58,13 +60,13 @@ func TestCalculateUrgency(t *testing.T) {
 			name: "Medium priority, overdue task",
 			task: models.Task{
 				UserPriority: 2,
-				Deadline:     time.Now().AddDate(0, 0, -2),
-				CreatedAt:    time.Now().AddDate(0, 0, -30),
-				UpdatedAt:    time.Now().AddDate(0, 0, -10),
+				Deadline:     now.AddDate(0, 0, -2),
+				CreatedAt:    now.AddDate(0, 0, -30),
+				UpdatedAt:    now.AddDate(0, 0, -10),
 			},
 			goal: models.Goal{
-				Deadline:  timePtr(time.Now().AddDate(0, 0, 14)),
-				CreatedAt: time.Now().AddDate(0, -2, 0),
+				Deadline:  timePtr(now.AddDate(0, 0, 14)),
+				CreatedAt: now.AddDate(0, -2, 0),
 			},
 			totalTasks:     5,
 			completedTasks: 1,
@@ -75,13 +77,13 @@ func TestCalculateUrgency(t *testing.T) {
 			name: "High priority, deadline tomorrow, goal barely started, stale",
 			task: models.Task{
 				UserPriority: 3,
-				Deadline:     time.Now().AddDate(0, 0, 1),
-				CreatedAt:    time.Now().AddDate(0, -1, 0),
-				UpdatedAt:    time.Now().AddDate(0, 0, -14),
+				Deadline:     now.AddDate(0, 0, 1),
+				CreatedAt:    now.AddDate(0, -1, 0),
+				UpdatedAt:    now.AddDate(0, 0, -14),
 			},
 			goal: models.Goal{
-				Deadline:  timePtr(time.Now().AddDate(0, 0, 7)),
-				CreatedAt: time.Now().AddDate(0, -2, 0),
+				Deadline:  timePtr(now.AddDate(0, 0, 7)),
+				CreatedAt: now.AddDate(0, -2, 0),
 			},
 			totalTasks:     10,
 			completedTasks: 1,
@@ -92,11 +94,11 @@ func TestCalculateUrgency(t *testing.T) {
 			name: "Low priority, no deadlines anywhere, exploration goal",
 			task: models.Task{
 				UserPriority: 1,
-				CreatedAt:    time.Now().AddDate(0, 0, -3),
-				UpdatedAt:    time.Now(),
+				CreatedAt:    now.AddDate(0, 0, -3),
+				UpdatedAt:    now,
 			},
 			goal: models.Goal{
-				CreatedAt: time.Now().AddDate(0, 0, -10),
+				CreatedAt: now.AddDate(0, 0, -10),
 			},
 			totalTasks:     4,
 			completedTasks: 2,
@@ -107,13 +109,13 @@ func TestCalculateUrgency(t *testing.T) {
 			name: "Medium priority, plenty of time, goal on track",
 			task: models.Task{
 				UserPriority: 2,
-				Deadline:     time.Now().AddDate(0, 0, 60),
-				CreatedAt:    time.Now().AddDate(0, 0, -5),
-				UpdatedAt:    time.Now(),
+				Deadline:     now.AddDate(0, 0, 60),
+				CreatedAt:    now.AddDate(0, 0, -5),
+				UpdatedAt:    now,
 			},
 			goal: models.Goal{
-				Deadline:  timePtr(time.Now().AddDate(0, 0, 90)),
-				CreatedAt: time.Now().AddDate(0, 0, -10),
+				Deadline:  timePtr(now.AddDate(0, 0, 90)),
+				CreatedAt: now.AddDate(0, 0, -10),
 			},
 			totalTasks:     6,
 			completedTasks: 3,
@@ -124,12 +126,12 @@ func TestCalculateUrgency(t *testing.T) {
 			name: "No tasks in goal",
 			task: models.Task{
 				UserPriority: 2,
-				CreatedAt:    time.Now(),
-				UpdatedAt:    time.Now(),
+				CreatedAt:    now,
+				UpdatedAt:    now,
 			},
 			goal: models.Goal{
-				Deadline:  timePtr(time.Now().AddDate(0, 0, 30)),
-				CreatedAt: time.Now().AddDate(0, 0, -5),
+				Deadline:  timePtr(now.AddDate(0, 0, 30)),
+				CreatedAt: now.AddDate(0, 0, -5),
 			},
 			totalTasks:     0,
 			completedTasks: 0,
@@ -143,7 +145,7 @@ func TestCalculateUrgency(t *testing.T) {
 			tt.task.ID = uuid.New()
 			tt.goal.ID = uuid.New()
 
-			got := CalculateUrgency(tt.task, tt.goal, tt.totalTasks, tt.completedTasks)
+			got := CalculateUrgency(tt.task, tt.goal, tt.totalTasks, tt.completedTasks, now, time.UTC)
 
 			if got < tt.wantMin || got > tt.wantMax {
 				t.Errorf("CalculateUrgency() = %d, want between %d-%d", got, tt.wantMin, tt.wantMax)
@@ -151,3 +153,63 @@ func TestCalculateUrgency(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateUrgencyUsesUserLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("tzdata not available:", err)
+	}
+
+	// 23:30 UTC on the 10th is already 08:30 on the 11th in Tokyo
+	now := time.Date(2026, 3, 10, 23, 30, 0, 0, time.UTC)
+	task := models.Task{
+		UserPriority: 1,
+		Deadline:     time.Date(2026, 3, 12, 23, 59, 59, 0, tokyo), // due "tomorrow" for a Tokyo user
+		CreatedAt:    now.AddDate(0, 0, -1),
+		UpdatedAt:    now,
+	}
+	goal := models.Goal{CreatedAt: now.AddDate(0, 0, -1)}
+
+	inTokyo := CalculateUrgency(task, goal, 0, 0, now, tokyo)
+	inUTC := CalculateUrgency(task, goal, 0, 0, now, time.UTC)
+
+	if inTokyo != 5 {
+		t.Errorf("CalculateUrgency() in Tokyo = %d, want 5 (deadline tomorrow)", inTokyo)
+	}
+	if inUTC != 4 {
+		t.Errorf("CalculateUrgency() in UTC = %d, want 4 (deadline in two days)", inUTC)
+	}
+}
+
+func TestForecastUrgencyBuildsPressure(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	task := models.Task{
+		UserPriority: 1,
+		Deadline:     now.AddDate(0, 0, 10),
+		CreatedAt:    now.AddDate(0, 0, -10),
+		UpdatedAt:    now,
+	}
+	goal := models.Goal{CreatedAt: now.AddDate(0, 0, -10)}
+
+	scorer := DefaultScorer{Config: DefaultConfig()}
+	points := ForecastUrgency(scorer, Input{Task: task, Goal: goal, TotalTasks: 4, CompletedTasks: 1, Now: now}, 12)
+
+	if len(points) != 12 {
+		t.Fatalf("ForecastUrgency() returned %d points, want 12", len(points))
+	}
+	if points[0].Date != "2026-03-02" || points[11].Date != "2026-03-13" {
+		t.Fatalf("ForecastUrgency() dates = %s..%s", points[0].Date, points[11].Date)
+	}
+	if points[0].Urgency != CalculateUrgency(task, goal, 4, 1, now, time.UTC) {
+		t.Errorf("first forecast point should match today's urgency")
+	}
+	// Up to the deadline day the task only gets more urgent
+	for i := 1; i <= 10; i++ {
+		if points[i].Urgency < points[i-1].Urgency {
+			t.Errorf("urgency dropped from %d to %d on %s", points[i-1].Urgency, points[i].Urgency, points[i].Date)
+		}
+	}
+	if points[10].Urgency <= points[0].Urgency {
+		t.Errorf("urgency should build towards the deadline, got %d -> %d", points[0].Urgency, points[10].Urgency)
+	}
+}
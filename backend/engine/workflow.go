@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+)
+
+// Task workflow statuses. IsCompleted stays in step: it is true exactly when a task is done.
+const (
+	TaskTodo       = "todo"
+	TaskInProgress = "in_progress"
+	TaskWaiting    = "waiting"
+	TaskDone       = "done"
+	TaskCancelled  = "cancelled"
+)
+
+// Completion events and where a completion change came from. A done task that is
+// cancelled is neither reopened nor still completed, so it gets its own event.
+// SourceQuickAdd is asserted by the client when it completes a task from its
+// quick-add list; the server has no quick-add flow of its own.
+const (
+	CompletionCompleted = "completed"
+	CompletionReopened  = "reopened"
+	CompletionCancelled = "cancelled"
+
+	SourceREST     = "rest"
+	SourceChat     = "chat"
+	SourceQuickAdd = "quick_add"
+)
+
+var taskStatuses = map[string]bool{
+	TaskTodo:       true,
+	TaskInProgress: true,
+	TaskWaiting:    true,
+	TaskDone:       true,
+	TaskCancelled:  true,
+}
+
+// ValidTaskStatus reports whether status is one of the workflow statuses
+func ValidTaskStatus(status string) bool {
+	return taskStatuses[status]
+}
+
+// NormalizeTaskStatus maps tasks saved before statuses existed onto the workflow:
+// completed tasks are done and anything else unknown is still to do
+func NormalizeTaskStatus(status string, completed bool) string {
+	switch {
+	case completed:
+		return TaskDone
+	case status == TaskDone || !taskStatuses[status]:
+		return TaskTodo
+	default:
+		return status
+	}
+}
+
+// TaskOpen reports whether a task still needs doing: it is neither done nor cancelled
+func TaskOpen(task models.Task) bool {
+	return !task.IsCompleted && task.Status != TaskCancelled
+}
+
+// CompletionTime is when a completed task was finished. Tasks completed before
+// CompletedAt was recorded fall back to their last update.
+func CompletionTime(task models.Task) (time.Time, bool) {
+	switch {
+	case !task.IsCompleted:
+		return time.Time{}, false
+	case task.CompletedAt != nil:
+		return *task.CompletedAt, true
+	default:
+		return task.UpdatedAt, true
+	}
+}
+
+// SetTaskStatus moves a task to a status, keeping IsCompleted in step. StartedAt is set
+// the first time work starts; CompletedAt is set when the task is done and cleared when
+// it is reopened or cancelled.
+func SetTaskStatus(task *models.Task, status string, now time.Time) {
+	task.Status = status
+	task.IsCompleted = status == TaskDone
+
+	if status == TaskInProgress && task.StartedAt == nil {
+		task.StartedAt = &now
+	}
+	switch {
+	case status != TaskDone:
+		task.CompletedAt = nil
+	case task.CompletedAt == nil:
+		task.CompletedAt = &now
+	}
+}
+
+// SetTaskCompleted completes or reopens a task. A reopened task goes back to in progress
+// if work on it had started, otherwise to do.
+func SetTaskCompleted(task *models.Task, completed bool, now time.Time) {
+	switch {
+	case completed:
+		SetTaskStatus(task, TaskDone, now)
+	case NormalizeTaskStatus(task.Status, task.IsCompleted) != TaskDone:
+		// Already open; leave its status alone
+	case task.StartedAt != nil:
+		SetTaskStatus(task, TaskInProgress, now)
+	default:
+		SetTaskStatus(task, TaskTodo, now)
+	}
+}
+
+// ParseBoardColumns validates a comma separated list of statuses to show as board
+// columns, in order, and returns it lowercased without duplicates
+func ParseBoardColumns(value string) ([]string, error) {
+	var columns []string
+	seen := map[string]bool{}
+	for _, status := range strings.Split(value, ",") {
+		status = strings.ToLower(strings.TrimSpace(status))
+		if !taskStatuses[status] {
+			return nil, errors.New("Board columns must be a comma separated list of todo, in_progress, waiting, done, cancelled")
+		}
+		if !seen[status] {
+			seen[status] = true
+			columns = append(columns, status)
+		}
+	}
+	return columns, nil
+}
@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+)
+
+func TestNormalizeTaskStatus(t *testing.T) {
+	tests := []struct {
+		status    string
+		completed bool
+		want      string
+	}{
+		{"", false, TaskTodo},
+		{"", true, TaskDone},
+		{"todo", true, TaskDone},
+		{"done", false, TaskTodo},
+		{"waiting", false, TaskWaiting},
+		{"cancelled", false, TaskCancelled},
+		{"blocked", false, TaskTodo},
+	}
+	for _, tt := range tests {
+		if got := NormalizeTaskStatus(tt.status, tt.completed); got != tt.want {
+			t.Errorf("NormalizeTaskStatus(%q, %t) = %q, want %q", tt.status, tt.completed, got, tt.want)
+		}
+	}
+}
+
+func TestSetTaskStatusTimestamps(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	later := start.Add(48 * time.Hour)
+	task := models.Task{Status: TaskTodo}
+
+	SetTaskStatus(&task, TaskInProgress, start)
+	if task.StartedAt == nil || !task.StartedAt.Equal(start) || task.IsCompleted {
+		t.Fatalf("in progress: started_at = %v, completed = %t", task.StartedAt, task.IsCompleted)
+	}
+
+	SetTaskStatus(&task, TaskWaiting, later)
+	SetTaskStatus(&task, TaskInProgress, later)
+	if !task.StartedAt.Equal(start) {
+		t.Errorf("started_at moved to %v, want it kept at %v", task.StartedAt, start)
+	}
+
+	SetTaskStatus(&task, TaskDone, later)
+	if !task.IsCompleted || task.CompletedAt == nil || !task.CompletedAt.Equal(later) {
+		t.Errorf("done: completed = %t, completed_at = %v", task.IsCompleted, task.CompletedAt)
+	}
+
+	SetTaskStatus(&task, TaskCancelled, later)
+	if task.IsCompleted || task.CompletedAt != nil || TaskOpen(task) {
+		t.Errorf("cancelled: completed = %t, completed_at = %v, open = %t", task.IsCompleted, task.CompletedAt, TaskOpen(task))
+	}
+}
+
+func TestSetTaskCompleted(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	started := models.Task{Status: TaskInProgress, StartedAt: &now}
+	SetTaskCompleted(&started, true, now)
+	SetTaskCompleted(&started, false, now)
+	if started.Status != TaskInProgress {
+		t.Errorf("reopened started task status = %q, want %q", started.Status, TaskInProgress)
+	}
+
+	fresh := models.Task{Status: TaskTodo}
+	SetTaskCompleted(&fresh, true, now)
+	SetTaskCompleted(&fresh, false, now)
+	if fresh.Status != TaskTodo || fresh.CompletedAt != nil {
+		t.Errorf("reopened task status = %q, completed_at = %v", fresh.Status, fresh.CompletedAt)
+	}
+
+	waiting := models.Task{Status: TaskWaiting}
+	SetTaskCompleted(&waiting, false, now)
+	if waiting.Status != TaskWaiting {
+		t.Errorf("uncompleting an open task changed its status to %q", waiting.Status)
+	}
+}
+
+func TestParseBoardColumns(t *testing.T) {
+	columns, err := ParseBoardColumns(" Todo, in_progress,todo ,done")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{TaskTodo, TaskInProgress, TaskDone}
+	if len(columns) != len(want) {
+		t.Fatalf("columns = %v, want %v", columns, want)
+	}
+	for i := range want {
+		if columns[i] != want[i] {
+			t.Errorf("columns = %v, want %v", columns, want)
+		}
+	}
+
+	for _, value := range []string{"", "todo,blocked", "todo,,done"} {
+		if _, err := ParseBoardColumns(value); err == nil {
+			t.Errorf("ParseBoardColumns(%q) accepted an invalid list", value)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package engine
+
+// WSJFScorer ranks tasks by weighted shortest job first: the cost of delaying a task
+// (priority, deadline pressure and goal lag) divided by its size in EstimatedHours,
+// so small tasks with the same cost of delay float to the top
+type WSJFScorer struct {
+	Config               Config
+	DefaultEstimateHours float64 // Size assumed for tasks without an estimate
+}
+
+func (s WSJFScorer) Score(in Input) int {
+	return s.Explain(in).Urgency
+}
+
+func (s WSJFScorer) Explain(in Input) Breakdown {
+	in = normalizeInput(in)
+	cfg := s.Config
+
+	b := Breakdown{Strategy: StrategyWSJF, BasePriority: in.Task.UserPriority}
+	b.applyDeadline(inputPressure(in, cfg))
+	b.applyGoalLag(in)
+
+	costOfDelay := cfg.PriorityWeight*float64(b.BasePriority) +
+		cfg.DeadlineWeight*float64(b.DeadlinePressure) +
+		cfg.GoalLagWeight*float64(b.GoalLag)
+
+	hours := s.DefaultEstimateHours
+	if in.Task.EstimatedHours != nil && *in.Task.EstimatedHours > 0 {
+		hours = *in.Task.EstimatedHours
+	}
+	b.JobSize = jobSize(hours)
+
+	// The highest possible cost of delay on the smallest job maps to 10
+	maxCostOfDelay := 3*cfg.PriorityWeight + 4*cfg.DeadlineWeight + 2*cfg.GoalLagWeight
+	if maxCostOfDelay > 0 {
+		b.RawScore = 10 * (costOfDelay / b.JobSize) / maxCostOfDelay
+	}
+
+	b.finish()
+	return b
+}
+
+// jobSize buckets hours into relative sizes on a Fibonacci-like scale
+func jobSize(hours float64) float64 {
+	switch {
+	case hours <= 1:
+		return 1
+	case hours <= 2:
+		return 2
+	case hours <= 4:
+		return 3
+	case hours <= 8:
+		return 5
+	case hours <= 16:
+		return 8
+	default:
+		return 13
+	}
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// GetVelocity counts completed and reopened tasks per week over the last weeks
+func (a *AnalyticsHandler) GetVelocity(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	weeks := fiber.Query[int](c, "weeks", 8)
+	if weeks < 1 || weeks > 52 {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Weeks must be between 1 and 52")
+	}
+
+	loc, err := services.UserLocation(a.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	now := currentTime(a.Clock)
+	events, err := services.LoadCompletionEvents(a.DB, userID, now.AddDate(0, 0, -7*weeks))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve completion history")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
+		"weeks": engine.CompletionVelocity(events, now, weeks, loc),
+	})
+}
+
+// GetCompletionStats reports on-time and late completion rates, the average urgency
+// at completion and estimate accuracy for tasks completed in the last days
+func (a *AnalyticsHandler) GetCompletionStats(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	days := fiber.Query[int](c, "days", 90)
+	if days < 1 || days > 365 {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Days must be between 1 and 365")
+	}
+
+	now := currentTime(a.Clock)
+	stats, err := services.CompletionAnalytics(a.DB, userID, now.AddDate(0, 0, -days))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve completion stats")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, stats)
+}
+
+// GetProductivity spreads the last days' completions over hours of the day and days
+// of the week in the user's timezone
+func (a *AnalyticsHandler) GetProductivity(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	days := fiber.Query[int](c, "days", 90)
+	if days < 1 || days > 365 {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Days must be between 1 and 365")
+	}
+
+	loc, err := services.UserLocation(a.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	events, err := services.LoadCompletionEvents(a.DB, userID, currentTime(a.Clock).AddDate(0, 0, -days))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve completion history")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, engine.CompletionDistribution(events, loc))
+}
+
+// GetBurndown returns a daily series of a goal's total, completed and remaining tasks,
+// with the ideal line when the goal has a deadline
+func (g *GoalHandler) GetBurndown(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	goalID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid goal ID")
+	}
+
+	days := fiber.Query[int](c, "days", 30)
+	if days < 1 || days > 365 {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Days must be between 1 and 365")
+	}
+
+	var goal models.Goal
+	if err := g.DB.Where("id = ? AND user_id = ?", goalID, userID).First(&goal).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusNotFound, "Goal not found")
+	}
+
+	loc, err := services.UserLocation(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	burndown, err := services.GoalBurndown(g.DB, goal, currentTime(g.Clock), days, loc)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve burn-down")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
+		"goal_id":  goal.ID,
+		"burndown": burndown,
+	})
+}
+
+// GetForecast projects when a deadline goal will be finished, with a confidence range
+// and whether that misses the deadline
+func (g *GoalHandler) GetForecast(c fiber.Ctx) error {
+	type forecastResponse struct {
+		GoalID uuid.UUID `json:"goal_id"`
+		engine.GoalForecast
+		ProjectedLocal string `json:"projected_local,omitempty"` // Dates in the user's timezone
+		EarliestLocal  string `json:"earliest_local,omitempty"`
+		LatestLocal    string `json:"latest_local,omitempty"`
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	goal, err := g.findGoalOfType(userID, c.Params("id"), "deadline")
+	if err != nil {
+		return respondGoalTypeError(c, err, "Forecasts are only available for deadline goals")
+	}
+	if goal.Deadline == nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Goal has no deadline to forecast against")
+	}
+
+	loc, err := services.UserLocation(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	uc, err := services.LoadUrgencyContext(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to load goal progress")
+	}
+
+	forecast := uc.Forecasts(currentTime(g.Clock), loc)[goal.ID]
+	response := forecastResponse{GoalID: goal.ID, GoalForecast: forecast}
+	localDate := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return utils.LocalDate(*t, loc)
+	}
+	response.ProjectedLocal = localDate(forecast.Projected)
+	response.EarliestLocal = localDate(forecast.Earliest)
+	response.LatestLocal = localDate(forecast.Latest)
+
+	return utils.RespondSuccess(c, fiber.StatusOK, response)
+}
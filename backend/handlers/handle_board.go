@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type boardColumnResponse struct {
+	Status string         `json:"status"`
+	Tasks  []taskResponse `json:"tasks"`
+}
+
+// GetBoard lists the user's tasks as kanban columns, one per status in the user's
+// board_columns preference. goal_id limits the board to one goal.
+func (t *TaskHandler) GetBoard(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	var goalID *uuid.UUID
+	if value := c.Query("goal_id"); value != "" {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Invalid goal ID")
+		}
+		goalID = &parsed
+	}
+
+	prefs, err := services.LoadPreferences(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve preferences")
+	}
+	columns, err := engine.ParseBoardColumns(prefs.BoardColumns)
+	if err != nil {
+		columns, _ = engine.ParseBoardColumns(models.DefaultUserPreferences(userID).BoardColumns)
+	}
+
+	loc, err := services.UserLocation(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	board, err := services.LoadBoard(t.DB, userID, columns, goalID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve board")
+	}
+
+	response := make([]boardColumnResponse, 0, len(board))
+	for _, column := range board {
+		tasks := make([]taskResponse, 0, len(column.Tasks))
+		for _, task := range column.Tasks {
+			tasks = append(tasks, newTaskResponse(task, loc))
+		}
+		response = append(response, boardColumnResponse{Status: column.Status, Tasks: tasks})
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{"columns": response})
+}
+
+// MoveTask moves a task to a status column of the board, at a position within it
+func (t *TaskHandler) MoveTask(c fiber.Ctx) error {
+	type moveTaskRequest struct {
+		Status   string `json:"status"`
+		Position *int   `json:"position"` // 0-based; defaults to the end of the column
+	}
+
+	var req moveTaskRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	if !engine.ValidTaskStatus(req.Status) {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Status must be one of todo, in_progress, waiting, done, cancelled")
+	}
+
+	task, err := t.findTask(userID, c.Params("id"))
+	if err != nil {
+		return respondTaskLookupError(c, err)
+	}
+
+	now := currentTime(t.Clock)
+	wasOpen := engine.TaskOpen(task)
+	wasCompleted := task.IsCompleted
+	err = t.DB.Transaction(func(tx *gorm.DB) error {
+		if err := services.MoveTask(tx, &task, req.Status, req.Position, now); err != nil {
+			return err
+		}
+		return services.RecordCompletionChange(tx, task, wasCompleted, engine.SourceREST, now)
+	})
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to move task")
+	}
+
+	if wasOpen && !engine.TaskOpen(task) {
+		advanceSeries(t.DB, task, now)
+	}
+	syncGoalStatuses(t.DB, userID, now)
+	notifyUrgency(t.Urgency, userID)
+
+	loc, err := services.UserLocation(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, newTaskResponse(task, loc))
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/llm"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/google/uuid"
+)
+
+// SendBriefing has the assistant write the user's daily briefing into their chat: the
+// most urgent tasks, overdue ones and habits at risk, with proposed actions that wait
+// for the user's approval like any other reply
+func (h *ChatHandler) SendBriefing(ctx context.Context, userID uuid.UUID) error {
+	if h.Gemini == nil {
+		return errors.New("no LLM is configured")
+	}
+
+	pc, _, err := h.promptContext(userID)
+	if err != nil {
+		return err
+	}
+
+	briefing := engine.DailyBriefing(engine.BriefingInput{
+		Now:      pc.Now,
+		Location: pc.Location,
+		Goals:    pc.Goals,
+		Tasks:    pc.Tasks,
+		Urgency:  pc.Urgency,
+		Habits:   pc.Habits,
+	})
+
+	request := []models.ChatMessage{{
+		UserID:  userID,
+		Role:    "user",
+		Message: fmt.Sprintf("Brief me on my day, %s.", briefing.Date),
+	}}
+	_, _, err = h.ask(ctx, userID, services.BriefingKind, llm.BuildBriefingPrompt(pc, briefing), request, nil)
+	return err
+}
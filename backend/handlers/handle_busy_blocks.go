@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// GetBusyBlocks lists the user's busy blocks that end after ?from (default now), earliest first
+func (p *PlanHandler) GetBusyBlocks(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	from := currentTime(p.Clock)
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, "From must be an RFC 3339 timestamp")
+		}
+		from = parsed
+	}
+
+	var blocks []models.BusyBlock
+	if err := p.DB.Where("user_id = ? AND ends_at > ?", userID, from).Order("starts_at asc").Find(&blocks).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve busy blocks")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, blocks)
+}
+
+func (p *PlanHandler) CreateBusyBlock(c fiber.Ctx) error {
+	type createBusyBlockRequest struct {
+		Title    string    `json:"title"`
+		StartsAt time.Time `json:"starts_at"` // RFC 3339
+		EndsAt   time.Time `json:"ends_at"`   // RFC 3339
+	}
+
+	var req createBusyBlockRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if req.Title == "" {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Title is required")
+	}
+
+	if msg := validateBusyBlock(req.StartsAt, req.EndsAt); msg != "" {
+		return utils.RespondError(c, fiber.StatusBadRequest, msg)
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	block := models.BusyBlock{
+		UserID:   userID,
+		Title:    req.Title,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+	}
+
+	if err := p.DB.Create(&block).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to create busy block")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusCreated, block)
+}
+
+func (p *PlanHandler) UpdateBusyBlock(c fiber.Ctx) error {
+	type updateBusyBlockRequest struct {
+		Title    *string    `json:"title"`
+		StartsAt *time.Time `json:"starts_at"`
+		EndsAt   *time.Time `json:"ends_at"`
+	}
+
+	var req updateBusyBlockRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	blockID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid busy block ID")
+	}
+
+	var block models.BusyBlock
+	if err := p.DB.Where("id = ? AND user_id = ?", blockID, userID).First(&block).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusNotFound, "Busy block not found")
+	}
+
+	if req.Title != nil {
+		if *req.Title == "" {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Title is required")
+		}
+		block.Title = *req.Title
+	}
+
+	if req.StartsAt != nil {
+		block.StartsAt = *req.StartsAt
+	}
+
+	if req.EndsAt != nil {
+		block.EndsAt = *req.EndsAt
+	}
+
+	if msg := validateBusyBlock(block.StartsAt, block.EndsAt); msg != "" {
+		return utils.RespondError(c, fiber.StatusBadRequest, msg)
+	}
+
+	if err := p.DB.Save(&block).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to update busy block")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, block)
+}
+
+func (p *PlanHandler) DeleteBusyBlock(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	blockID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid busy block ID")
+	}
+
+	result := p.DB.Where("id = ? AND user_id = ?", blockID, userID).Delete(&models.BusyBlock{})
+	if result.Error != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to delete busy block")
+	}
+	if result.RowsAffected == 0 {
+		return utils.RespondError(c, fiber.StatusNotFound, "Busy block not found")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func validateBusyBlock(start, end time.Time) string {
+	if start.IsZero() || end.IsZero() {
+		return "Starts at and ends at are required"
+	}
+	if !end.After(start) {
+		return "Busy block must end after it starts"
+	}
+	if end.Sub(start) > 14*24*time.Hour {
+		return "Busy block cannot be longer than 14 days"
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// GetCapacity compares the estimated effort due by each upcoming deadline with the
+// user's remaining working hours and warns when the schedule cannot be met
+func (t *TaskHandler) GetCapacity(c fiber.Ctx) error {
+	type deadlineCapacity struct {
+		TaskID         uuid.UUID `json:"task_id"`
+		GoalID         uuid.UUID `json:"goal_id"`
+		Title          string    `json:"title"`
+		Deadline       time.Time `json:"deadline"`
+		DeadlineLocal  string    `json:"deadline_local"`
+		RequiredHours  float64   `json:"required_hours"`
+		AvailableHours float64   `json:"available_hours"`
+		Load           float64   `json:"load"`
+		Status         string    `json:"status"` // ok, tight, overloaded
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	loc, err := services.UserLocation(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	uc, err := services.LoadUrgencyContext(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve tasks")
+	}
+
+	loads := uc.Capacity(currentTime(t.Clock), loc)
+
+	deadlines := make([]deadlineCapacity, 0, len(loads))
+	overloaded := 0
+	for _, task := range uc.OpenTasks {
+		load, ok := loads[task.ID]
+		if !ok {
+			continue
+		}
+
+		status := "ok"
+		switch {
+		case load.Overloaded():
+			status = "overloaded"
+			overloaded++
+		case load.Tight():
+			status = "tight"
+		}
+
+		deadlines = append(deadlines, deadlineCapacity{
+			TaskID:         task.ID,
+			GoalID:         task.GoalID,
+			Title:          task.Title,
+			Deadline:       load.Deadline,
+			DeadlineLocal:  utils.LocalDate(load.Deadline, loc),
+			RequiredHours:  load.RequiredHours,
+			AvailableHours: load.AvailableHours,
+			Load:           load.Load,
+			Status:         status,
+		})
+	}
+
+	sort.SliceStable(deadlines, func(i, j int) bool { return deadlines[i].Deadline.Before(deadlines[j].Deadline) })
+
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
+		"overloaded":       overloaded > 0,
+		"overloaded_tasks": overloaded,
+		"hours_per_week":   uc.Hours.HoursPerWeek(),
+		"deadlines":        deadlines,
+	})
+}
@@ -1,15 +1,23 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"slices"
+	"strings"
+	"time"
 
+	"github.com/Pranay0205/velo/backend/engine"
 	"github.com/Pranay0205/velo/backend/llm"
 	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
 	"github.com/Pranay0205/velo/backend/utils"
 	"github.com/gofiber/fiber/v3"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // ChatHandler handles chat interactions between the user and the LLM
@@ -39,70 +47,270 @@ func (h *ChatHandler) Chat(c fiber.Ctx) error {
 
 	log.Printf("[Chat] Saved user message to database for user %s", userID)
 
-	var goals []models.Goal
-	if err := h.DB.Where("user_id = ? AND status != ?", userID, "abandoned").Find(&goals).Error; err != nil {
-		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve goals")
+	chatsHistory, err := h.getRecentChatHistory(userID, 20)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve chat history")
 	}
 
-	log.Printf("[Chat] Retrieved %d goals for user", len(goals))
+	log.Printf("[Chat] Retrieved chat history for user: %d messages\n", len(chatsHistory))
 
-	var tasks []models.Task
-	if err := h.DB.Where("user_id = ?", userID).Find(&tasks).Error; err != nil {
-		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve tasks")
+	return h.reply(c, userID, chatsHistory, nil)
+}
+
+// RegenerateReply replaces the last assistant message (and its proposed actions) with a fresh reply
+func (h *ChatHandler) RegenerateReply(c fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	chatsHistory, err := h.getRecentChatHistory(userID, 21)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve chat history")
 	}
 
-	log.Printf("[Chat] Retrieved %d tasks for user", len(tasks))
+	if len(chatsHistory) == 0 {
+		return utils.RespondError(c, fiber.StatusNotFound, "No chat history to regenerate")
+	}
 
-	var userName string
-	if err := h.DB.Model(&models.User{}).Where("id = ?", userID).Pluck("name", &userName).Error; err != nil {
-		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user info")
+	// If the last turn was never answered (e.g. the LLM failed) there is nothing to replace
+	last := chatsHistory[len(chatsHistory)-1]
+	if last.Role != "assistant" {
+		log.Printf("[RegenerateReply] Last message for user %s has no reply, generating one", userID)
+		return h.reply(c, userID, chatsHistory, nil)
 	}
 
-	log.Printf("[Chat] Retrieved user name: %s", userName)
+	chatsHistory = chatsHistory[:len(chatsHistory)-1]
+	if len(chatsHistory) == 0 {
+		return utils.RespondError(c, fiber.StatusBadRequest, "No user message to reply to")
+	}
 
-	chatsHistory, err := h.getRecentChatHistory(userID, 20)
+	log.Printf("[RegenerateReply] Regenerating assistant message %s for user %s", last.ID, userID)
+
+	return h.reply(c, userID, chatsHistory, func(tx *gorm.DB) error {
+		return deleteChatMessages(tx, userID, []uuid.UUID{last.ID})
+	})
+}
+
+// EditMessage rewrites a previous user message, drops every later message and re-runs the chat from there
+func (h *ChatHandler) EditMessage(c fiber.Ctx) error {
+	type EditMessageRequest struct {
+		Message string `json:"message"`
+	}
+
+	var req EditMessageRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if strings.TrimSpace(req.Message) == "" {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Message is required")
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	messageID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid message ID")
+	}
+
+	var edited models.ChatMessage
+	if err := h.DB.Where("id = ? AND user_id = ?", messageID, userID).First(&edited).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusNotFound, "Message not found")
+	}
+
+	if edited.Role != "user" {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Only user messages can be edited")
+	}
+
+	chatsHistory, err := h.getChatHistoryUntil(userID, edited.CreatedAt, 20)
 	if err != nil {
 		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve chat history")
 	}
 
-	log.Printf("[Chat] Retrieved chat history for user: %d messages\n", len(chatsHistory))
+	for i := range chatsHistory {
+		if chatsHistory[i].ID == edited.ID {
+			chatsHistory[i].Message = req.Message
+		}
+	}
+
+	log.Printf("[EditMessage] Editing message %s for user %s and truncating later history", edited.ID, userID)
+
+	return h.reply(c, userID, chatsHistory, func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ChatMessage{}).
+			Where("id = ? AND user_id = ?", edited.ID, userID).
+			Update("message", req.Message).Error; err != nil {
+			return err
+		}
+
+		var laterIDs []uuid.UUID
+		if err := tx.Model(&models.ChatMessage{}).
+			Where("user_id = ? AND created_at > ?", userID, edited.CreatedAt).
+			Pluck("id", &laterIDs).Error; err != nil {
+			return err
+		}
+		return deleteChatMessages(tx, userID, laterIDs)
+	})
+}
+
+// deleteChatMessages removes a user's chat messages together with the feedback and
+// action outcomes recorded against them, so no ratings are left pointing at nothing
+func deleteChatMessages(tx *gorm.DB, userID uuid.UUID, messageIDs []uuid.UUID) error {
+	if len(messageIDs) == 0 {
+		return nil
+	}
+	if err := tx.Where("user_id = ? AND chat_message_id IN ?", userID, messageIDs).Delete(&models.MessageFeedback{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("user_id = ? AND chat_message_id IN ?", userID, messageIDs).Delete(&models.ActionOutcome{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("user_id = ? AND id IN ?", userID, messageIDs).Delete(&models.ChatMessage{}).Error
+}
 
-	systemPrompt := llm.BuildSystemPrompt(userName, goals, tasks)
+// reply asks the LLM for the next assistant turn after chatsHistory and stores it.
+// prepare, when set, runs in the same transaction right before the reply is saved so
+// history is only rewritten once the LLM has actually produced a replacement.
+func (h *ChatHandler) reply(c fiber.Ctx, userID uuid.UUID, chatsHistory []models.ChatMessage, prepare func(tx *gorm.DB) error) error {
+	pc, _, err := h.promptContext(userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	systemPrompt := llm.BuildSystemPrompt(pc)
 
-	llmResponse, err := h.Gemini.Chat(c.Context(), systemPrompt, chatsHistory)
+	assistantChat, llmResponse, err := h.ask(c.Context(), userID, "", systemPrompt, chatsHistory, prepare)
 	if err != nil {
-		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to get response from LLM")
+		return utils.RespondError(c, fiber.StatusInternalServerError, err.Error())
 	}
 
-	log.Printf("[Chat] LLM response: %s", llmResponse.Message)
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
+		"id":      assistantChat.ID,
+		"message": llmResponse.Message,
+		"actions": llmResponse.Actions,
+	})
+}
+
+// ask sends the conversation to the LLM and saves its reply, with any proposed actions,
+// as an assistant message of the given kind after running prepare in the same
+// transaction. Errors are ready to show to the user.
+func (h *ChatHandler) ask(ctx context.Context, userID uuid.UUID, kind string, systemPrompt string, chatsHistory []models.ChatMessage, prepare func(tx *gorm.DB) error) (*models.ChatMessage, *llm.LLMResponse, error) {
+	llmResponse, err := h.Gemini.Chat(ctx, systemPrompt, chatsHistory)
+	if err != nil {
+		return nil, nil, errors.New("Failed to get response from LLM")
+	}
 
-	var assistantChat *models.ChatMessage
+	log.Printf("[Chat] LLM response: %s", llmResponse.Message)
 
 	if llmResponse.Message == "" {
-		return utils.RespondError(c, fiber.StatusInternalServerError, "LLM returned an empty response")
+		return nil, nil, errors.New("LLM returned an empty response")
+	}
+
+	if llmResponse.Actions == nil {
+		llmResponse.Actions = []llm.Action{}
 	}
 
-	assistantChat = &models.ChatMessage{
+	actionsJSON, err := json.Marshal(llmResponse.Actions)
+	if err != nil {
+		return nil, nil, errors.New("Failed to encode proposed actions")
+	}
+
+	assistantChat := &models.ChatMessage{
 		UserID:  userID,
 		Message: llmResponse.Message,
 		Role:    "assistant",
+		Kind:    kind,
+		Actions: actionsJSON,
 	}
-	if err := h.DB.Create(&assistantChat).Error; err != nil {
-		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to save assistant message")
+
+	err = h.DB.Transaction(func(tx *gorm.DB) error {
+		if prepare != nil {
+			if err := prepare(tx); err != nil {
+				return err
+			}
+		}
+		return tx.Create(assistantChat).Error
+	})
+	if err != nil {
+		log.Printf("[Chat] Failed to save assistant message for user %s: %v", userID, err)
+		return nil, nil, errors.New("Failed to save assistant message")
 	}
+
 	log.Printf("[Chat] Saved assistant message, ID: %s", assistantChat.ID)
 
-	log.Printf("[Chat] Saved assistant message to database for user %s", userID)
+	return assistantChat, llmResponse, nil
+}
 
-	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
-		"message": llmResponse.Message,
-		"actions": llmResponse.Actions,
-	})
+// promptContext loads everything the assistant is told about the user, along with the
+// urgency context it was scored with. Errors are ready to show to the user.
+func (h *ChatHandler) promptContext(userID uuid.UUID) (llm.PromptContext, *services.UrgencyContext, error) {
+	var goals []models.Goal
+	if err := h.DB.Where("user_id = ? AND status != ?", userID, "abandoned").Find(&goals).Error; err != nil {
+		return llm.PromptContext{}, nil, errors.New("Failed to retrieve goals")
+	}
+
+	log.Printf("[Chat] Retrieved %d goals for user", len(goals))
+
+	var tasks []models.Task
+	if err := h.DB.Where("user_id = ?", userID).Find(&tasks).Error; err != nil {
+		return llm.PromptContext{}, nil, errors.New("Failed to retrieve tasks")
+	}
+
+	log.Printf("[Chat] Retrieved %d tasks for user", len(tasks))
+
+	checklists, err := services.LoadChecklists(h.DB, userID)
+	if err != nil {
+		return llm.PromptContext{}, nil, errors.New("Failed to retrieve checklists")
+	}
+
+	var user models.User
+	if err := h.DB.Where("id = ?", userID).First(&user).Error; err != nil {
+		return llm.PromptContext{}, nil, errors.New("Failed to retrieve user info")
+	}
+
+	log.Printf("[Chat] Retrieved user name: %s", user.Name)
+
+	prefs, err := services.LoadPreferences(h.DB, userID)
+	if err != nil {
+		return llm.PromptContext{}, nil, errors.New("Failed to retrieve preferences")
+	}
+
+	uc, err := services.LoadUrgencyContext(h.DB, userID)
+	if err != nil {
+		return llm.PromptContext{}, nil, errors.New("Failed to retrieve urgency context")
+	}
+
+	now := currentTime(h.Clock)
+	loc := utils.LoadLocation(user.Timezone)
+
+	habits := make(map[uuid.UUID]engine.HabitStats)
+	explorations := make(map[uuid.UUID]engine.ExplorationStats)
+	for _, goal := range goals {
+		if stats := services.HabitStats(goal, uc.CheckIns[goal.ID], now, loc); stats != nil {
+			habits[goal.ID] = *stats
+		}
+		if stats := services.ExplorationStats(goal, uc.LearningLog[goal.ID], now, loc); stats != nil {
+			explorations[goal.ID] = *stats
+		}
+	}
+
+	return llm.PromptContext{
+		UserName:     user.Name,
+		Now:          now,
+		Location:     loc,
+		Preferences:  prefs,
+		Goals:        goals,
+		Tasks:        tasks,
+		Urgency:      uc.ExplainTasks(tasks, now, loc),
+		Habits:       habits,
+		Explorations: explorations,
+		Blockers:     uc.Blockers,
+		Checklists:   checklists,
+		Forecasts:    uc.Forecasts(now, loc),
+	}, uc, nil
 }
 
 func (h *ChatHandler) ExecuteActions(c fiber.Ctx) error {
 	type ExecuteActionsRequest struct {
-		Actions []llm.Action `json:"actions"`
+		MessageID     *uuid.UUID   `json:"message_id"`     // assistant message that proposed the actions, used for feedback
+		ActionIndexes []int        `json:"action_indexes"` // position of each action in that message's proposal
+		Actions       []llm.Action `json:"actions"`
 	}
 
 	var req ExecuteActionsRequest
@@ -114,6 +322,10 @@ func (h *ChatHandler) ExecuteActions(c fiber.Ctx) error {
 		return utils.RespondError(c, fiber.StatusBadRequest, "No actions to execute")
 	}
 
+	if req.MessageID != nil && len(req.ActionIndexes) != len(req.Actions) {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Each action needs its index in the proposal")
+	}
+
 	userID := c.Locals("userID").(uuid.UUID)
 
 	log.Printf("[ExecuteActions] Received %d actions to execute for user %s", len(req.Actions), userID)
@@ -125,6 +337,15 @@ func (h *ChatHandler) ExecuteActions(c fiber.Ctx) error {
 
 	log.Printf("[ExecuteActions] Successfully executed actions for user %s", userID)
 
+	syncGoalStatuses(h.DB, userID, currentTime(h.Clock))
+	notifyUrgency(h.Urgency, userID)
+
+	if req.MessageID != nil {
+		if err := h.recordActionOutcomes(userID, *req.MessageID, req.ActionIndexes, true); err != nil {
+			log.Printf("[ExecuteActions] Failed to record accepted actions for message %s: %v", *req.MessageID, err)
+		}
+	}
+
 	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
 		"message": "Actions executed successfully",
 	})
@@ -164,13 +385,34 @@ func (h *ChatHandler) getRecentChatHistory(userID uuid.UUID, limit int) ([]model
 	return chats, nil
 }
 
+// getChatHistoryUntil retrieves up to limit chat messages created at or before until, ordered oldest to newest
+func (h *ChatHandler) getChatHistoryUntil(userID uuid.UUID, until time.Time, limit int) ([]models.ChatMessage, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	var chats []models.ChatMessage
+	if err := h.DB.Where("user_id = ? AND created_at <= ?", userID, until).Order("created_at desc").Limit(limit).Find(&chats).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve chat history: %w", err)
+	}
+
+	slices.Reverse(chats)
+
+	return chats, nil
+}
+
 // executeLLMActions processes the actions returned by the LLM
 func (h *ChatHandler) executeLLMActions(userID uuid.UUID, actions []llm.Action) error {
+	loc, err := services.UserLocation(h.DB, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load user timezone: %w", err)
+	}
+
 	createdGoalIDs := []uuid.UUID{}
 	for _, action := range actions {
 		switch action.Type {
 		case "create_goal":
-			goalID, err := h.createGoal(userID, action.Goal)
+			goalID, err := h.createGoal(userID, action.Goal, loc)
 			createdGoalIDs = append(createdGoalIDs, goalID)
 			if err != nil {
 				return fmt.Errorf("failed to execute create_goal action: %w", err)
@@ -185,7 +427,7 @@ func (h *ChatHandler) executeLLMActions(userID uuid.UUID, actions []llm.Action)
 			} else {
 				continue
 			}
-			if err := h.createTask(userID, goalID, action.Task); err != nil {
+			if err := h.createTask(userID, goalID, action.Task, loc); err != nil {
 				return fmt.Errorf("failed to execute create_task action: %w", err)
 			}
 
@@ -193,7 +435,7 @@ func (h *ChatHandler) executeLLMActions(userID uuid.UUID, actions []llm.Action)
 			if action.UpdateGoalAction == nil {
 				continue
 			}
-			if err := h.updateGoalAction(userID, action.UpdateGoalAction); err != nil {
+			if err := h.updateGoalAction(userID, action.UpdateGoalAction, loc); err != nil {
 				return fmt.Errorf("failed to execute update_goal action: %w", err)
 			}
 
@@ -209,7 +451,7 @@ func (h *ChatHandler) executeLLMActions(userID uuid.UUID, actions []llm.Action)
 			if action.UpdateTaskAction == nil {
 				continue
 			}
-			if err := h.updateTaskAction(userID, action.UpdateTaskAction); err != nil {
+			if err := h.updateTaskAction(userID, action.UpdateTaskAction, loc); err != nil {
 				return fmt.Errorf("failed to execute update_task action: %w", err)
 			}
 
@@ -221,6 +463,22 @@ func (h *ChatHandler) executeLLMActions(userID uuid.UUID, actions []llm.Action)
 				return fmt.Errorf("failed to execute delete_task action: %w", err)
 			}
 
+		case "add_checklist_item":
+			if action.ChecklistItem == nil {
+				continue
+			}
+			if err := h.addChecklistItemAction(userID, action.ChecklistItem); err != nil {
+				return fmt.Errorf("failed to execute add_checklist_item action: %w", err)
+			}
+
+		case "update_checklist_item":
+			if action.ChecklistItem == nil {
+				continue
+			}
+			if err := h.updateChecklistItemAction(userID, action.ChecklistItem); err != nil {
+				return fmt.Errorf("failed to execute update_checklist_item action: %w", err)
+			}
+
 		case "reprioritize_task":
 			if action.ReprioritizeTask == nil {
 				continue
@@ -237,14 +495,36 @@ func (h *ChatHandler) executeLLMActions(userID uuid.UUID, actions []llm.Action)
 }
 
 // createGoal creates a new goal and returns its ID
-func (h *ChatHandler) createGoal(userID uuid.UUID, goalData *llm.GoalAction) (uuid.UUID, error) {
+func (h *ChatHandler) createGoal(userID uuid.UUID, goalData *llm.GoalAction, loc *time.Location) (uuid.UUID, error) {
 	goal := models.Goal{
 		UserID:      userID,
 		Title:       goalData.Title,
 		Description: goalData.Description,
 		GoalType:    goalData.GoalType,
-		Status:      "not_started",
-		Deadline:    goalData.Deadline,
+		Status:      engine.GoalNotStarted,
+		Frequency:   goalData.Frequency,
+	}
+
+	if goalData.GoalType == "habit" && goalData.Frequency == nil {
+		return uuid.Nil, errors.New("Frequency is required for habit goals")
+	}
+	if err := validateFrequency(goalData.Frequency); err != nil {
+		return uuid.Nil, err
+	}
+
+	if goalData.GoalType == "exploration" {
+		if err := validateWeeklyHoursBudget(goalData.WeeklyHoursBudget); err != nil {
+			return uuid.Nil, err
+		}
+		goal.WeeklyHoursBudget = goalData.WeeklyHoursBudget
+	}
+
+	if goalData.Deadline != nil {
+		deadline, err := utils.ParseDeadline(*goalData.Deadline, loc)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		goal.Deadline = &deadline
 	}
 
 	if err := h.DB.Create(&goal).Error; err != nil {
@@ -254,8 +534,8 @@ func (h *ChatHandler) createGoal(userID uuid.UUID, goalData *llm.GoalAction) (uu
 	return goal.ID, nil
 }
 
-// createTask creates a new task under a goal
-func (h *ChatHandler) createTask(userID uuid.UUID, goalID uuid.UUID, taskData *llm.TaskAction) error {
+// createTask creates a new task under a goal, starting a series when it repeats
+func (h *ChatHandler) createTask(userID uuid.UUID, goalID uuid.UUID, taskData *llm.TaskAction, loc *time.Location) error {
 	task := models.Task{
 		UserID:       userID,
 		GoalID:       goalID,
@@ -263,11 +543,21 @@ func (h *ChatHandler) createTask(userID uuid.UUID, goalID uuid.UUID, taskData *l
 		UserPriority: taskData.UserPriority,
 	}
 
-	if err := h.DB.Create(&task).Error; err != nil {
-		return err
+	if taskData.Recurrence == nil || *taskData.Recurrence == "" {
+		return h.DB.Create(&task).Error
 	}
 
-	return nil
+	rule, err := engine.ParseRRule(*taskData.Recurrence, loc)
+	if err != nil {
+		return fmt.Errorf("invalid recurrence: %w", err)
+	}
+
+	return h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := services.StartSeries(tx, &task, rule, currentTime(h.Clock), loc); err != nil {
+			return err
+		}
+		return tx.Create(&task).Error
+	})
 }
 
 // rePrioritizeTask updates a task's priority
@@ -283,7 +573,7 @@ func (h *ChatHandler) rePrioritizeTask(userID uuid.UUID, data *llm.ReprioritizeA
 }
 
 // updateGoalAction updates specific fields of an existing goal
-func (h *ChatHandler) updateGoalAction(userID uuid.UUID, data *llm.UpdateGoalAction) error {
+func (h *ChatHandler) updateGoalAction(userID uuid.UUID, data *llm.UpdateGoalAction, loc *time.Location) error {
 	updates := map[string]interface{}{}
 
 	if data.Title != nil {
@@ -295,54 +585,72 @@ func (h *ChatHandler) updateGoalAction(userID uuid.UUID, data *llm.UpdateGoalAct
 	if data.GoalType != nil {
 		updates["goal_type"] = *data.GoalType
 	}
-	if data.Status != nil {
-		updates["status"] = *data.Status
-	}
 	if data.Deadline != nil {
-		updates["deadline"] = *data.Deadline
+		deadline, err := utils.ParseDeadline(*data.Deadline, loc)
+		if err != nil {
+			return err
+		}
+		updates["deadline"] = deadline
 	}
 	if data.Frequency != nil {
+		if err := validateFrequency(data.Frequency); err != nil {
+			return err
+		}
 		updates["frequency"] = *data.Frequency
 	}
+	if data.WeeklyHoursBudget != nil {
+		if err := validateWeeklyHoursBudget(data.WeeklyHoursBudget); err != nil {
+			return err
+		}
+		updates["weekly_hours_budget"] = *data.WeeklyHoursBudget
+	}
 
-	if len(updates) == 0 {
+	if len(updates) == 0 && data.Status == nil {
 		return fmt.Errorf("no fields to update")
 	}
 
-	result := h.DB.Model(&models.Goal{}).
-		Where("id = ? AND user_id = ?", data.GoalID, userID).
-		Updates(updates)
-
-	if result.RowsAffected == 0 {
+	var goal models.Goal
+	if err := h.DB.Where("id = ? AND user_id = ?", data.GoalID, userID).First(&goal).Error; err != nil {
 		return fmt.Errorf("goal not found: %s", data.GoalID)
 	}
-	return result.Error
+
+	if err := validateGoalTransition(goal, data.Status); err != nil {
+		return err
+	}
+
+	return h.DB.Transaction(func(tx *gorm.DB) error {
+		if data.Status != nil {
+			if err := services.TransitionGoal(tx, &goal, *data.Status, engine.TriggerChat, currentTime(h.Clock)); err != nil {
+				return err
+			}
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+		return tx.Model(&goal).Updates(updates).Error
+	})
 }
 
-// deleteGoalAction soft-deletes a goal by setting status to abandoned
+// deleteGoalAction moves a goal and its tasks to the trash
 func (h *ChatHandler) deleteGoalAction(userID uuid.UUID, data *llm.DeleteGoalAction) error {
 	if data.GoalID == "" {
 		return fmt.Errorf("goal_id is required for delete_goal action")
 	}
 
-	result := h.DB.Where("goal_id = ? AND user_id = ?", data.GoalID, userID).Delete(&models.Task{})
-
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete associated tasks: %w", result.Error)
+	goalID, err := uuid.Parse(data.GoalID)
+	if err != nil {
+		return fmt.Errorf("invalid goal_id: %s", data.GoalID)
 	}
 
-	result = h.DB.Model(&models.Goal{}).
-		Where("id = ? AND user_id = ?", data.GoalID, userID).
-		Update("status", "abandoned")
-
-	if result.RowsAffected == 0 {
+	err = services.SoftDeleteGoal(h.DB, userID, goalID, currentTime(h.Clock))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return fmt.Errorf("goal not found: %s", data.GoalID)
 	}
-	return result.Error
+	return err
 }
 
 // updateTaskAction updates specific fields of an existing task
-func (h *ChatHandler) updateTaskAction(userID uuid.UUID, data *llm.UpdateTaskAction) error {
+func (h *ChatHandler) updateTaskAction(userID uuid.UUID, data *llm.UpdateTaskAction, loc *time.Location) error {
 	updates := map[string]interface{}{}
 
 	if data.Title != nil {
@@ -352,40 +660,110 @@ func (h *ChatHandler) updateTaskAction(userID uuid.UUID, data *llm.UpdateTaskAct
 		updates["description"] = *data.Description
 	}
 	if data.Deadline != nil {
-		updates["deadline"] = *data.Deadline
+		deadline, err := utils.ParseDeadline(*data.Deadline, loc)
+		if err != nil {
+			return err
+		}
+		updates["deadline"] = deadline
 	}
 	if data.UserPriority != nil {
 		updates["user_priority"] = *data.UserPriority
 	}
-	if data.Completed != nil {
-		updates["is_completed"] = *data.Completed
+	if data.Status != nil && !engine.ValidTaskStatus(*data.Status) {
+		return fmt.Errorf("invalid task status: %s", *data.Status)
 	}
 
-	if len(updates) == 0 {
+	if len(updates) == 0 && data.Completed == nil && data.Status == nil {
 		return fmt.Errorf("no fields to update")
 	}
 
-	result := h.DB.Model(&models.Task{}).
-		Where("id = ? AND user_id = ?", data.TaskID, userID).
-		Updates(updates)
+	var task models.Task
+	if err := h.DB.Where("id = ? AND user_id = ?", data.TaskID, userID).First(&task).Error; err != nil {
+		return fmt.Errorf("task not found: %s", data.TaskID)
+	}
 
-	if result.RowsAffected == 0 {
+	now := currentTime(h.Clock)
+	wasOpen := engine.TaskOpen(task)
+	wasCompleted := task.IsCompleted
+	if data.Completed != nil || data.Status != nil {
+		if data.Completed != nil {
+			engine.SetTaskCompleted(&task, *data.Completed, now)
+		}
+		if data.Status != nil {
+			engine.SetTaskStatus(&task, *data.Status, now)
+		}
+		updates["status"] = task.Status
+		updates["is_completed"] = task.IsCompleted
+		updates["started_at"] = task.StartedAt
+		updates["completed_at"] = task.CompletedAt
+	}
+
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&task).Updates(updates).Error; err != nil {
+			return err
+		}
+		return services.RecordCompletionChange(tx, task, wasCompleted, engine.SourceChat, now)
+	})
+	if err != nil {
+		return err
+	}
+
+	if wasOpen && !engine.TaskOpen(task) {
+		advanceSeries(h.DB, task, now)
+	}
+	return nil
+}
+
+// addChecklistItemAction appends an item to a task's checklist
+func (h *ChatHandler) addChecklistItemAction(userID uuid.UUID, data *llm.ChecklistAction) error {
+	if data.Title == nil || *data.Title == "" {
+		return fmt.Errorf("title is required for add_checklist_item action")
+	}
+
+	var task models.Task
+	if err := h.DB.Where("id = ? AND user_id = ?", data.TaskID, userID).First(&task).Error; err != nil {
 		return fmt.Errorf("task not found: %s", data.TaskID)
 	}
-	return result.Error
+
+	_, err := services.AddChecklistItem(h.DB, task, *data.Title, nil, currentTime(h.Clock))
+	return err
 }
 
-// deleteTaskAction hard-deletes a task
+// updateChecklistItemAction ticks, unticks or renames a checklist item
+func (h *ChatHandler) updateChecklistItemAction(userID uuid.UUID, data *llm.ChecklistAction) error {
+	if data.ItemID == nil {
+		return fmt.Errorf("item_id is required for update_checklist_item action")
+	}
+	if data.Title == nil && data.Completed == nil {
+		return fmt.Errorf("no fields to update")
+	}
+	if data.Title != nil && *data.Title == "" {
+		return fmt.Errorf("title cannot be empty")
+	}
+
+	var item models.ChecklistItem
+	if err := h.DB.Where("id = ? AND task_id = ? AND user_id = ?", *data.ItemID, data.TaskID, userID).First(&item).Error; err != nil {
+		return fmt.Errorf("checklist item not found: %s", *data.ItemID)
+	}
+
+	changes := services.ChecklistChanges{Title: data.Title, Completed: data.Completed}
+	return services.UpdateChecklistItem(h.DB, &item, changes, currentTime(h.Clock))
+}
+
+// deleteTaskAction moves a task to the trash
 func (h *ChatHandler) deleteTaskAction(userID uuid.UUID, data *llm.DeleteTaskAction) error {
 	if data.TaskID == "" {
 		return fmt.Errorf("task_id is required for delete_task action")
 	}
 
-	result := h.DB.Where("id = ? AND user_id = ?", data.TaskID, userID).
-		Delete(&models.Task{})
+	taskID, err := uuid.Parse(data.TaskID)
+	if err != nil {
+		return fmt.Errorf("invalid task_id: %s", data.TaskID)
+	}
 
-	if result.RowsAffected == 0 {
+	err = services.SoftDeleteTask(h.DB, userID, taskID, currentTime(h.Clock))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return fmt.Errorf("task not found: %s", data.TaskID)
 	}
-	return result.Error
+	return err
 }
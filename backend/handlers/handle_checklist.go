@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	errInvalidTaskID        = errors.New("invalid task ID")
+	errInvalidChecklistItem = errors.New("invalid checklist item ID")
+)
+
+// GetChecklist lists a task's checklist items in order
+func (t *TaskHandler) GetChecklist(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	task, err := t.findTask(userID, c.Params("id"))
+	if err != nil {
+		return respondTaskLookupError(c, err)
+	}
+
+	items := []models.ChecklistItem{}
+	if err := t.DB.Where("task_id = ?", task.ID).Order("position").Find(&items).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve checklist")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, items)
+}
+
+// AddChecklistItem adds an item to a task's checklist, at the end unless a position is given
+func (t *TaskHandler) AddChecklistItem(c fiber.Ctx) error {
+	type addChecklistItemRequest struct {
+		Title    string `json:"title"`
+		Position *int   `json:"position"` // 0-based; defaults to the end
+	}
+
+	var req addChecklistItemRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	if req.Title == "" {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Title is required")
+	}
+
+	task, err := t.findTask(userID, c.Params("id"))
+	if err != nil {
+		return respondTaskLookupError(c, err)
+	}
+
+	item, err := services.AddChecklistItem(t.DB, task, req.Title, req.Position, currentTime(t.Clock))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to add checklist item")
+	}
+
+	notifyUrgency(t.Urgency, userID)
+
+	return utils.RespondSuccess(c, fiber.StatusCreated, item)
+}
+
+// UpdateChecklistItem renames, ticks or moves a checklist item
+func (t *TaskHandler) UpdateChecklistItem(c fiber.Ctx) error {
+	type updateChecklistItemRequest struct {
+		Title       *string `json:"title"`
+		IsCompleted *bool   `json:"is_completed"`
+		Position    *int    `json:"position"`
+	}
+
+	var req updateChecklistItemRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	if req.Title != nil && *req.Title == "" {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Title cannot be empty")
+	}
+
+	item, err := t.findChecklistItem(userID, c.Params("id"), c.Params("itemId"))
+	if err != nil {
+		return respondTaskLookupError(c, err)
+	}
+
+	changes := services.ChecklistChanges{Title: req.Title, Completed: req.IsCompleted, Position: req.Position}
+	if err := services.UpdateChecklistItem(t.DB, &item, changes, currentTime(t.Clock)); err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to update checklist item")
+	}
+
+	notifyUrgency(t.Urgency, userID)
+
+	return utils.RespondSuccess(c, fiber.StatusOK, item)
+}
+
+// DeleteChecklistItem removes an item from a task's checklist
+func (t *TaskHandler) DeleteChecklistItem(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	item, err := t.findChecklistItem(userID, c.Params("id"), c.Params("itemId"))
+	if err != nil {
+		return respondTaskLookupError(c, err)
+	}
+
+	if err := services.DeleteChecklistItem(t.DB, item, currentTime(t.Clock)); err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to delete checklist item")
+	}
+
+	notifyUrgency(t.Urgency, userID)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// findTask loads a task owned by the user
+func (t *TaskHandler) findTask(userID uuid.UUID, id string) (models.Task, error) {
+	var task models.Task
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return task, errInvalidTaskID
+	}
+	err = t.DB.Where("id = ? AND user_id = ?", taskID, userID).First(&task).Error
+	return task, err
+}
+
+// findChecklistItem loads a checklist item of a task owned by the user
+func (t *TaskHandler) findChecklistItem(userID uuid.UUID, taskID, itemID string) (models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	task, err := t.findTask(userID, taskID)
+	if err != nil {
+		return item, err
+	}
+	parsed, err := uuid.Parse(itemID)
+	if err != nil {
+		return item, errInvalidChecklistItem
+	}
+	err = t.DB.Where("id = ? AND task_id = ?", parsed, task.ID).First(&item).Error
+	return item, err
+}
+
+// respondTaskLookupError maps findTask and findChecklistItem errors to responses
+func respondTaskLookupError(c fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, errInvalidTaskID):
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid task ID")
+	case errors.Is(err, errInvalidChecklistItem):
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid checklist item ID")
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return utils.RespondError(c, fiber.StatusNotFound, "Task or checklist item not found")
+	default:
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve task")
+	}
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetTaskDependencies lists the tasks a task waits on and the tasks waiting on it
+func (t *TaskHandler) GetTaskDependencies(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid task ID")
+	}
+
+	var task models.Task
+	if err := t.DB.Where("id = ? AND user_id = ?", taskID, userID).First(&task).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusNotFound, "Task not found")
+	}
+
+	var dependencies []models.TaskDependency
+	if err := t.DB.Where("user_id = ? AND (task_id = ? OR depends_on_id = ?)", userID, taskID, taskID).Find(&dependencies).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve dependencies")
+	}
+
+	var dependsOnIDs, blocksIDs []uuid.UUID
+	for _, d := range dependencies {
+		if d.TaskID == taskID {
+			dependsOnIDs = append(dependsOnIDs, d.DependsOnID)
+		} else {
+			blocksIDs = append(blocksIDs, d.TaskID)
+		}
+	}
+
+	dependsOn := []models.Task{}
+	if len(dependsOnIDs) > 0 {
+		if err := t.DB.Where("id IN ?", dependsOnIDs).Order("deadline").Find(&dependsOn).Error; err != nil {
+			return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve dependencies")
+		}
+	}
+	blocks := []models.Task{}
+	if len(blocksIDs) > 0 {
+		if err := t.DB.Where("id IN ?", blocksIDs).Order("deadline").Find(&blocks).Error; err != nil {
+			return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve dependencies")
+		}
+	}
+
+	blocked := false
+	for _, d := range dependsOn {
+		blocked = blocked || engine.TaskOpen(d)
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
+		"task_id":    taskID,
+		"blocked":    blocked && engine.TaskOpen(task),
+		"depends_on": dependsOn,
+		"blocks":     blocks,
+	})
+}
+
+// AddTaskDependency makes a task wait on another of the user's tasks
+func (t *TaskHandler) AddTaskDependency(c fiber.Ctx) error {
+	type addDependencyRequest struct {
+		DependsOnID uuid.UUID `json:"depends_on_id"`
+	}
+
+	var req addDependencyRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid task ID")
+	}
+
+	if req.DependsOnID == uuid.Nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Depends on ID is required")
+	}
+
+	dependency, err := services.AddDependency(t.DB, userID, taskID, req.DependsOnID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return utils.RespondError(c, fiber.StatusNotFound, "Task not found")
+	case errors.Is(err, services.ErrDependencyCycle):
+		return utils.RespondError(c, fiber.StatusConflict, "Dependency would create a cycle")
+	case errors.Is(err, services.ErrDependencyExists):
+		return utils.RespondError(c, fiber.StatusConflict, "Task already depends on that task")
+	case err != nil:
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to add dependency")
+	}
+
+	notifyUrgency(t.Urgency, userID)
+
+	return utils.RespondSuccess(c, fiber.StatusCreated, dependency)
+}
+
+// RemoveTaskDependency stops a task from waiting on another
+func (t *TaskHandler) RemoveTaskDependency(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid task ID")
+	}
+
+	dependsOnID, err := uuid.Parse(c.Params("dependsOnId"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid task ID")
+	}
+
+	err = services.RemoveDependency(t.DB, userID, taskID, dependsOnID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.RespondError(c, fiber.StatusNotFound, "Dependency not found")
+	}
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to remove dependency")
+	}
+
+	notifyUrgency(t.Urgency, userID)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetCriticalPath returns the longest chain of dependent open tasks in a goal by
+// estimated hours, and whether the working hours left before the goal's deadline cover it
+func (g *GoalHandler) GetCriticalPath(c fiber.Ctx) error {
+	type pathStep struct {
+		TaskID        uuid.UUID `json:"task_id"`
+		Title         string    `json:"title"`
+		Hours         float64   `json:"hours"`
+		Deadline      time.Time `json:"deadline"`
+		DeadlineLocal string    `json:"deadline_local,omitempty"`
+		Blocked       bool      `json:"blocked"`
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	goalID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid goal ID")
+	}
+
+	var goal models.Goal
+	if err := g.DB.Where("id = ? AND user_id = ?", goalID, userID).First(&goal).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusNotFound, "Goal not found")
+	}
+
+	loc, err := services.UserLocation(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	uc, err := services.LoadUrgencyContext(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve tasks")
+	}
+
+	byID := make(map[uuid.UUID]models.Task)
+	var candidates []engine.PathTask
+	for _, task := range uc.OpenTasks {
+		if task.GoalID != goal.ID {
+			continue
+		}
+		byID[task.ID] = task
+		candidates = append(candidates, engine.PathTask{ID: task.ID, Hours: engine.TaskHours(task, uc.DefaultEstimateHours)})
+	}
+
+	path, total := engine.CriticalPath(candidates, uc.Blockers)
+
+	steps := make([]pathStep, 0, len(path))
+	for _, id := range path {
+		task := byID[id]
+		steps = append(steps, pathStep{
+			TaskID:        task.ID,
+			Title:         task.Title,
+			Hours:         engine.TaskHours(task, uc.DefaultEstimateHours),
+			Deadline:      task.Deadline,
+			DeadlineLocal: utils.LocalDate(task.Deadline, loc),
+			Blocked:       len(uc.Blockers[task.ID]) > 0,
+		})
+	}
+
+	response := fiber.Map{
+		"goal_id":     goal.ID,
+		"tasks":       steps,
+		"total_hours": total,
+	}
+	if goal.Deadline != nil {
+		available := uc.Hours.AvailableHours(currentTime(g.Clock), *goal.Deadline, loc)
+		response["available_hours"] = available
+		response["fits_before_deadline"] = total <= available
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, response)
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/llm"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// SubmitFeedback stores a thumbs up/down rating and optional comment for an assistant message
+func (h *ChatHandler) SubmitFeedback(c fiber.Ctx) error {
+	type feedbackRequest struct {
+		Rating  int    `json:"rating"` // 1: thumbs up, -1: thumbs down
+		Comment string `json:"comment"`
+	}
+
+	var req feedbackRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	if req.Rating != 1 && req.Rating != -1 {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Rating must be 1 (thumbs up) or -1 (thumbs down)")
+	}
+
+	message, err := h.findAssistantMessage(userID, c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusNotFound, "Assistant message not found")
+	}
+
+	feedback := models.MessageFeedback{
+		UserID:        userID,
+		ChatMessageID: message.ID,
+		Rating:        req.Rating,
+		Comment:       strings.TrimSpace(req.Comment),
+	}
+
+	// One rating per message: rating again overwrites the previous one
+	if err := h.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_message_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rating", "comment", "updated_at"}),
+	}).Create(&feedback).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to save feedback")
+	}
+
+	log.Printf("[SubmitFeedback] Saved rating %d for message %s", req.Rating, message.ID)
+
+	return utils.RespondSuccess(c, fiber.StatusOK, feedback)
+}
+
+// DiscardActions records proposed actions the user rejected in the action review
+func (h *ChatHandler) DiscardActions(c fiber.Ctx) error {
+	type discardActionsRequest struct {
+		ActionIndexes []int `json:"action_indexes"` // Positions of the discarded actions in the message's proposal
+	}
+
+	var req discardActionsRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if len(req.ActionIndexes) == 0 {
+		return utils.RespondError(c, fiber.StatusBadRequest, "No actions to discard")
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	messageID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid message ID")
+	}
+
+	if err := h.recordActionOutcomes(userID, messageID, req.ActionIndexes, false); err != nil {
+		if errors.Is(err, errInvalidActionIndex) {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Action index is not part of the proposal")
+		}
+		log.Printf("[DiscardActions] Failed to record discarded actions for message %s: %v", messageID, err)
+		return utils.RespondError(c, fiber.StatusNotFound, "Assistant message not found")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
+		"message": "Discarded actions recorded",
+	})
+}
+
+// ExportFeedback exports every rated or reviewed assistant reply with its prompt for prompt evaluation.
+// Pass ?format=jsonl to get one JSON object per line instead of a JSON array.
+func (h *ChatHandler) ExportFeedback(c fiber.Ctx) error {
+	type exportRecord struct {
+		MessageID        uuid.UUID       `json:"message_id"`
+		CreatedAt        time.Time       `json:"created_at"`
+		Prompt           string          `json:"prompt"`
+		Reply            string          `json:"reply"`
+		ProposedActions  json.RawMessage `json:"proposed_actions"`
+		Rating           *int            `json:"rating"`
+		Comment          string          `json:"comment"`
+		AcceptedActions  []int           `json:"accepted_actions"`
+		DiscardedActions []int           `json:"discarded_actions"`
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	var messages []models.ChatMessage
+	if err := h.DB.Where("user_id = ?", userID).Order("created_at asc").Find(&messages).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve chat history")
+	}
+
+	var feedback []models.MessageFeedback
+	if err := h.DB.Where("user_id = ?", userID).Find(&feedback).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve feedback")
+	}
+
+	var outcomes []models.ActionOutcome
+	if err := h.DB.Where("user_id = ?", userID).Order("action_index asc").Find(&outcomes).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve action outcomes")
+	}
+
+	feedbackMap := make(map[uuid.UUID]models.MessageFeedback)
+	for _, f := range feedback {
+		feedbackMap[f.ChatMessageID] = f
+	}
+
+	outcomeMap := make(map[uuid.UUID][]models.ActionOutcome)
+	for _, o := range outcomes {
+		outcomeMap[o.ChatMessageID] = append(outcomeMap[o.ChatMessageID], o)
+	}
+
+	records := []exportRecord{}
+	var lastPrompt string
+	for _, msg := range messages {
+		if msg.Role == "user" {
+			lastPrompt = msg.Message
+			continue
+		}
+
+		f, rated := feedbackMap[msg.ID]
+		msgOutcomes := outcomeMap[msg.ID]
+		if !rated && len(msgOutcomes) == 0 {
+			continue
+		}
+
+		record := exportRecord{
+			MessageID:        msg.ID,
+			CreatedAt:        msg.CreatedAt,
+			Prompt:           lastPrompt,
+			Reply:            msg.Message,
+			ProposedActions:  msg.Actions,
+			AcceptedActions:  []int{},
+			DiscardedActions: []int{},
+		}
+
+		if rated {
+			record.Rating = &f.Rating
+			record.Comment = f.Comment
+		}
+
+		for _, o := range msgOutcomes {
+			if o.Accepted {
+				record.AcceptedActions = append(record.AcceptedActions, o.ActionIndex)
+			} else {
+				record.DiscardedActions = append(record.DiscardedActions, o.ActionIndex)
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	log.Printf("[ExportFeedback] Exporting %d feedback records for user %s", len(records), userID)
+
+	if c.Query("format") == "jsonl" {
+		var sb strings.Builder
+		for _, record := range records {
+			line, err := json.Marshal(record)
+			if err != nil {
+				return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to encode feedback export")
+			}
+			sb.Write(line)
+			sb.WriteByte('\n')
+		}
+
+		c.Set(fiber.HeaderContentType, "application/x-ndjson")
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="velo-feedback.jsonl"`)
+		return c.Status(fiber.StatusOK).SendString(sb.String())
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, records)
+}
+
+// findAssistantMessage loads an assistant message owned by the user
+func (h *ChatHandler) findAssistantMessage(userID uuid.UUID, id string) (*models.ChatMessage, error) {
+	messageID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message ID: %w", err)
+	}
+
+	var message models.ChatMessage
+	if err := h.DB.Where("id = ? AND user_id = ? AND role = ?", messageID, userID, "assistant").First(&message).Error; err != nil {
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+// errInvalidActionIndex is returned when an action index is outside the message's proposal
+var errInvalidActionIndex = errors.New("action index is not in the proposal")
+
+// recordActionOutcomes stores whether each of the proposed actions at the given indexes of an
+// assistant message's proposal was accepted or discarded
+func (h *ChatHandler) recordActionOutcomes(userID uuid.UUID, messageID uuid.UUID, indexes []int, accepted bool) error {
+	message, err := h.findAssistantMessage(userID, messageID.String())
+	if err != nil {
+		return err
+	}
+
+	var proposed []llm.Action
+	if len(message.Actions) > 0 {
+		if err := json.Unmarshal(message.Actions, &proposed); err != nil {
+			return fmt.Errorf("failed to decode proposed actions: %w", err)
+		}
+	}
+
+	seen := make(map[int]bool, len(indexes))
+	var records []models.ActionOutcome
+	for _, i := range indexes {
+		if i < 0 || i >= len(proposed) {
+			return errInvalidActionIndex
+		}
+		if seen[i] {
+			continue
+		}
+		seen[i] = true
+		records = append(records, models.ActionOutcome{
+			UserID:        userID,
+			ChatMessageID: message.ID,
+			ActionIndex:   i,
+			ActionType:    proposed[i].Type,
+			Accepted:      accepted,
+		})
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	return h.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_message_id"}, {Name: "action_index"}},
+		DoUpdates: clause.AssignmentColumns([]string{"accepted", "updated_at"}),
+	}).Create(&records).Error
+}
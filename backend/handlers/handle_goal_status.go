@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+var validGoalStatuses = map[string]bool{
+	engine.GoalNotStarted: true,
+	engine.GoalInProgress: true,
+	engine.GoalOverdue:    true,
+	engine.GoalCompleted:  true,
+	engine.GoalAbandoned:  true,
+}
+
+var (
+	errInvalidStatus     = errors.New("invalid status")
+	errInvalidTransition = errors.New("status transition not allowed")
+)
+
+// validateGoalTransition checks that the goal may be moved to the requested status.
+// Requesting the status the goal already has is a no-op.
+func validateGoalTransition(goal models.Goal, to *string) error {
+	if to == nil {
+		return nil
+	}
+	if !validGoalStatuses[*to] {
+		return errInvalidStatus
+	}
+
+	from := engine.NormalizeGoalStatus(goal.Status)
+	if from == *to {
+		return nil
+	}
+	if !engine.CanTransitionGoal(from, *to) {
+		return fmt.Errorf("%w: cannot move a goal from %s to %s", errInvalidTransition, from, *to)
+	}
+	return nil
+}
+
+// respondTransitionError maps a validateGoalTransition error to a response
+func respondTransitionError(c fiber.Ctx, err error) error {
+	if errors.Is(err, errInvalidTransition) {
+		return utils.RespondError(c, fiber.StatusConflict, err.Error())
+	}
+	return utils.RespondError(c, fiber.StatusBadRequest, "Invalid status")
+}
+
+// GetStatusHistory lists a goal's status transitions, oldest first
+func (g *GoalHandler) GetStatusHistory(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	goalID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid goal ID")
+	}
+
+	var goal models.Goal
+	if err := g.DB.Where("id = ? AND user_id = ?", goalID, userID).First(&goal).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusNotFound, "Goal not found")
+	}
+
+	var transitions []models.GoalStatusTransition
+	if err := g.DB.Where("goal_id = ?", goal.ID).Order("transitioned_at asc").Find(&transitions).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve status history")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
+		"status":                 engine.NormalizeGoalStatus(goal.Status),
+		"status_changed_at":      goal.StatusChangedAt,
+		"completion_proposed_at": goal.CompletionProposedAt,
+		"transitions":            transitions,
+	})
+}
@@ -1,12 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"time"
 
+	"github.com/Pranay0205/velo/backend/engine"
 	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
 	"github.com/Pranay0205/velo/backend/utils"
 	"github.com/gofiber/fiber/v3"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 var validGoalTypes = map[string]bool{
@@ -15,11 +19,18 @@ var validGoalTypes = map[string]bool{
 	"exploration": true,
 }
 
-var validGoalStatuses = map[string]bool{
-	"not_started": true,
-	"in_progress": true,
-	"completed":   true,
-	"abandoned":   true,
+// goalResponse adds the deadline rendered as a date in the user's timezone
+type goalResponse struct {
+	models.Goal
+	DeadlineLocal string `json:"deadline_local,omitempty"`
+}
+
+func newGoalResponse(goal models.Goal, loc *time.Location) goalResponse {
+	response := goalResponse{Goal: goal}
+	if goal.Deadline != nil {
+		response.DeadlineLocal = utils.LocalDate(*goal.Deadline, loc)
+	}
+	return response
 }
 
 // GoalHandler handles goal-related requests
@@ -27,12 +38,13 @@ var validGoalStatuses = map[string]bool{
 // Method to create a new goal
 func (g *GoalHandler) CreateGoal(c fiber.Ctx) error {
 	type createGoalRequest struct {
-		Title       string     `json:"title"`
-		Description string     `json:"description"`
-		GoalType    string     `json:"goal_type"`
-		Status      string     `json:"status"`
-		Deadline    *time.Time `json:"deadline"`
-		Frequency   *int       `json:"frequency"`
+		Title             string   `json:"title"`
+		Description       string   `json:"description"`
+		GoalType          string   `json:"goal_type"`
+		Status            string   `json:"status"`
+		Deadline          *string  `json:"deadline"` // YYYY-MM-DD in the user's timezone, or RFC 3339
+		Frequency         *int     `json:"frequency"`
+		WeeklyHoursBudget *float64 `json:"weekly_hours_budget"` // Exploration goals only
 	}
 
 	var req createGoalRequest
@@ -61,21 +73,45 @@ func (g *GoalHandler) CreateGoal(c fiber.Ctx) error {
 		return utils.RespondError(c, fiber.StatusBadRequest, "Frequency is required for habit goals")
 	}
 
+	if err := validateFrequency(req.Frequency); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := validateWeeklyHoursBudget(req.WeeklyHoursBudget); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	loc, err := services.UserLocation(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
 	goal := models.Goal{
 		UserID:      userID,
 		Title:       req.Title,
 		Description: req.Description,
 		GoalType:    req.GoalType,
-		Status:      "not_started",
-		Deadline:    req.Deadline,
+		Status:      engine.GoalNotStarted,
 		Frequency:   req.Frequency,
 	}
 
+	if req.GoalType == "exploration" {
+		goal.WeeklyHoursBudget = req.WeeklyHoursBudget
+	}
+
+	if req.Deadline != nil {
+		deadline, err := utils.ParseDeadline(*req.Deadline, loc)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Invalid deadline: "+err.Error())
+		}
+		goal.Deadline = &deadline
+	}
+
 	if err := g.DB.Create(&goal).Error; err != nil {
 		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to create goal")
 	}
 
-	return utils.RespondSuccess(c, fiber.StatusCreated, goal)
+	return utils.RespondSuccess(c, fiber.StatusCreated, newGoalResponse(goal, loc))
 }
 
 // Method to get all goals for the authenticated user
@@ -85,6 +121,11 @@ func (g *GoalHandler) GetGoals(c fiber.Ctx) error {
 		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
 	}
 
+	loc, err := services.UserLocation(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
 	var goals []models.Goal
 	if err := g.DB.Where("user_id = ? AND status != ?", userID, "abandoned").Find(&goals).Error; err != nil {
 		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve goals")
@@ -97,7 +138,7 @@ func (g *GoalHandler) GetGoals(c fiber.Ctx) error {
 	}
 
 	var metrics []GoalMetrics
-	g.DB.Model(&models.Task{}).
+	g.DB.Model(&models.Task{}).Scopes(services.CountedTasks).
 		Select("goal_id, COUNT(*) as total_tasks, COUNT(CASE WHEN is_completed = true THEN 1 END) as completed_tasks").
 		Where("user_id = ?", userID).
 		Group("goal_id").
@@ -108,19 +149,42 @@ func (g *GoalHandler) GetGoals(c fiber.Ctx) error {
 		metricsMap[m.GoalID] = m
 	}
 
+	checkIns, err := services.LoadCheckInDates(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve check-ins")
+	}
+
+	learningLog, err := services.LoadLearningLog(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve learning log")
+	}
+
+	progress, err := services.LoadGoalProgress(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve goal progress")
+	}
+
 	type GoalWithMetrics struct {
-		models.Goal
-		TotalTasks     int `json:"total_tasks"`
-		CompletedTasks int `json:"completed_tasks"`
+		goalResponse
+		TotalTasks     int                      `json:"total_tasks"`
+		CompletedTasks int                      `json:"completed_tasks"`
+		Progress       float64                  `json:"progress"` // 0-1, counting ticked checklist items of open tasks
+		Habit          *engine.HabitStats       `json:"habit,omitempty"`
+		Exploration    *engine.ExplorationStats `json:"exploration,omitempty"`
 	}
 
+	now := currentTime(g.Clock)
+
 	var response []GoalWithMetrics
 	for _, goal := range goals {
 		m := metricsMap[goal.ID]
 		response = append(response, GoalWithMetrics{
-			Goal:           goal,
+			goalResponse:   newGoalResponse(goal, loc),
 			TotalTasks:     m.TotalTasks,
 			CompletedTasks: m.CompletedTasks,
+			Progress:       progress[goal.ID],
+			Habit:          services.HabitStats(goal, checkIns[goal.ID], now, loc),
+			Exploration:    services.ExplorationStats(goal, learningLog[goal.ID], now, loc),
 		})
 	}
 
@@ -130,11 +194,12 @@ func (g *GoalHandler) GetGoals(c fiber.Ctx) error {
 // Method to update a specific goal
 func (g *GoalHandler) UpdateGoal(c fiber.Ctx) error {
 	type updateGoalRequest struct {
-		Title       *string    `json:"title"`
-		Description *string    `json:"description"`
-		Status      *string    `json:"status"`
-		Deadline    *time.Time `json:"deadline"`
-		Frequency   *int       `json:"frequency"`
+		Title             *string  `json:"title"`
+		Description       *string  `json:"description"`
+		Status            *string  `json:"status"`
+		Deadline          *string  `json:"deadline"` // YYYY-MM-DD in the user's timezone, or RFC 3339
+		Frequency         *int     `json:"frequency"`
+		WeeklyHoursBudget *float64 `json:"weekly_hours_budget"`
 	}
 
 	var req updateGoalRequest
@@ -161,49 +226,85 @@ func (g *GoalHandler) UpdateGoal(c fiber.Ctx) error {
 		goal.Description = *req.Description
 	}
 
-	if req.Status != nil && !validGoalStatuses[*req.Status] {
-		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid status")
+	if err := validateGoalTransition(goal, req.Status); err != nil {
+		return respondTransitionError(c, err)
+	}
+
+	loc, err := services.UserLocation(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
 	}
 
 	if req.Deadline != nil {
-		goal.Deadline = req.Deadline
+		deadline, err := utils.ParseDeadline(*req.Deadline, loc)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Invalid deadline: "+err.Error())
+		}
+		goal.Deadline = &deadline
 	}
 
 	if req.Frequency != nil {
+		if err := validateFrequency(req.Frequency); err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, err.Error())
+		}
 		goal.Frequency = req.Frequency
 	}
 
-	if err := g.DB.Save(&goal).Error; err != nil {
+	if req.WeeklyHoursBudget != nil {
+		if goal.GoalType != "exploration" {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Weekly hours budget is only available for exploration goals")
+		}
+		if err := validateWeeklyHoursBudget(req.WeeklyHoursBudget); err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		goal.WeeklyHoursBudget = req.WeeklyHoursBudget
+	}
+
+	now := currentTime(g.Clock)
+	err = g.DB.Transaction(func(tx *gorm.DB) error {
+		if req.Status != nil {
+			if err := services.TransitionGoal(tx, &goal, *req.Status, engine.TriggerUser, now); err != nil {
+				return err
+			}
+		}
+		return tx.Save(&goal).Error
+	})
+	if err != nil {
 		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to update goal")
 	}
 
-	return utils.RespondSuccess(c, fiber.StatusOK, goal)
+	// A moved deadline can put the goal back on track, or make it overdue
+	syncGoalStatuses(g.DB, userID, now)
+	notifyUrgency(g.Urgency, userID)
+
+	if err := g.DB.First(&goal, "id = ?", goal.ID).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve updated goal")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, newGoalResponse(goal, loc))
 }
 
-// Method to delete a specific goal (soft delete by setting status to "abandoned")
+// Method to delete a specific goal (soft delete: the goal and its tasks move to the trash)
 func (g *GoalHandler) DeleteGoal(c fiber.Ctx) error {
 	userID, ok := c.Locals("userID").(uuid.UUID)
 	if !ok {
 		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
 	}
 
-	goalID := c.Params("id")
-
-	result := g.DB.Where("goal_id = ? AND user_id = ?", goalID, userID).Delete(&models.Task{})
-
-	if result.Error != nil {
-		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to delete associated tasks")
+	goalID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid goal ID")
 	}
 
-	result = g.DB.Where("id = ? AND user_id = ?", goalID, userID).Delete(&models.Goal{})
-
-	if result.Error != nil {
+	err = services.SoftDeleteGoal(g.DB, userID, goalID, currentTime(g.Clock))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.RespondError(c, fiber.StatusNotFound, "Goal not found")
+	}
+	if err != nil {
 		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to delete goal")
 	}
 
-	if result.RowsAffected == 0 {
-		return utils.RespondError(c, fiber.StatusNotFound, "Goal not found")
-	}
+	notifyUrgency(g.Urgency, userID)
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
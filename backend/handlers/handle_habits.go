@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// validateFrequency checks a habit's weekly target; check-ins are limited to one per day
+func validateFrequency(frequency *int) error {
+	if frequency != nil && (*frequency < 1 || *frequency > 7) {
+		return errors.New("Frequency must be between 1 and 7 check-ins per week")
+	}
+	return nil
+}
+
+// CheckInHabit records that the user did their habit today, or on the given local date
+func (g *GoalHandler) CheckInHabit(c fiber.Ctx) error {
+	type checkInRequest struct {
+		Date *string `json:"date"` // YYYY-MM-DD in the user's timezone, defaults to today
+		Note string  `json:"note"`
+	}
+
+	var req checkInRequest
+	if len(c.Body()) > 0 {
+		if err := c.Bind().JSON(&req); err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	goal, err := g.findGoalOfType(userID, c.Params("id"), "habit")
+	if err != nil {
+		return respondGoalTypeError(c, err, "Check-ins are only available for habit goals")
+	}
+
+	loc, err := services.UserLocation(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	now := currentTime(g.Clock)
+	date := utils.LocalDate(now, loc)
+	checkedAt := now
+	if req.Date != nil {
+		day, err := time.ParseInLocation(utils.DateLayout, *req.Date, loc)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Date must be YYYY-MM-DD")
+		}
+		if day.After(now) {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Cannot check in for a future date")
+		}
+		if *req.Date != date {
+			// A check-in for an earlier day counts as activity on that day, not now
+			checkedAt = day
+		}
+		date = *req.Date
+	}
+
+	var existing int64
+	g.DB.Model(&models.HabitCheckIn{}).Where("goal_id = ? AND date = ?", goal.ID, date).Count(&existing)
+	if existing > 0 {
+		return utils.RespondError(c, fiber.StatusConflict, "Already checked in on "+date)
+	}
+
+	checkIn := models.HabitCheckIn{
+		GoalID:    goal.ID,
+		UserID:    userID,
+		Date:      date,
+		Note:      req.Note,
+		CheckedAt: checkedAt,
+	}
+
+	err = g.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&checkIn).Error; err != nil {
+			return err
+		}
+		if goal.LastActiveAt == nil || checkedAt.After(*goal.LastActiveAt) {
+			return tx.Model(&goal).Update("last_active_at", checkedAt).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to record check-in")
+	}
+
+	syncGoalStatuses(g.DB, userID, now)
+	notifyUrgency(g.Urgency, userID)
+
+	stats, err := g.habitStats(goal, now, loc)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to calculate habit progress")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusCreated, fiber.Map{
+		"check_in": checkIn,
+		"stats":    stats,
+	})
+}
+
+// GetCheckIns lists a habit's check-ins for the last ?days (default 30) with its streaks and adherence
+func (g *GoalHandler) GetCheckIns(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	days := fiber.Query[int](c, "days", 30)
+	if days < 1 || days > 366 {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Days must be between 1 and 366")
+	}
+
+	goal, err := g.findGoalOfType(userID, c.Params("id"), "habit")
+	if err != nil {
+		return respondGoalTypeError(c, err, "Check-ins are only available for habit goals")
+	}
+
+	loc, err := services.UserLocation(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	now := currentTime(g.Clock)
+	since := utils.LocalDate(now.AddDate(0, 0, -(days-1)), loc)
+
+	var checkIns []models.HabitCheckIn
+	if err := g.DB.Where("goal_id = ? AND date >= ?", goal.ID, since).Order("date desc").Find(&checkIns).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve check-ins")
+	}
+
+	stats, err := g.habitStats(goal, now, loc)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to calculate habit progress")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
+		"check_ins": checkIns,
+		"stats":     stats,
+	})
+}
+
+// DeleteCheckIn undoes the check-in on a local date and rolls LastActiveAt back accordingly
+func (g *GoalHandler) DeleteCheckIn(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	goal, err := g.findGoalOfType(userID, c.Params("id"), "habit")
+	if err != nil {
+		return respondGoalTypeError(c, err, "Check-ins are only available for habit goals")
+	}
+
+	err = g.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("goal_id = ? AND date = ?", goal.ID, c.Params("date")).Delete(&models.HabitCheckIn{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var latest models.HabitCheckIn
+		err := tx.Where("goal_id = ?", goal.ID).Order("checked_at desc").First(&latest).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Model(&goal).Update("last_active_at", nil).Error
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&goal).Update("last_active_at", latest.CheckedAt).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.RespondError(c, fiber.StatusNotFound, "Check-in not found")
+	}
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to delete check-in")
+	}
+
+	notifyUrgency(g.Urgency, userID)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetHabitReminders lists active habits that are falling behind their weekly target, most pressing first
+func (g *GoalHandler) GetHabitReminders(c fiber.Ctx) error {
+	type habitReminder struct {
+		GoalID  uuid.UUID         `json:"goal_id"`
+		Title   string            `json:"title"`
+		Stats   engine.HabitStats `json:"stats"`
+		Message string            `json:"message"`
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	loc, err := services.UserLocation(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	var goals []models.Goal
+	if err := g.DB.Where("user_id = ? AND goal_type = ? AND status NOT IN ?", userID, "habit", []string{"completed", "abandoned"}).Find(&goals).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve goals")
+	}
+
+	checkIns, err := services.LoadCheckInDates(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve check-ins")
+	}
+
+	now := currentTime(g.Clock)
+	reminders := []habitReminder{}
+	for _, goal := range goals {
+		stats := services.HabitStats(goal, checkIns[goal.ID], now, loc)
+		if stats == nil || stats.Pressure == 0 {
+			continue
+		}
+		reminders = append(reminders, habitReminder{
+			GoalID:  goal.ID,
+			Title:   goal.Title,
+			Stats:   *stats,
+			Message: fmt.Sprintf("%s: %d of %d check-ins this week, %d more needed in %d day(s)", goal.Title, stats.ThisWeek, stats.Frequency, stats.Remaining, stats.DaysLeft),
+		})
+	}
+
+	sort.SliceStable(reminders, func(i, j int) bool { return reminders[i].Stats.Pressure > reminders[j].Stats.Pressure })
+
+	return utils.RespondSuccess(c, fiber.StatusOK, reminders)
+}
+
+var errWrongGoalType = errors.New("goal has a different type")
+
+// findGoalOfType loads a goal of the given type owned by the user
+func (g *GoalHandler) findGoalOfType(userID uuid.UUID, id string, goalType string) (models.Goal, error) {
+	var goal models.Goal
+	goalID, err := uuid.Parse(id)
+	if err != nil {
+		return goal, fmt.Errorf("invalid goal ID: %w", err)
+	}
+
+	if err := g.DB.Where("id = ? AND user_id = ?", goalID, userID).First(&goal).Error; err != nil {
+		return goal, err
+	}
+
+	if goal.GoalType != goalType {
+		return goal, errWrongGoalType
+	}
+
+	return goal, nil
+}
+
+// respondGoalTypeError maps a findGoalOfType error to a response
+func respondGoalTypeError(c fiber.Ctx, err error, wrongTypeMessage string) error {
+	if errors.Is(err, errWrongGoalType) {
+		return utils.RespondError(c, fiber.StatusBadRequest, wrongTypeMessage)
+	}
+	return utils.RespondError(c, fiber.StatusNotFound, "Goal not found")
+}
+
+func (g *GoalHandler) habitStats(goal models.Goal, now time.Time, loc *time.Location) (*engine.HabitStats, error) {
+	var dates []string
+	if err := g.DB.Model(&models.HabitCheckIn{}).Where("goal_id = ?", goal.ID).Pluck("date", &dates).Error; err != nil {
+		return nil, err
+	}
+	return services.HabitStats(goal, dates, now, loc), nil
+}
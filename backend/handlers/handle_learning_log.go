@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var validLogKinds = map[string]bool{
+	"note": true,
+	"link": true,
+}
+
+// validateWeeklyHoursBudget checks an exploration goal's weekly time box
+func validateWeeklyHoursBudget(budget *float64) error {
+	if budget != nil && (*budget <= 0 || *budget > 80) {
+		return errors.New("Weekly hours budget must be more than 0 and at most 80")
+	}
+	return nil
+}
+
+// AddLearningLogEntry records a note or link against an exploration goal, optionally with time spent
+func (g *GoalHandler) AddLearningLogEntry(c fiber.Ctx) error {
+	type addLogEntryRequest struct {
+		Kind       string     `json:"kind"` // note or link
+		Content    string     `json:"content"`
+		URL        string     `json:"url"`
+		HoursSpent float64    `json:"hours_spent"`
+		LoggedAt   *time.Time `json:"logged_at"` // RFC 3339, defaults to now
+	}
+
+	var req addLogEntryRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if !validLogKinds[req.Kind] {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Kind must be note or link")
+	}
+
+	if req.Kind == "note" && req.Content == "" {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Content is required for notes")
+	}
+
+	if req.Kind == "link" {
+		if parsed, err := url.ParseRequestURI(req.URL); err != nil || parsed.Host == "" {
+			return utils.RespondError(c, fiber.StatusBadRequest, "A valid URL is required for links")
+		}
+	}
+
+	if req.HoursSpent < 0 || req.HoursSpent > 24 {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Hours spent must be between 0 and 24")
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	goal, err := g.findGoalOfType(userID, c.Params("id"), "exploration")
+	if err != nil {
+		return respondGoalTypeError(c, err, "The learning log is only available for exploration goals")
+	}
+
+	now := currentTime(g.Clock)
+	loggedAt := now
+	if req.LoggedAt != nil {
+		if req.LoggedAt.After(now) {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Cannot log an entry in the future")
+		}
+		loggedAt = *req.LoggedAt
+	}
+
+	entry := models.LearningLogEntry{
+		GoalID:     goal.ID,
+		UserID:     userID,
+		Kind:       req.Kind,
+		Content:    req.Content,
+		URL:        req.URL,
+		HoursSpent: req.HoursSpent,
+		LoggedAt:   loggedAt,
+	}
+
+	err = g.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&entry).Error; err != nil {
+			return err
+		}
+		if goal.LastActiveAt == nil || loggedAt.After(*goal.LastActiveAt) {
+			return tx.Model(&goal).Update("last_active_at", loggedAt).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to add learning log entry")
+	}
+
+	syncGoalStatuses(g.DB, userID, now)
+	notifyUrgency(g.Urgency, userID)
+
+	return utils.RespondSuccess(c, fiber.StatusCreated, entry)
+}
+
+// GetLearningLog lists an exploration goal's log, newest first, with its time-box progress
+func (g *GoalHandler) GetLearningLog(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	goal, err := g.findGoalOfType(userID, c.Params("id"), "exploration")
+	if err != nil {
+		return respondGoalTypeError(c, err, "The learning log is only available for exploration goals")
+	}
+
+	loc, err := services.UserLocation(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	var entries []models.LearningLogEntry
+	if err := g.DB.Where("goal_id = ?", goal.ID).Order("logged_at desc").Find(&entries).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve learning log")
+	}
+
+	engineEntries := make([]engine.ExplorationEntry, 0, len(entries))
+	for _, entry := range entries {
+		engineEntries = append(engineEntries, engine.ExplorationEntry{At: entry.LoggedAt, Hours: entry.HoursSpent})
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
+		"entries": entries,
+		"stats":   services.ExplorationStats(goal, engineEntries, currentTime(g.Clock), loc),
+	})
+}
+
+// DeleteLearningLogEntry removes one entry from an exploration goal's log and rolls LastActiveAt back accordingly
+func (g *GoalHandler) DeleteLearningLogEntry(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	goal, err := g.findGoalOfType(userID, c.Params("id"), "exploration")
+	if err != nil {
+		return respondGoalTypeError(c, err, "The learning log is only available for exploration goals")
+	}
+
+	entryID, err := uuid.Parse(c.Params("entryId"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid entry ID")
+	}
+
+	err = g.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND goal_id = ?", entryID, goal.ID).Delete(&models.LearningLogEntry{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var latest models.LearningLogEntry
+		err := tx.Where("goal_id = ?", goal.ID).Order("logged_at desc").First(&latest).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Model(&goal).Update("last_active_at", nil).Error
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&goal).Update("last_active_at", latest.LoggedAt).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.RespondError(c, fiber.StatusNotFound, "Learning log entry not found")
+	}
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to delete learning log entry")
+	}
+
+	notifyUrgency(g.Urgency, userID)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -18,7 +18,8 @@ func (h *AuthHandler) Me(c fiber.Ctx) error {
 	}
 
 	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
-		"email": user.Email,
-		"name":  user.Name,
+		"email":    user.Email,
+		"name":     user.Name,
+		"timezone": user.Timezone,
 	})
 }
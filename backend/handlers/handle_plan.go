@@ -0,0 +1,451 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type planItemResponse struct {
+	TaskID        uuid.UUID `json:"task_id"`
+	GoalID        uuid.UUID `json:"goal_id"`
+	Title         string    `json:"title"`
+	Urgency       int       `json:"urgency"`
+	DeadlineLocal string    `json:"deadline_local,omitempty"`
+	Hours         float64   `json:"hours"`
+	Reason        string    `json:"reason"`
+	Pinned        bool      `json:"pinned"`
+	Partial       bool      `json:"partial"`
+	Completed     bool      `json:"completed"`
+}
+
+type planCompletion struct {
+	PlannedTasks   int     `json:"planned_tasks"`
+	CompletedTasks int     `json:"completed_tasks"`
+	PlannedHours   float64 `json:"planned_hours"`
+	CompletedHours float64 `json:"completed_hours"`
+	Rate           float64 `json:"rate"` // Share of planned tasks finished by the end of the day
+}
+
+type planResponse struct {
+	Date           string             `json:"date"`
+	Status         string             `json:"status"` // draft or accepted
+	AvailableHours float64            `json:"available_hours"`
+	PlannedHours   float64            `json:"planned_hours"`
+	AcceptedAt     *time.Time         `json:"accepted_at,omitempty"`
+	Items          []planItemResponse `json:"items"`
+	Skipped        []uuid.UUID        `json:"skipped"`
+	Completion     planCompletion     `json:"completion"`
+}
+
+// GetTodayPlan returns today's accepted plan, or a fresh proposal built from the
+// user's open tasks, pins and skips when nothing has been accepted yet
+func (p *PlanHandler) GetTodayPlan(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	hours, err := planHoursOverride(c)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	loc, err := services.UserLocation(p.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	now := currentTime(p.Clock)
+	plan, err := findPlan(p.DB, userID, utils.LocalDate(now, loc))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve plan")
+	}
+
+	if plan.AcceptedAt == nil {
+		uc, err := services.LoadUrgencyContext(p.DB, userID)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve tasks")
+		}
+		proposal := proposePlan(uc, plan, now, loc, hours)
+		applyProposal(&plan, proposal)
+	}
+
+	response, err := buildPlanResponse(p.DB, plan, loc)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve planned tasks")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, response)
+}
+
+// UpdatePlanTask pins a task into today's plan or skips it. Changing an accepted plan
+// reopens it as a draft so the user can review the new proposal and accept again.
+func (p *PlanHandler) UpdatePlanTask(c fiber.Ctx) error {
+	type updatePlanTaskRequest struct {
+		Pinned  *bool `json:"pinned"`
+		Skipped *bool `json:"skipped"`
+	}
+
+	var req updatePlanTaskRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if req.Pinned == nil && req.Skipped == nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Pinned or skipped is required")
+	}
+
+	if req.Pinned != nil && req.Skipped != nil && *req.Pinned && *req.Skipped {
+		return utils.RespondError(c, fiber.StatusBadRequest, "A task cannot be both pinned and skipped")
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid task ID")
+	}
+
+	var task models.Task
+	if err := p.DB.Scopes(services.OpenTasks).Where("id = ? AND user_id = ?", taskID, userID).First(&task).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusNotFound, "Open task not found")
+	}
+
+	loc, err := services.UserLocation(p.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	plan, err := findPlan(p.DB, userID, utils.LocalDate(currentTime(p.Clock), loc))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve plan")
+	}
+
+	item := models.DailyPlanItem{TaskID: task.ID}
+	for _, existing := range plan.Items {
+		if existing.TaskID == task.ID {
+			item = existing
+		}
+	}
+
+	if req.Pinned != nil {
+		item.Pinned = *req.Pinned
+		if item.Pinned {
+			item.Skipped = false
+		}
+	}
+	if req.Skipped != nil {
+		item.Skipped = *req.Skipped
+		if item.Skipped {
+			item.Pinned = false
+		}
+	}
+
+	err = p.DB.Transaction(func(tx *gorm.DB) error {
+		if plan.ID == uuid.Nil {
+			if err := tx.Omit("Items").Create(&plan).Error; err != nil {
+				return err
+			}
+		}
+
+		// Reopen an accepted plan: only the user's pins and skips survive
+		if plan.AcceptedAt != nil {
+			if err := tx.Where("plan_id = ? AND pinned = ? AND skipped = ?", plan.ID, false, false).Delete(&models.DailyPlanItem{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&plan).Updates(map[string]any{"accepted_at": nil, "planned_hours": 0}).Error; err != nil {
+				return err
+			}
+		}
+
+		if !item.Pinned && !item.Skipped {
+			return tx.Where("plan_id = ? AND task_id = ?", plan.ID, task.ID).Delete(&models.DailyPlanItem{}).Error
+		}
+
+		item.PlanID = plan.ID
+		return tx.Save(&item).Error
+	})
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to update plan")
+	}
+
+	return p.GetTodayPlan(c)
+}
+
+// AcceptTodayPlan stores the current proposal as today's plan
+func (p *PlanHandler) AcceptTodayPlan(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	hours, err := planHoursOverride(c)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	loc, err := services.UserLocation(p.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	now := currentTime(p.Clock)
+	plan, err := findPlan(p.DB, userID, utils.LocalDate(now, loc))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve plan")
+	}
+
+	if plan.AcceptedAt != nil {
+		return utils.RespondError(c, fiber.StatusConflict, "Today's plan is already accepted")
+	}
+
+	uc, err := services.LoadUrgencyContext(p.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve tasks")
+	}
+
+	proposal := proposePlan(uc, plan, now, loc, hours)
+	applyProposal(&plan, proposal)
+	plan.AcceptedAt = &now
+
+	err = p.DB.Transaction(func(tx *gorm.DB) error {
+		if plan.ID == uuid.Nil {
+			if err := tx.Omit("Items").Create(&plan).Error; err != nil {
+				return err
+			}
+		} else if err := tx.Omit("Items").Save(&plan).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("plan_id = ?", plan.ID).Delete(&models.DailyPlanItem{}).Error; err != nil {
+			return err
+		}
+
+		for i := range plan.Items {
+			plan.Items[i].ID = uuid.Nil
+			plan.Items[i].PlanID = plan.ID
+		}
+		if len(plan.Items) == 0 {
+			return nil
+		}
+		return tx.Create(&plan.Items).Error
+	})
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to accept plan")
+	}
+
+	response, err := buildPlanResponse(p.DB, plan, loc)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve planned tasks")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, response)
+}
+
+// GetPlanHistory returns the user's accepted plans for the last days with how much of each was finished
+func (p *PlanHandler) GetPlanHistory(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	days := fiber.Query[int](c, "days", 14)
+	if days < 1 || days > 90 {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Days must be between 1 and 90")
+	}
+
+	loc, err := services.UserLocation(p.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	since := utils.LocalDate(currentTime(p.Clock).AddDate(0, 0, -(days-1)), loc)
+
+	var plans []models.DailyPlan
+	if err := p.DB.Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("position asc") }).
+		Where("user_id = ? AND date >= ? AND accepted_at IS NOT NULL", userID, since).
+		Order("date desc").
+		Find(&plans).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve plans")
+	}
+
+	response := make([]planResponse, 0, len(plans))
+	for _, plan := range plans {
+		item, err := buildPlanResponse(p.DB, plan, loc)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve planned tasks")
+		}
+		response = append(response, item)
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, response)
+}
+
+// findPlan loads the user's plan for a date, returning an unsaved empty plan if there is none
+func findPlan(db *gorm.DB, userID uuid.UUID, date string) (models.DailyPlan, error) {
+	var plan models.DailyPlan
+	err := db.Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("position asc") }).
+		Where("user_id = ? AND date = ?", userID, date).
+		First(&plan).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DailyPlan{UserID: userID, Date: date}, nil
+	}
+	return plan, err
+}
+
+// planHoursOverride reads the optional ?hours= replacement for today's available working hours
+func planHoursOverride(c fiber.Ctx) (*float64, error) {
+	if c.Query("hours") == "" {
+		return nil, nil
+	}
+	hours := fiber.Query[float64](c, "hours", -1)
+	if hours < 0 || hours > 24 {
+		return nil, errors.New("Hours must be between 0 and 24")
+	}
+	return &hours, nil
+}
+
+// proposePlan runs the planner over the user's open tasks, honouring the plan's pins and
+// skips. Blocked tasks are left out unless pinned.
+func proposePlan(uc *services.UrgencyContext, plan models.DailyPlan, now time.Time, loc *time.Location, hours *float64) engine.DailyPlan {
+	pinned := map[uuid.UUID]bool{}
+	skipped := map[uuid.UUID]bool{}
+	for _, item := range plan.Items {
+		pinned[item.TaskID] = item.Pinned
+		skipped[item.TaskID] = item.Skipped
+	}
+
+	candidates := make([]engine.PlanCandidate, 0, len(uc.OpenTasks))
+	for _, task := range uc.OpenTasks {
+		if skipped[task.ID] || (len(uc.Blockers[task.ID]) > 0 && !pinned[task.ID]) {
+			continue
+		}
+		candidates = append(candidates, engine.PlanCandidate{
+			Task:     task,
+			Deadline: engine.EffectiveDeadline(task, uc.Goals[task.GoalID]),
+			Hours:    engine.TaskHours(task, uc.DefaultEstimateHours),
+			Pinned:   pinned[task.ID],
+		})
+	}
+
+	available := uc.Hours.AvailableHours(now, utils.EndOfDay(now, loc), loc)
+	if hours != nil {
+		available = *hours
+	}
+
+	return engine.BuildDailyPlan(candidates, available, now, loc)
+}
+
+// applyProposal replaces the plan's picked items with the proposal, keeping skipped rows
+func applyProposal(plan *models.DailyPlan, proposal engine.DailyPlan) {
+	items := make([]models.DailyPlanItem, 0, len(proposal.Picks))
+	for i, pick := range proposal.Picks {
+		items = append(items, models.DailyPlanItem{
+			TaskID:   pick.TaskID,
+			Position: i,
+			Hours:    pick.Hours,
+			Reason:   pick.Reason,
+			Pinned:   pick.Pinned,
+			Partial:  pick.Partial,
+		})
+	}
+	for _, item := range plan.Items {
+		if item.Skipped {
+			item.Position = len(items)
+			items = append(items, item)
+		}
+	}
+
+	plan.Items = items
+	plan.AvailableHours = proposal.AvailableHours
+	plan.PlannedHours = proposal.PlannedHours
+}
+
+// buildPlanResponse joins the plan's items with their tasks. A task counts as completed
+// when it was finished by the end of the plan's day.
+func buildPlanResponse(db *gorm.DB, plan models.DailyPlan, loc *time.Location) (planResponse, error) {
+	response := planResponse{
+		Date:           plan.Date,
+		Status:         "draft",
+		AvailableHours: plan.AvailableHours,
+		PlannedHours:   plan.PlannedHours,
+		AcceptedAt:     plan.AcceptedAt,
+		Items:          []planItemResponse{},
+		Skipped:        []uuid.UUID{},
+	}
+	if plan.AcceptedAt != nil {
+		response.Status = "accepted"
+	}
+
+	taskIDs := make([]uuid.UUID, 0, len(plan.Items))
+	for _, item := range plan.Items {
+		taskIDs = append(taskIDs, item.TaskID)
+	}
+
+	var tasks []models.Task
+	if len(taskIDs) > 0 {
+		if err := db.Where("id IN ?", taskIDs).Find(&tasks).Error; err != nil {
+			return planResponse{}, err
+		}
+	}
+	byID := make(map[uuid.UUID]models.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	endOfDay := time.Time{}
+	if date, err := time.ParseInLocation(utils.DateLayout, plan.Date, loc); err == nil {
+		endOfDay = utils.EndOfDay(date, loc)
+	}
+
+	for _, item := range plan.Items {
+		if item.Skipped {
+			response.Skipped = append(response.Skipped, item.TaskID)
+			continue
+		}
+
+		task, ok := byID[item.TaskID]
+		if !ok {
+			continue
+		}
+
+		completedAt, done := engine.CompletionTime(task)
+		completed := done && !completedAt.After(endOfDay)
+		response.Items = append(response.Items, planItemResponse{
+			TaskID:        task.ID,
+			GoalID:        task.GoalID,
+			Title:         task.Title,
+			Urgency:       task.AIUrgency,
+			DeadlineLocal: utils.LocalDate(task.Deadline, loc),
+			Hours:         item.Hours,
+			Reason:        item.Reason,
+			Pinned:        item.Pinned,
+			Partial:       item.Partial,
+			Completed:     completed,
+		})
+
+		response.Completion.PlannedTasks++
+		response.Completion.PlannedHours += item.Hours
+		if completed {
+			response.Completion.CompletedTasks++
+			response.Completion.CompletedHours += item.Hours
+		}
+	}
+
+	if response.Completion.PlannedTasks > 0 {
+		response.Completion.Rate = float64(response.Completion.CompletedTasks) / float64(response.Completion.PlannedTasks)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var validTones = map[string]bool{
+	"friendly":     true,
+	"direct":       true,
+	"motivational": true,
+}
+
+var validVerbosity = map[string]bool{
+	"brief":    true,
+	"balanced": true,
+	"detailed": true,
+}
+
+var validWorkDays = map[string]bool{
+	"mon": true,
+	"tue": true,
+	"wed": true,
+	"thu": true,
+	"fri": true,
+	"sat": true,
+	"sun": true,
+}
+
+type preferencesResponse struct {
+	models.UserPreferences
+	Timezone string `json:"timezone"`
+}
+
+// GetPreferences returns the authenticated user's preferences, falling back to defaults
+func (p *PreferencesHandler) GetPreferences(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	prefs, err := services.LoadPreferences(p.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve preferences")
+	}
+
+	var user models.User
+	if err := p.DB.Select("timezone").Where("id = ?", userID).First(&user).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, preferencesResponse{UserPreferences: prefs, Timezone: user.Timezone})
+}
+
+// UpdatePreferences updates any subset of the authenticated user's preferences
+func (p *PreferencesHandler) UpdatePreferences(c fiber.Ctx) error {
+	type updatePreferencesRequest struct {
+		WorkStartHour *int    `json:"work_start_hour"`
+		WorkEndHour   *int    `json:"work_end_hour"`
+		WorkDays      *string `json:"work_days"`
+		Tone          *string `json:"tone"`
+		Verbosity     *string `json:"verbosity"`
+		TasksPerGoal  *int    `json:"tasks_per_goal"`
+		Language      *string `json:"language"`
+		Timezone      *string `json:"timezone"`
+		BoardColumns  *string `json:"board_columns"`
+		Briefing      *bool   `json:"briefing"`
+		BriefingHour  *int    `json:"briefing_hour"`
+	}
+
+	var req updatePreferencesRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	prefs, err := services.LoadPreferences(p.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve preferences")
+	}
+
+	if req.WorkStartHour != nil {
+		prefs.WorkStartHour = *req.WorkStartHour
+	}
+
+	if req.WorkEndHour != nil {
+		prefs.WorkEndHour = *req.WorkEndHour
+	}
+
+	if prefs.WorkStartHour < 0 || prefs.WorkEndHour > 24 || prefs.WorkStartHour >= prefs.WorkEndHour {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Working hours must be between 0 and 24 and start before they end")
+	}
+
+	if req.WorkDays != nil {
+		days, err := normalizeWorkDays(*req.WorkDays)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		prefs.WorkDays = days
+	}
+
+	if req.Tone != nil {
+		if !validTones[*req.Tone] {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Tone must be one of friendly, direct, motivational")
+		}
+		prefs.Tone = *req.Tone
+	}
+
+	if req.Verbosity != nil {
+		if !validVerbosity[*req.Verbosity] {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Verbosity must be one of brief, balanced, detailed")
+		}
+		prefs.Verbosity = *req.Verbosity
+	}
+
+	if req.TasksPerGoal != nil {
+		if *req.TasksPerGoal < 1 || *req.TasksPerGoal > 10 {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Tasks per goal must be between 1 and 10")
+		}
+		prefs.TasksPerGoal = *req.TasksPerGoal
+	}
+
+	if req.Language != nil {
+		language := strings.TrimSpace(*req.Language)
+		if language == "" || len(language) > 32 {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Language must be between 1 and 32 characters")
+		}
+		prefs.Language = language
+	}
+
+	if req.BoardColumns != nil {
+		columns, err := engine.ParseBoardColumns(*req.BoardColumns)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		prefs.BoardColumns = strings.Join(columns, ",")
+	}
+
+	if req.Briefing != nil {
+		prefs.Briefing = *req.Briefing
+	}
+
+	if req.BriefingHour != nil {
+		if *req.BriefingHour < 0 || *req.BriefingHour > 23 {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Briefing hour must be between 0 and 23")
+		}
+		prefs.BriefingHour = *req.BriefingHour
+	}
+
+	var user models.User
+	if err := p.DB.Where("id = ?", userID).First(&user).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user")
+	}
+
+	if req.Timezone != nil {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil || *req.Timezone == "" {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Invalid timezone")
+		}
+		user.Timezone = *req.Timezone
+	}
+
+	err = p.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&prefs).Error; err != nil {
+			return err
+		}
+		return tx.Model(&user).Update("timezone", user.Timezone).Error
+	})
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to update preferences")
+	}
+
+	// Working hours and timezone feed into capacity and deadline pressure
+	notifyUrgency(p.Urgency, userID)
+
+	return utils.RespondSuccess(c, fiber.StatusOK, preferencesResponse{UserPreferences: prefs, Timezone: user.Timezone})
+}
+
+// normalizeWorkDays validates a comma separated list of day abbreviations and lowercases it
+func normalizeWorkDays(value string) (string, error) {
+	var days []string
+	seen := map[string]bool{}
+	for _, day := range strings.Split(value, ",") {
+		day = strings.ToLower(strings.TrimSpace(day))
+		if !validWorkDays[day] {
+			return "", errors.New("Work days must be a comma separated list of mon, tue, wed, thu, fri, sat, sun")
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	return strings.Join(days, ","), nil
+}
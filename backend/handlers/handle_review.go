@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/llm"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// maxReviewDays caps the range a review can look back over
+const maxReviewDays = 31
+
+// reviewKind marks the chat messages that hold a written up review
+const reviewKind = "review"
+
+// GetWeeklyReview looks back over a range of days, the last seven by default, at what
+// was completed, what slipped, stale tasks, neglected habits and new goals
+func (h *ChatHandler) GetWeeklyReview(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	_, review, status, err := h.weeklyReview(userID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		return utils.RespondError(c, status, err.Error())
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{"review": review})
+}
+
+// WriteWeeklyReview has the assistant write up the review for a range of days and
+// propose actions for the week ahead. Its reply is saved to the chat so the actions go
+// through the usual review before anything changes.
+func (h *ChatHandler) WriteWeeklyReview(c fiber.Ctx) error {
+	type writeReviewRequest struct {
+		From string `json:"from"` // YYYY-MM-DD; defaults to six days before to
+		To   string `json:"to"`   // YYYY-MM-DD; defaults to today
+	}
+
+	var req writeReviewRequest
+	if len(c.Body()) > 0 {
+		if err := c.Bind().JSON(&req); err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	if h.Gemini == nil {
+		return utils.RespondError(c, fiber.StatusServiceUnavailable, "Narrative summaries are not available")
+	}
+
+	pc, review, status, err := h.weeklyReview(userID, req.From, req.To)
+	if err != nil {
+		return utils.RespondError(c, status, err.Error())
+	}
+
+	request := []models.ChatMessage{{
+		UserID:  userID,
+		Role:    "user",
+		Message: fmt.Sprintf("Write my review for %s to %s and suggest what to do next.", review.From, review.To),
+	}}
+	message, llmResponse, err := h.ask(c.Context(), userID, reviewKind, llm.BuildReviewPrompt(pc, review), request, nil)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
+		"review": review,
+		"narrative": fiber.Map{
+			"id":      message.ID,
+			"message": llmResponse.Message,
+			"actions": llmResponse.Actions,
+		},
+	})
+}
+
+// weeklyReview builds the review between two local dates, given as YYYY-MM-DD or empty
+// for the defaults, along with the prompt context it was built from. Errors are ready
+// to show to the user with the returned status.
+func (h *ChatHandler) weeklyReview(userID uuid.UUID, fromValue, toValue string) (llm.PromptContext, engine.Review, int, error) {
+	pc, uc, err := h.promptContext(userID)
+	if err != nil {
+		return pc, engine.Review{}, fiber.StatusInternalServerError, err
+	}
+	from, to, err := reviewRange(fromValue, toValue, pc.Now, pc.Location)
+	if err != nil {
+		return pc, engine.Review{}, fiber.StatusBadRequest, err
+	}
+
+	return pc, engine.WeeklyReview(engine.ReviewInput{
+		From:     from,
+		To:       to,
+		Now:      pc.Now,
+		Location: pc.Location,
+		Goals:    pc.Goals,
+		Tasks:    pc.Tasks,
+		Urgency:  pc.Urgency,
+		CheckIns: uc.CheckIns,
+	}), fiber.StatusOK, nil
+}
+
+// reviewRange parses the first and last local day of a review, defaulting to the seven
+// days ending today. It returns the start of the first day and of the day after the last.
+func reviewRange(fromValue, toValue string, now time.Time, loc *time.Location) (time.Time, time.Time, error) {
+	var err error
+	to := utils.StartOfDay(now, loc)
+	if toValue != "" {
+		if to, err = time.ParseInLocation("2006-01-02", toValue, loc); err != nil {
+			return time.Time{}, time.Time{}, errors.New("To must be a date in YYYY-MM-DD format")
+		}
+	}
+	from := to.AddDate(0, 0, -6)
+	if fromValue != "" {
+		if from, err = time.ParseInLocation("2006-01-02", fromValue, loc); err != nil {
+			return time.Time{}, time.Time{}, errors.New("From must be a date in YYYY-MM-DD format")
+		}
+	}
+
+	end := to.AddDate(0, 0, 1)
+	if from.After(to) || from.AddDate(0, 0, maxReviewDays).Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("From must be on or before to, at most %d days apart", maxReviewDays)
+	}
+	return from, end, nil
+}
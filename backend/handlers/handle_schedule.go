@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// GetSchedule time-blocks the user's open tasks into their working hours for the next days.
+// The schedule is built from the current tasks and busy blocks on every request, so
+// completing, adding or editing a task re-flows it without any extra bookkeeping.
+func (p *PlanHandler) GetSchedule(c fiber.Ctx) error {
+	type scheduleBlock struct {
+		TaskID    uuid.UUID `json:"task_id"`
+		GoalID    uuid.UUID `json:"goal_id"`
+		Title     string    `json:"title"`
+		Start     time.Time `json:"start"`
+		End       time.Time `json:"end"`
+		LocalDate string    `json:"local_date"`
+		Hours     float64   `json:"hours"`
+	}
+
+	type unscheduledTask struct {
+		TaskID         uuid.UUID `json:"task_id"`
+		Title          string    `json:"title"`
+		RemainingHours float64   `json:"remaining_hours"`
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	days := fiber.Query[int](c, "days", 7)
+	if days < 1 || days > 14 {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Days must be between 1 and 14")
+	}
+
+	loc, err := services.UserLocation(p.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	uc, err := services.LoadUrgencyContext(p.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve tasks")
+	}
+
+	now := currentTime(p.Clock)
+	until := utils.EndOfDay(now.In(loc).AddDate(0, 0, days-1), loc)
+
+	var blocks []models.BusyBlock
+	if err := p.DB.Where("user_id = ? AND ends_at > ? AND starts_at < ?", userID, now, until).Find(&blocks).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve busy blocks")
+	}
+
+	busy := make([]engine.Interval, 0, len(blocks))
+	for _, block := range blocks {
+		busy = append(busy, engine.Interval{Start: block.StartsAt, End: block.EndsAt})
+	}
+
+	tasks := make([]engine.ScheduleTask, 0, len(uc.OpenTasks))
+	byID := make(map[uuid.UUID]models.Task, len(uc.OpenTasks))
+	for _, task := range uc.OpenTasks {
+		byID[task.ID] = task
+		tasks = append(tasks, engine.ScheduleTask{
+			Task:     task,
+			Deadline: engine.EffectiveDeadline(task, uc.Goals[task.GoalID]),
+			Hours:    engine.TaskHours(task, uc.DefaultEstimateHours),
+		})
+	}
+
+	schedule := engine.BuildSchedule(tasks, uc.Blockers, uc.Hours, busy, now, until, loc)
+
+	scheduled := make([]scheduleBlock, 0, len(schedule.Blocks))
+	for _, block := range schedule.Blocks {
+		task := byID[block.TaskID]
+		scheduled = append(scheduled, scheduleBlock{
+			TaskID:    task.ID,
+			GoalID:    task.GoalID,
+			Title:     task.Title,
+			Start:     block.Start,
+			End:       block.End,
+			LocalDate: utils.LocalDate(block.Start, loc),
+			Hours:     block.End.Sub(block.Start).Hours(),
+		})
+	}
+
+	unscheduled := make([]unscheduledTask, 0, len(schedule.Unscheduled))
+	for _, item := range schedule.Unscheduled {
+		unscheduled = append(unscheduled, unscheduledTask{
+			TaskID:         item.TaskID,
+			Title:          byID[item.TaskID].Title,
+			RemainingHours: item.RemainingHours,
+		})
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
+		"from":        now,
+		"until":       until,
+		"blocks":      scheduled,
+		"busy":        blocks,
+		"unscheduled": unscheduled,
+		"late":        schedule.Late,
+	})
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"strings"
+	"time"
 
 	"github.com/Pranay0205/velo/backend/auth"
 	"github.com/Pranay0205/velo/backend/models"
@@ -17,6 +18,7 @@ func (h *AuthHandler) Signup(c fiber.Ctx) error {
 		LastName string `json:"last_name"`
 		Email    string `json:"email"`
 		Password string `json:"password"`
+		Timezone string `json:"timezone"` // optional IANA zone, defaults to UTC
 	}
 
 	var req requestStruct
@@ -26,6 +28,12 @@ func (h *AuthHandler) Signup(c fiber.Ctx) error {
 		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
 	}
 
+	if req.Timezone == "" {
+		req.Timezone = "UTC"
+	} else if _, err := time.LoadLocation(req.Timezone); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid timezone")
+	}
+
 	hashedPassword, err := auth.HashPassword(req.Password)
 	if err != nil {
 		return utils.RespondError(c, fiber.StatusInternalServerError, err.Error())
@@ -37,6 +45,7 @@ func (h *AuthHandler) Signup(c fiber.Ctx) error {
 		LastName:     req.LastName,
 		Email:        req.Email,
 		PasswordHash: hashedPassword,
+		Timezone:     req.Timezone,
 	}
 
 	result := h.DB.Create(&user)
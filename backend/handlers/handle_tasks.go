@@ -1,13 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"time"
 
 	"github.com/Pranay0205/velo/backend/engine"
 	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
 	"github.com/Pranay0205/velo/backend/utils"
 	"github.com/gofiber/fiber/v3"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 var userPriority map[int]string = map[int]string{
@@ -16,13 +19,37 @@ var userPriority map[int]string = map[int]string{
 	3: "High",
 }
 
+// validScopes are the edit scopes for recurring tasks; empty means this occurrence only
+var validScopes = map[string]bool{
+	"":       true,
+	"this":   true,
+	"future": true,
+}
+
+// taskResponse adds the deadline rendered as a date in the user's timezone
+// and, when requested, how the task's urgency was calculated
+type taskResponse struct {
+	models.Task
+	DeadlineLocal    string                      `json:"deadline_local,omitempty"`
+	Recurrence       string                      `json:"recurrence,omitempty"` // Rule of the task's active series
+	Blocked          bool                        `json:"blocked,omitempty"`    // Waiting on an open task
+	BlockedBy        []uuid.UUID                 `json:"blocked_by,omitempty"`
+	Checklist        *services.ChecklistProgress `json:"checklist,omitempty"` // Ticked and total checklist items
+	UrgencyBreakdown *engine.Breakdown           `json:"urgency_breakdown,omitempty"`
+}
+
+func newTaskResponse(task models.Task, loc *time.Location) taskResponse {
+	return taskResponse{Task: task, DeadlineLocal: utils.LocalDate(task.Deadline, loc)}
+}
+
 func (t *TaskHandler) CreateTask(c fiber.Ctx) error {
 	type createTaskRequest struct {
-		Title        string     `json:"title"`
-		GoalID       uuid.UUID  `json:"goal_id"`
-		Description  *string    `json:"description"`
-		Deadline     *time.Time `json:"deadline"`
-		UserPriority int        `json:"user_priority"` // 1-3: Low, Med, High
+		Title        string    `json:"title"`
+		GoalID       uuid.UUID `json:"goal_id"`
+		Description  *string   `json:"description"`
+		Deadline     *string   `json:"deadline"`      // YYYY-MM-DD in the user's timezone, or RFC 3339
+		UserPriority int       `json:"user_priority"` // 1-3: Low, Med, High
+		Recurrence   *string   `json:"recurrence"`    // RRULE such as FREQ=WEEKLY;BYDAY=FR; the deadline is the first occurrence
 	}
 
 	var req createTaskRequest
@@ -58,8 +85,26 @@ func (t *TaskHandler) CreateTask(c fiber.Ctx) error {
 		task.Description = *req.Description
 	}
 
+	loc, err := services.UserLocation(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
 	if req.Deadline != nil {
-		task.Deadline = *req.Deadline
+		deadline, err := utils.ParseDeadline(*req.Deadline, loc)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Invalid deadline: "+err.Error())
+		}
+		task.Deadline = deadline
+	}
+
+	var rule *engine.Recurrence
+	if req.Recurrence != nil && *req.Recurrence != "" {
+		parsed, err := engine.ParseRRule(*req.Recurrence, loc)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Invalid recurrence: "+err.Error())
+		}
+		rule = &parsed
 	}
 
 	var goal models.Goal
@@ -67,16 +112,29 @@ func (t *TaskHandler) CreateTask(c fiber.Ctx) error {
 		return utils.RespondError(c, fiber.StatusBadRequest, "Goal not found or doesn't belong to you")
 	}
 
-	if err := t.DB.Create(&task).Error; err != nil {
+	err = t.DB.Transaction(func(tx *gorm.DB) error {
+		if rule != nil {
+			if err := services.StartSeries(tx, &task, *rule, currentTime(t.Clock), loc); err != nil {
+				return err
+			}
+		}
+		return tx.Create(&task).Error
+	})
+	if err != nil {
 		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to create task")
 	}
 
+	syncGoalStatuses(t.DB, userID, currentTime(t.Clock))
+	notifyUrgency(t.Urgency, userID)
+
 	return utils.RespondSuccess(c, fiber.StatusCreated, map[string]interface{}{
-		"id":            task.ID,
-		"title":         task.Title,
-		"description":   task.Description,
-		"deadline":      task.Deadline,
-		"user_priority": userPriority[task.UserPriority],
+		"id":             task.ID,
+		"title":          task.Title,
+		"description":    task.Description,
+		"deadline":       task.Deadline,
+		"deadline_local": utils.LocalDate(task.Deadline, loc),
+		"user_priority":  userPriority[task.UserPriority],
+		"series_id":      task.SeriesID,
 	})
 }
 
@@ -86,6 +144,11 @@ func (t *TaskHandler) GetTasks(c fiber.Ctx) error {
 		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
 	}
 
+	loc, err := services.UserLocation(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
 	// Base query to get tasks for the user
 	query := t.DB.Where("user_id = ?", userID)
 
@@ -104,61 +167,163 @@ func (t *TaskHandler) GetTasks(c fiber.Ctx) error {
 		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve tasks")
 	}
 
-	// Get all goals for the user and store them to slice to calculate metrics
-	var goals []models.Goal
-	if err := t.DB.Where("user_id = ?", userID).Find(&goals).Error; err != nil {
-		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve goals")
+	// Urgency is kept up to date by the background service; only explain it on request
+	var breakdowns map[uuid.UUID]engine.Breakdown
+	var blockers map[uuid.UUID][]uuid.UUID
+	if fiber.Query[bool](c, "explain", false) {
+		uc, err := services.LoadUrgencyContext(t.DB, userID)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve goals")
+		}
+		breakdowns = uc.ExplainTasks(tasks, currentTime(t.Clock), loc)
+		blockers = uc.Blockers
+	} else {
+		blockers, err = services.LoadOpenBlockers(t.DB, userID)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve dependencies")
+		}
 	}
 
-	// Calculate metrics for each goal
-	type GoalMetrics struct {
-		GoalID         uuid.UUID `json:"goal_id"`
-		TotalTasks     int       `json:"total_tasks"`
-		CompletedTasks int       `json:"completed_tasks"`
+	rules, err := services.LoadSeriesRules(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve recurring tasks")
 	}
 
-	// Get task counts for each goal
-	var metrics []GoalMetrics
-	t.DB.Model(&models.Task{}).
-		Select("goal_id, COUNT(*) as total_tasks, COUNT(CASE WHEN is_completed = true THEN 1 END) as completed_tasks").
-		Where("user_id = ?", userID).
-		Group("goal_id").
-		Scan(&metrics)
+	checklists, err := services.LoadChecklistProgress(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve checklists")
+	}
 
-	// Create maps for easy lookup
-	goalMap := make(map[uuid.UUID]models.Goal)
-	for _, g := range goals {
-		goalMap[g.ID] = g
+	response := make([]taskResponse, 0, len(tasks))
+	for _, task := range tasks {
+		item := newTaskResponse(task, loc)
+		if task.SeriesID != nil {
+			item.Recurrence = rules[*task.SeriesID]
+		}
+		if waitingOn := blockers[task.ID]; len(waitingOn) > 0 {
+			item.Blocked = true
+			item.BlockedBy = waitingOn
+		}
+		if progress, ok := checklists[task.ID]; ok {
+			item.Checklist = &progress
+		}
+		if breakdown, ok := breakdowns[task.ID]; ok {
+			item.UrgencyBreakdown = &breakdown
+		}
+		response = append(response, item)
 	}
 
-	// Map to store metrics by goal ID
-	metricsMap := make(map[uuid.UUID]GoalMetrics)
-	for _, m := range metrics {
-		metricsMap[m.GoalID] = m
+	return utils.RespondSuccess(c, fiber.StatusOK, response)
+}
+
+// ForecastUrgency projects how each open task's urgency builds over the next ?days=N days (default 7, max 30)
+func (t *TaskHandler) ForecastUrgency(c fiber.Ctx) error {
+	type taskForecast struct {
+		TaskID         uuid.UUID             `json:"task_id"`
+		GoalID         uuid.UUID             `json:"goal_id"`
+		Title          string                `json:"title"`
+		CurrentUrgency int                   `json:"current_urgency"`
+		Forecast       []engine.UrgencyPoint `json:"forecast"`
 	}
 
-	for i, task := range tasks {
-		if goal, exists := goalMap[task.GoalID]; exists {
-			if m, exists := metricsMap[task.GoalID]; exists {
-				newUrgency := engine.CalculateUrgency(task, goal, m.TotalTasks, m.CompletedTasks)
-				if newUrgency != task.AIUrgency {
-					tasks[i].AIUrgency = newUrgency
-					t.DB.Model(&tasks[i]).Update("ai_urgency", newUrgency)
-				}
-			}
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	days := fiber.Query[int](c, "days", 7)
+	if days < 1 || days > 30 {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Days must be between 1 and 30")
+	}
+
+	loc, err := services.UserLocation(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	var tasks []models.Task
+	if err := t.DB.Scopes(services.OpenTasks).Where("user_id = ?", userID).Find(&tasks).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve tasks")
+	}
+
+	uc, err := services.LoadUrgencyContext(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve goals")
+	}
+
+	now := currentTime(t.Clock)
+	response := make([]taskForecast, 0, len(tasks))
+	for _, task := range tasks {
+		in, ok := uc.Input(task, now, loc)
+		if !ok {
+			continue
 		}
+
+		points := engine.ForecastUrgency(uc.Scorer, in, days)
+		response = append(response, taskForecast{
+			TaskID:         task.ID,
+			GoalID:         task.GoalID,
+			Title:          task.Title,
+			CurrentUrgency: points[0].Urgency,
+			Forecast:       points,
+		})
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, response)
+}
+
+// ExplainUrgency returns the structured breakdown behind a task's urgency score
+func (t *TaskHandler) ExplainUrgency(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid task ID")
+	}
+
+	var task models.Task
+	if err := t.DB.Where("id = ? AND user_id = ?", taskID, userID).First(&task).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusNotFound, "Task not found")
+	}
+
+	loc, err := services.UserLocation(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	uc, err := services.LoadUrgencyContext(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve goals")
+	}
+
+	breakdown, ok := uc.ExplainTasks([]models.Task{task}, currentTime(t.Clock), loc)[task.ID]
+	if !ok {
+		return utils.RespondError(c, fiber.StatusNotFound, "Task's goal not found")
 	}
 
-	return utils.RespondSuccess(c, fiber.StatusOK, tasks)
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
+		"task_id":   task.ID,
+		"urgency":   breakdown.Urgency,
+		"breakdown": breakdown,
+		"summary":   breakdown.Summary(),
+	})
 }
 
 func (t *TaskHandler) UpdateTask(c fiber.Ctx) error {
 	type updateTaskRequest struct {
-		Title        *string    `json:"title"`
-		Description  *string    `json:"description"`
-		Deadline     *time.Time `json:"deadline"`
-		UserPriority *int       `json:"user_priority"` // 1-3: Low, Med, High
-		IsCompleted  *bool      `json:"is_completed"`
+		Title          *string  `json:"title"`
+		Description    *string  `json:"description"`
+		Deadline       *string  `json:"deadline"`      // YYYY-MM-DD in the user's timezone, or RFC 3339
+		UserPriority   *int     `json:"user_priority"` // 1-3: Low, Med, High
+		IsCompleted    *bool    `json:"is_completed"`
+		Status         *string  `json:"status"` // todo, in_progress, waiting, done or cancelled
+		EstimatedHours *float64 `json:"estimated_hours"`
+		TrackedHours   *float64 `json:"tracked_hours"` // Time actually spent on the task
+		Recurrence     *string  `json:"recurrence"`    // RRULE, or "" to stop repeating
+		Scope          string   `json:"scope"`         // For recurring tasks: "this" occurrence (default) or all "future" ones
 	}
 
 	var req updateTaskRequest
@@ -177,39 +342,138 @@ func (t *TaskHandler) UpdateTask(c fiber.Ctx) error {
 		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid task ID")
 	}
 
+	if !validScopes[req.Scope] {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Scope must be this or future")
+	}
+
+	if req.Status != nil && !engine.ValidTaskStatus(*req.Status) {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Status must be one of todo, in_progress, waiting, done, cancelled")
+	}
+
+	if (req.EstimatedHours != nil && *req.EstimatedHours < 0) || (req.TrackedHours != nil && *req.TrackedHours < 0) {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Hours cannot be negative")
+	}
+
 	var task models.Task
 	if err := t.DB.Where("id = ? AND user_id = ?", taskID, userID).First(&task).Error; err != nil {
 		return utils.RespondError(c, fiber.StatusNotFound, "Task not found")
 	}
 
-	if req.Title != nil {
-		task.Title = *req.Title
-	}
-
-	if req.Description != nil {
-		task.Description = *req.Description
+	loc, err := services.UserLocation(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
 	}
 
+	var deadline *time.Time
 	if req.Deadline != nil {
-		task.Deadline = *req.Deadline
+		parsed, err := utils.ParseDeadline(*req.Deadline, loc)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Invalid deadline: "+err.Error())
+		}
+		deadline = &parsed
 	}
 
 	if req.UserPriority != nil {
 		if _, ok = userPriority[*req.UserPriority]; !ok {
 			return utils.RespondError(c, fiber.StatusBadRequest, "User priority must be between low and high")
 		}
-		task.UserPriority = *req.UserPriority
 	}
 
+	var rule *engine.Recurrence
+	if req.Recurrence != nil && *req.Recurrence != "" {
+		parsed, err := engine.ParseRRule(*req.Recurrence, loc)
+		if err != nil {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Invalid recurrence: "+err.Error())
+		}
+		rule = &parsed
+	}
+
+	future := req.Scope == "future" && task.SeriesID != nil
+	if req.Recurrence != nil && task.SeriesID != nil && !future {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Recurrence can only be changed for all future occurrences")
+	}
+
+	now := currentTime(t.Clock)
+	wasOpen := engine.TaskOpen(task)
+	wasCompleted := task.IsCompleted
 	if req.IsCompleted != nil {
-		task.IsCompleted = *req.IsCompleted
+		engine.SetTaskCompleted(&task, *req.IsCompleted, now)
+	}
+	if req.Status != nil {
+		engine.SetTaskStatus(&task, *req.Status, now)
+	}
+	if req.EstimatedHours != nil {
+		task.EstimatedHours = req.EstimatedHours
+	}
+	if req.TrackedHours != nil {
+		task.TrackedHours = req.TrackedHours
 	}
 
-	if err := t.DB.Save(&task).Error; err != nil {
+	err = t.DB.Transaction(func(tx *gorm.DB) error {
+		if future {
+			changes := services.SeriesChanges{
+				Title:        req.Title,
+				Description:  req.Description,
+				UserPriority: req.UserPriority,
+				Deadline:     deadline,
+				Rule:         rule,
+			}
+			if req.Recurrence != nil && rule == nil {
+				if err := services.StopSeries(tx, task, true, now); err != nil {
+					return err
+				}
+				// A stopped series is not restarted; the new deadline only moves this occurrence
+				changes.Deadline = nil
+				if deadline != nil {
+					task.Deadline = *deadline
+				}
+			}
+			if err := services.ApplyToFutureOccurrences(tx, &task, changes, now, loc); err != nil {
+				return err
+			}
+		} else {
+			if req.Title != nil {
+				task.Title = *req.Title
+			}
+			if req.Description != nil {
+				task.Description = *req.Description
+			}
+			if deadline != nil {
+				task.Deadline = *deadline
+			}
+			if req.UserPriority != nil {
+				task.UserPriority = *req.UserPriority
+			}
+			if rule != nil {
+				if err := services.StartSeries(tx, &task, *rule, now, loc); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.Save(&task).Error; err != nil {
+			return err
+		}
+		return services.RecordCompletionChange(tx, task, wasCompleted, engine.SourceREST, now)
+	})
+	if err != nil {
 		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to update task")
 	}
 
-	return utils.RespondSuccess(c, fiber.StatusOK, task)
+	if wasOpen && !engine.TaskOpen(task) {
+		advanceSeries(t.DB, task, now)
+	}
+	syncGoalStatuses(t.DB, userID, now)
+	notifyUrgency(t.Urgency, userID)
+
+	response := newTaskResponse(task, loc)
+	if task.SeriesID != nil {
+		if rules, err := services.LoadSeriesRules(t.DB, userID); err == nil {
+			response.Recurrence = rules[*task.SeriesID]
+		}
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, response)
 }
 
 func (t *TaskHandler) DeleteTask(c fiber.Ctx) error {
@@ -223,16 +487,42 @@ func (t *TaskHandler) DeleteTask(c fiber.Ctx) error {
 		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid task ID")
 	}
 
-	if err := t.DB.Where("id = ? AND user_id = ?", taskID, userID).Delete(&models.Task{}).Error; err != nil {
+	// With scope=future, a recurring task's series stops and its later occurrences go too
+	scope := c.Query("scope")
+	if !validScopes[scope] {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Scope must be this or future")
+	}
+
+	now := currentTime(t.Clock)
+	var task models.Task
+	if err := t.DB.Where("id = ? AND user_id = ?", taskID, userID).First(&task).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusNotFound, "Task not found")
+	}
+
+	err = services.SoftDeleteTask(t.DB, userID, taskID, now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.RespondError(c, fiber.StatusNotFound, "Task not found")
+	}
+	if err != nil {
 		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to delete task")
 	}
 
+	if scope == "future" {
+		if err := services.StopSeries(t.DB, task, true, now); err != nil {
+			return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to stop recurring task")
+		}
+	}
+
+	syncGoalStatuses(t.DB, userID, now)
+	notifyUrgency(t.Urgency, userID)
+
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
 func (t *TaskHandler) CompleteTask(c fiber.Ctx) error {
 	type completeTaskRequest struct {
-		IsCompleted bool `json:"is_completed"`
+		IsCompleted bool   `json:"is_completed"`
+		Source      string `json:"source"` // quick_add when the client ticked it from its quick-add list; defaults to rest
 	}
 
 	var req completeTaskRequest
@@ -250,16 +540,64 @@ func (t *TaskHandler) CompleteTask(c fiber.Ctx) error {
 		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid task ID")
 	}
 
+	source := req.Source
+	if source == "" {
+		source = engine.SourceREST
+	}
+	if source != engine.SourceREST && source != engine.SourceQuickAdd {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Source must be rest or quick_add")
+	}
+
 	var task models.Task
 	if err := t.DB.Where("id = ? AND user_id = ?", taskID, userID).First(&task).Error; err != nil {
 		return utils.RespondError(c, fiber.StatusNotFound, "Task not found")
 	}
 
-	task.IsCompleted = req.IsCompleted
+	now := currentTime(t.Clock)
+	wasOpen := engine.TaskOpen(task)
+	wasCompleted := task.IsCompleted
+	engine.SetTaskCompleted(&task, req.IsCompleted, now)
 
-	if err := t.DB.Save(&task).Error; err != nil {
+	err = t.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&task).Error; err != nil {
+			return err
+		}
+		return services.RecordCompletionChange(tx, task, wasCompleted, source, now)
+	})
+	if err != nil {
 		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to update task completion status")
 	}
 
-	return utils.RespondSuccess(c, fiber.StatusOK, task)
+	if wasOpen && !engine.TaskOpen(task) {
+		advanceSeries(t.DB, task, now)
+	}
+	syncGoalStatuses(t.DB, userID, now)
+	notifyUrgency(t.Urgency, userID)
+
+	loc, err := services.UserLocation(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, newTaskResponse(task, loc))
+}
+
+// GetTaskCompletions lists when a task was completed, reopened or cancelled once done, oldest first
+func (t *TaskHandler) GetTaskCompletions(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	task, err := t.findTask(userID, c.Params("id"))
+	if err != nil {
+		return respondTaskLookupError(c, err)
+	}
+
+	events := []models.TaskCompletionEvent{}
+	if err := t.DB.Where("task_id = ?", task.ID).Order("occurred_at").Find(&events).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve completion history")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, events)
 }
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetTrash lists deleted goals with how many tasks went with them, and tasks deleted on
+// their own whose goal still exists, most recently deleted first
+func (g *GoalHandler) GetTrash(c fiber.Ctx) error {
+	type trashedGoal struct {
+		models.Goal
+		TaskCount int `json:"task_count"`
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	var goals []models.Goal
+	if err := g.DB.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).Order("deleted_at desc").Find(&goals).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve deleted goals")
+	}
+
+	var tasks []models.Task
+	if err := g.DB.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).Order("deleted_at desc").Find(&tasks).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve deleted tasks")
+	}
+
+	deletedGoals := make(map[uuid.UUID]models.Goal, len(goals))
+	for _, goal := range goals {
+		deletedGoals[goal.ID] = goal
+	}
+
+	taskCounts := make(map[uuid.UUID]int)
+	looseTasks := []models.Task{}
+	for _, task := range tasks {
+		goal, ok := deletedGoals[task.GoalID]
+		switch {
+		case !ok:
+			looseTasks = append(looseTasks, task)
+		case !task.DeletedAt.Time.Before(goal.DeletedAt.Time):
+			taskCounts[task.GoalID]++
+		}
+	}
+
+	trashedGoals := make([]trashedGoal, 0, len(goals))
+	for _, goal := range goals {
+		trashedGoals = append(trashedGoals, trashedGoal{Goal: goal, TaskCount: taskCounts[goal.ID]})
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
+		"goals": trashedGoals,
+		"tasks": looseTasks,
+	})
+}
+
+// RestoreGoal brings a goal back from the trash along with the tasks deleted with it
+func (g *GoalHandler) RestoreGoal(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	goalID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid goal ID")
+	}
+
+	err = services.RestoreGoal(g.DB, userID, goalID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.RespondError(c, fiber.StatusNotFound, "Goal not found in trash")
+	}
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to restore goal")
+	}
+
+	// The deadline may have passed while the goal was in the trash
+	syncGoalStatuses(g.DB, userID, currentTime(g.Clock))
+	notifyUrgency(g.Urgency, userID)
+
+	loc, err := services.UserLocation(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	var goal models.Goal
+	if err := g.DB.First(&goal, "id = ?", goalID).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve goal")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, newGoalResponse(goal, loc))
+}
+
+// RestoreTask brings a single task back from the trash
+func (t *TaskHandler) RestoreTask(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid task ID")
+	}
+
+	err = services.RestoreTask(t.DB, userID, taskID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.RespondError(c, fiber.StatusNotFound, "Task not found in trash")
+	}
+	if errors.Is(err, services.ErrGoalInTrash) {
+		return utils.RespondError(c, fiber.StatusConflict, "Restore the task's goal first")
+	}
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to restore task")
+	}
+
+	syncGoalStatuses(t.DB, userID, currentTime(t.Clock))
+	notifyUrgency(t.Urgency, userID)
+
+	loc, err := services.UserLocation(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	var task models.Task
+	if err := t.DB.First(&task, "id = ?", taskID).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve task")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, newTaskResponse(task, loc))
+}
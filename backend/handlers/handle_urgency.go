@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// GetUrgencySettings returns the scoring strategy, weights and thresholds used for the user's tasks
+func (t *TaskHandler) GetUrgencySettings(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	settings, err := services.LoadUrgencySettings(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve urgency settings")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, settings)
+}
+
+// UpdateUrgencySettings changes any subset of the user's scoring settings
+func (t *TaskHandler) UpdateUrgencySettings(c fiber.Ctx) error {
+	type updateUrgencySettingsRequest struct {
+		Strategy             *string  `json:"strategy"`
+		PriorityWeight       *float64 `json:"priority_weight"`
+		DeadlineWeight       *float64 `json:"deadline_weight"`
+		GoalLagWeight        *float64 `json:"goal_lag_weight"`
+		StalenessWeight      *float64 `json:"staleness_weight"`
+		ElapsedThreshold1    *float64 `json:"elapsed_threshold_1"`
+		ElapsedThreshold2    *float64 `json:"elapsed_threshold_2"`
+		ElapsedThreshold3    *float64 `json:"elapsed_threshold_3"`
+		ElapsedThreshold4    *float64 `json:"elapsed_threshold_4"`
+		CriticalDays         *int     `json:"critical_days"`
+		WarningDays          *int     `json:"warning_days"`
+		StaleIdleRatio       *float64 `json:"stale_idle_ratio"`
+		DefaultEstimateHours *float64 `json:"default_estimate_hours"`
+	}
+
+	var req updateUrgencySettingsRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	settings, err := services.LoadUrgencySettings(t.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve urgency settings")
+	}
+
+	if req.Strategy != nil {
+		if !engine.Strategies[*req.Strategy] {
+			return utils.RespondError(c, fiber.StatusBadRequest, "Strategy must be one of default, wsjf")
+		}
+		settings.Strategy = *req.Strategy
+	}
+
+	assign := func(dst *float64, src *float64) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	assign(&settings.PriorityWeight, req.PriorityWeight)
+	assign(&settings.DeadlineWeight, req.DeadlineWeight)
+	assign(&settings.GoalLagWeight, req.GoalLagWeight)
+	assign(&settings.StalenessWeight, req.StalenessWeight)
+	assign(&settings.ElapsedThreshold1, req.ElapsedThreshold1)
+	assign(&settings.ElapsedThreshold2, req.ElapsedThreshold2)
+	assign(&settings.ElapsedThreshold3, req.ElapsedThreshold3)
+	assign(&settings.ElapsedThreshold4, req.ElapsedThreshold4)
+	assign(&settings.StaleIdleRatio, req.StaleIdleRatio)
+	assign(&settings.DefaultEstimateHours, req.DefaultEstimateHours)
+
+	if req.CriticalDays != nil {
+		settings.CriticalDays = *req.CriticalDays
+	}
+
+	if req.WarningDays != nil {
+		settings.WarningDays = *req.WarningDays
+	}
+
+	if err := validateUrgencySettings(settings); err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := t.DB.Save(&settings).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to update urgency settings")
+	}
+
+	// Stored scores were computed with the old settings
+	notifyUrgency(t.Urgency, userID)
+
+	return utils.RespondSuccess(c, fiber.StatusOK, settings)
+}
+
+func validateUrgencySettings(s models.UrgencySettings) error {
+	for _, weight := range []float64{s.PriorityWeight, s.DeadlineWeight, s.GoalLagWeight, s.StalenessWeight} {
+		if weight < 0 || weight > 5 {
+			return errors.New("Weights must be between 0 and 5")
+		}
+	}
+
+	thresholds := []float64{s.ElapsedThreshold1, s.ElapsedThreshold2, s.ElapsedThreshold3, s.ElapsedThreshold4}
+	for i, threshold := range thresholds {
+		if threshold <= 0 || threshold > 1 || (i > 0 && threshold <= thresholds[i-1]) {
+			return errors.New("Elapsed thresholds must be increasing fractions between 0 and 1")
+		}
+	}
+
+	if s.CriticalDays < 0 || s.WarningDays < s.CriticalDays || s.WarningDays > 30 {
+		return errors.New("Critical days must be at least 0 and no more than warning days (max 30)")
+	}
+
+	if s.StaleIdleRatio <= 0 || s.StaleIdleRatio >= 1 {
+		return errors.New("Stale idle ratio must be between 0 and 1")
+	}
+
+	if s.DefaultEstimateHours <= 0 || s.DefaultEstimateHours > 40 {
+		return errors.New("Default estimate hours must be between 0 and 40")
+	}
+
+	return nil
+}
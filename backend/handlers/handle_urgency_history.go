@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// GetUrgencyHistory returns every recorded urgency change for a task, oldest first
+func (t *TaskHandler) GetUrgencyHistory(c fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid task ID")
+	}
+
+	var task models.Task
+	if err := t.DB.Where("id = ? AND user_id = ?", taskID, userID).First(&task).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusNotFound, "Task not found")
+	}
+
+	var history []models.UrgencyHistory
+	if err := t.DB.Where("task_id = ?", task.ID).Order("recorded_at asc").Find(&history).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve urgency history")
+	}
+
+	return utils.RespondSuccess(c, fiber.StatusOK, history)
+}
+
+// GetUrgencyTrend aggregates the urgency history of a goal's tasks into a daily series and
+// lists open tasks that have stayed critical for at least stuck_days without being touched,
+// longest stuck first
+func (g *GoalHandler) GetUrgencyTrend(c fiber.Ctx) error {
+	type stuckTask struct {
+		TaskID        uuid.UUID `json:"task_id"`
+		Title         string    `json:"title"`
+		Urgency       int       `json:"urgency"`
+		CriticalSince time.Time `json:"critical_since"`
+		CriticalDays  int       `json:"critical_days"`
+	}
+
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return utils.RespondError(c, fiber.StatusUnauthorized, "Invalid user session")
+	}
+
+	goalID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Invalid goal ID")
+	}
+
+	days := fiber.Query[int](c, "days", 30)
+	if days < 1 || days > 90 {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Days must be between 1 and 90")
+	}
+
+	stuckDays := fiber.Query[int](c, "stuck_days", 14)
+	if stuckDays < 1 {
+		return utils.RespondError(c, fiber.StatusBadRequest, "Stuck days must be at least 1")
+	}
+
+	var goal models.Goal
+	if err := g.DB.Where("id = ? AND user_id = ?", goalID, userID).First(&goal).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusNotFound, "Goal not found")
+	}
+
+	loc, err := services.UserLocation(g.DB, userID)
+	if err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve user timezone")
+	}
+
+	var history []models.UrgencyHistory
+	if err := g.DB.Where("goal_id = ?", goal.ID).Order("recorded_at asc").Find(&history).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve urgency history")
+	}
+
+	var tasks []models.Task
+	if err := g.DB.Where("goal_id = ? AND user_id = ?", goal.ID, userID).Find(&tasks).Error; err != nil {
+		return utils.RespondError(c, fiber.StatusInternalServerError, "Failed to retrieve tasks")
+	}
+
+	// Completed and cancelled tasks drop out of the trend from the moment they stop being scored
+	open := make(map[uuid.UUID]models.Task, len(tasks))
+	for _, task := range tasks {
+		if engine.TaskOpen(task) {
+			open[task.ID] = task
+		}
+	}
+
+	samples := make([]engine.UrgencySample, 0, len(history))
+	byTask := make(map[uuid.UUID][]engine.UrgencySample)
+	for _, entry := range history {
+		if _, ok := open[entry.TaskID]; !ok {
+			continue
+		}
+		sample := engine.UrgencySample{TaskID: entry.TaskID, Urgency: entry.Urgency, At: entry.RecordedAt}
+		samples = append(samples, sample)
+		byTask[entry.TaskID] = append(byTask[entry.TaskID], sample)
+	}
+
+	now := currentTime(g.Clock)
+
+	stuck := []stuckTask{}
+	for taskID, taskSamples := range byTask {
+		since := engine.CriticalSince(taskSamples)
+		if since == nil {
+			continue
+		}
+
+		task := open[taskID]
+		criticalDays := int(now.Sub(*since).Hours() / 24)
+		if criticalDays < stuckDays || task.UpdatedAt.After(*since) {
+			continue
+		}
+
+		stuck = append(stuck, stuckTask{
+			TaskID:        task.ID,
+			Title:         task.Title,
+			Urgency:       task.AIUrgency,
+			CriticalSince: *since,
+			CriticalDays:  criticalDays,
+		})
+	}
+
+	// Longest stuck first; the map gives no order of its own
+	sort.Slice(stuck, func(i, j int) bool {
+		if !stuck[i].CriticalSince.Equal(stuck[j].CriticalSince) {
+			return stuck[i].CriticalSince.Before(stuck[j].CriticalSince)
+		}
+		return stuck[i].TaskID.String() < stuck[j].TaskID.String()
+	})
+
+	return utils.RespondSuccess(c, fiber.StatusOK, fiber.Map{
+		"goal_id":        goal.ID,
+		"trend":          engine.UrgencyTrend(samples, now, days, loc),
+		"stuck_critical": stuck,
+	})
+}
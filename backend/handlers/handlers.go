@@ -1,7 +1,14 @@
 package handlers
 
 import (
+	"log"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
 	"github.com/Pranay0205/velo/backend/llm"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -10,15 +17,72 @@ type AuthHandler struct {
 	JWTSecret string
 }
 
+type PreferencesHandler struct {
+	DB      *gorm.DB
+	Urgency UrgencyNotifier
+}
+
 type GoalHandler struct {
-	DB *gorm.DB
+	DB      *gorm.DB
+	Clock   engine.Clock
+	Urgency UrgencyNotifier
 }
 
 type TaskHandler struct {
-	DB *gorm.DB
+	DB      *gorm.DB
+	Clock   engine.Clock
+	Urgency UrgencyNotifier
+}
+
+type PlanHandler struct {
+	DB    *gorm.DB
+	Clock engine.Clock
+}
+
+type AnalyticsHandler struct {
+	DB    *gorm.DB
+	Clock engine.Clock
 }
 
 type ChatHandler struct {
-	DB     *gorm.DB
-	Gemini *llm.GeminiClient
+	DB      *gorm.DB
+	Gemini  llm.ChatClient
+	Clock   engine.Clock
+	Urgency UrgencyNotifier
+}
+
+// UrgencyNotifier is told whenever a user's tasks or goals change so their urgency can be recomputed
+type UrgencyNotifier interface {
+	Notify(userID uuid.UUID)
+}
+
+// notifyUrgency forwards a change to the notifier if one is configured
+func notifyUrgency(notifier UrgencyNotifier, userID uuid.UUID) {
+	if notifier != nil {
+		notifier.Notify(userID)
+	}
+}
+
+// currentTime reads the injected clock, defaulting to the wall clock when none is set
+func currentTime(clock engine.Clock) time.Time {
+	if clock == nil {
+		return time.Now()
+	}
+	return clock.Now()
+}
+
+// syncGoalStatuses applies the automatic goal lifecycle straight away so the response
+// reflects it. A failure is only logged: the urgency service retries on its next run.
+func syncGoalStatuses(db *gorm.DB, userID uuid.UUID, now time.Time) {
+	if err := services.SyncGoalStatuses(db, userID, now); err != nil {
+		log.Printf("Failed to sync goal statuses for user %s: %v", userID, err)
+	}
+}
+
+// advanceSeries generates the next occurrence of a recurring task that was just
+// completed. A failure is only logged: the urgency service generates it once it is due.
+func advanceSeries(db *gorm.DB, task models.Task, now time.Time) {
+	if err := services.AfterOccurrenceCompleted(db, task, now); err != nil {
+		log.Printf("Failed to generate the next occurrence of task %s: %v", task.ID, err)
+	}
 }
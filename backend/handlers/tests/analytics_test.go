@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestAnalytics(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 11, 15, 0, 0, 0, time.UTC) // Wednesday
+
+	user := newTestUser(t, db, "analytics@example.com")
+	goal := models.Goal{UserID: user.ID, Title: "Write thesis", GoalType: "deadline", Status: engine.GoalInProgress, CreatedAt: now.AddDate(0, 0, -10)}
+	db.Create(&goal)
+	outline := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Outline", UserPriority: 2, Deadline: now.AddDate(0, 0, 1), AIUrgency: 6, CreatedAt: now.AddDate(0, 0, -10)}
+	draft := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Draft", UserPriority: 2, Deadline: now.AddDate(0, 0, -1), AIUrgency: 8, CreatedAt: now.AddDate(0, 0, -10)}
+	dropped := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Survey", UserPriority: 1, Status: engine.TaskCancelled, CreatedAt: now.AddDate(0, 0, -10)}
+	db.Create(&outline)
+	db.Create(&draft)
+	db.Create(&dropped)
+
+	clock := &engine.FixedClock{Time: now}
+	taskHandler := &handlers.TaskHandler{DB: db, Clock: clock}
+	goalHandler := &handlers.GoalHandler{DB: db, Clock: clock}
+	analyticsHandler := &handlers.AnalyticsHandler{DB: db, Clock: clock}
+	app := newTestApp(user.ID)
+	app.Put("/tasks/:id", taskHandler.UpdateTask)
+	app.Get("/goals/:id/burndown", goalHandler.GetBurndown)
+	app.Get("/analytics/velocity", analyticsHandler.GetVelocity)
+	app.Get("/analytics/completion", analyticsHandler.GetCompletionStats)
+	app.Get("/analytics/productivity", analyticsHandler.GetProductivity)
+
+	// The outline is finished on time last week, the draft late today
+	clock.Time = now.AddDate(0, 0, -7)
+	send(t, app, "PUT", "/tasks/"+outline.ID.String(), map[string]any{"is_completed": true, "estimated_hours": 2, "tracked_hours": 3})
+	clock.Time = now
+	if status, body := send(t, app, "PUT", "/tasks/"+draft.ID.String(), map[string]any{"is_completed": true}); status != fiber.StatusOK {
+		t.Fatalf("complete draft = %d: %s", status, body)
+	}
+
+	_, body := send(t, app, "GET", "/analytics/velocity?weeks=2", nil)
+	var velocity struct {
+		Weeks []engine.WeeklyVelocity `json:"weeks"`
+	}
+	decodeData(t, body, &velocity)
+	if len(velocity.Weeks) != 2 || velocity.Weeks[0].Completed != 1 || velocity.Weeks[1].Completed != 1 {
+		t.Errorf("velocity = %+v", velocity.Weeks)
+	}
+
+	_, body = send(t, app, "GET", "/analytics/completion", nil)
+	var got engine.CompletionStats
+	decodeData(t, body, &got)
+	if got.Completed != 2 || got.OnTime != 1 || got.Late != 1 || got.AverageUrgency != 7 {
+		t.Errorf("completion stats = %+v", got)
+	}
+	if got.EstimateAccuracy == nil || got.EstimateAccuracy.Tasks != 1 || got.EstimateAccuracy.Ratio != 1.5 {
+		t.Errorf("estimate accuracy = %+v", got.EstimateAccuracy)
+	}
+
+	_, body = send(t, app, "GET", "/analytics/productivity", nil)
+	var productivity engine.ProductivityDistribution
+	decodeData(t, body, &productivity)
+	if productivity.Total != 2 || productivity.ByHour[15] != 2 || productivity.ByWeekday[2] != 2 {
+		t.Errorf("productivity = %+v", productivity)
+	}
+
+	// The cancelled survey stays out of the burn-down
+	_, body = send(t, app, "GET", "/goals/"+goal.ID.String()+"/burndown?days=8", nil)
+	var burndown struct {
+		Burndown []engine.BurndownPoint `json:"burndown"`
+	}
+	decodeData(t, body, &burndown)
+	points := burndown.Burndown
+	if len(points) != 8 || points[0].Remaining != 1 || points[7].Total != 2 || points[7].Remaining != 0 {
+		t.Errorf("burn-down = %+v", points)
+	}
+
+	for _, path := range []string{"/analytics/velocity?weeks=0", "/analytics/completion?days=400", "/goals/" + goal.ID.String() + "/burndown?days=0"} {
+		if status, _ := send(t, app, "GET", path, nil); status != fiber.StatusBadRequest {
+			t.Errorf("GET %s = %d, want 400", path, status)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+func TestTaskBoard(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+
+	user := newTestUser(t, db, "board@example.com")
+	goal := models.Goal{UserID: user.ID, Title: "Launch site", GoalType: "deadline", Status: engine.GoalInProgress}
+	db.Create(&goal)
+	var tasks []models.Task
+	for i, title := range []string{"Design", "Build", "Write copy"} {
+		task := models.Task{UserID: user.ID, GoalID: goal.ID, Title: title, UserPriority: 2, CreatedAt: now.Add(time.Duration(i) * time.Minute)}
+		db.Create(&task)
+		tasks = append(tasks, task)
+	}
+	design, build, copywriting := tasks[0], tasks[1], tasks[2]
+
+	clock := engine.FixedClock{Time: now}
+	taskHandler := &handlers.TaskHandler{DB: db, Clock: clock}
+	goalHandler := &handlers.GoalHandler{DB: db, Clock: clock}
+	app := newTestApp(user.ID)
+	app.Get("/board", taskHandler.GetBoard)
+	app.Put("/tasks/:id/move", taskHandler.MoveTask)
+	app.Put("/tasks/:id", taskHandler.UpdateTask)
+	app.Get("/goals", goalHandler.GetGoals)
+
+	board := func() map[string][]uuid.UUID {
+		status, body := send(t, app, "GET", "/board?goal_id="+goal.ID.String(), nil)
+		if status != fiber.StatusOK {
+			t.Fatalf("GET /board = %d: %s", status, body)
+		}
+		var layout struct {
+			Columns []struct {
+				Status string        `json:"status"`
+				Tasks  []models.Task `json:"tasks"`
+			} `json:"columns"`
+		}
+		decodeData(t, body, &layout)
+		columns := map[string][]uuid.UUID{}
+		for _, column := range layout.Columns {
+			ids := []uuid.UUID{}
+			for _, task := range column.Tasks {
+				ids = append(ids, task.ID)
+			}
+			columns[column.Status] = ids
+		}
+		return columns
+	}
+	sameIDs := func(got, want []uuid.UUID) bool {
+		if len(got) != len(want) {
+			return false
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	columns := board()
+	if len(columns) != 4 || !sameIDs(columns[engine.TaskTodo], []uuid.UUID{design.ID, build.ID, copywriting.ID}) {
+		t.Fatalf("initial board = %v", columns)
+	}
+
+	// Moving to the top of a column puts the task before the others
+	status, body := send(t, app, "PUT", "/tasks/"+copywriting.ID.String()+"/move", map[string]any{"status": "todo", "position": 0})
+	if status != fiber.StatusOK {
+		t.Fatalf("move = %d: %s", status, body)
+	}
+	if got := board()[engine.TaskTodo]; !sameIDs(got, []uuid.UUID{copywriting.ID, design.ID, build.ID}) {
+		t.Errorf("todo column after reorder = %v", got)
+	}
+
+	send(t, app, "PUT", "/tasks/"+design.ID.String()+"/move", map[string]any{"status": "in_progress"})
+	var moved models.Task
+	db.First(&moved, "id = ?", design.ID)
+	if moved.Status != engine.TaskInProgress || moved.StartedAt == nil || moved.IsCompleted {
+		t.Errorf("moved task status = %q, started_at = %v, completed = %t", moved.Status, moved.StartedAt, moved.IsCompleted)
+	}
+	var left models.Task
+	db.First(&left, "id = ?", build.ID)
+	if left.Position != 1 {
+		t.Errorf("todo task after the moved one at position %d, want 1 once the gap closes", left.Position)
+	}
+
+	send(t, app, "PUT", "/tasks/"+design.ID.String()+"/move", map[string]any{"status": "done"})
+	db.First(&moved, "id = ?", design.ID)
+	if !moved.IsCompleted || moved.CompletedAt == nil || !moved.CompletedAt.Equal(now) {
+		t.Errorf("done task completed = %t, completed_at = %v", moved.IsCompleted, moved.CompletedAt)
+	}
+	if got := board()[engine.TaskDone]; !sameIDs(got, []uuid.UUID{design.ID}) {
+		t.Errorf("done column = %v", got)
+	}
+
+	// Cancelled tasks leave the board's default columns and the goal's task counts
+	status, body = send(t, app, "PUT", "/tasks/"+build.ID.String(), map[string]any{"status": "cancelled"})
+	if status != fiber.StatusOK {
+		t.Fatalf("cancel = %d: %s", status, body)
+	}
+	for column, ids := range board() {
+		for _, id := range ids {
+			if id == build.ID {
+				t.Errorf("cancelled task shown in the %s column", column)
+			}
+		}
+	}
+
+	_, body = send(t, app, "GET", "/goals", nil)
+	var goals []struct {
+		ID             uuid.UUID `json:"id"`
+		TotalTasks     int       `json:"total_tasks"`
+		CompletedTasks int       `json:"completed_tasks"`
+	}
+	decodeData(t, body, &goals)
+	if len(goals) != 1 || goals[0].TotalTasks != 2 || goals[0].CompletedTasks != 1 {
+		t.Errorf("goal counts = %+v, want 1 of 2 tasks completed", goals)
+	}
+
+	if status, _ := send(t, app, "PUT", "/tasks/"+build.ID.String()+"/move", map[string]any{"status": "archived"}); status != fiber.StatusBadRequest {
+		t.Errorf("move to unknown status = %d, want 400", status)
+	}
+	if status, _ := send(t, app, "PUT", "/tasks/"+build.ID.String(), map[string]any{"status": "blocked"}); status != fiber.StatusBadRequest {
+		t.Errorf("update to unknown status = %d, want 400", status)
+	}
+
+	// The board shows the user's chosen columns in their order
+	prefs := models.DefaultUserPreferences(user.ID)
+	prefs.BoardColumns = "cancelled,todo"
+	db.Create(&prefs)
+	columns = board()
+	if len(columns) != 2 || !sameIDs(columns[engine.TaskCancelled], []uuid.UUID{build.ID}) {
+		t.Errorf("custom board = %v", columns)
+	}
+}
+
+func TestMovingTaskStartsGoal(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+
+	user := newTestUser(t, db, "board-start@example.com")
+	goal := models.Goal{UserID: user.ID, Title: "Launch site", GoalType: "deadline", Status: engine.GoalNotStarted}
+	db.Create(&goal)
+	task := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Design", UserPriority: 2}
+	db.Create(&task)
+
+	taskHandler := &handlers.TaskHandler{DB: db, Clock: engine.FixedClock{Time: now}}
+	app := newTestApp(user.ID)
+	app.Put("/tasks/:id/move", taskHandler.MoveTask)
+
+	if status, body := send(t, app, "PUT", "/tasks/"+task.ID.String()+"/move", map[string]any{"status": "in_progress"}); status != fiber.StatusOK {
+		t.Fatalf("move = %d: %s", status, body)
+	}
+	var started models.Goal
+	db.First(&started, "id = ?", goal.ID)
+	if started.Status != engine.GoalInProgress {
+		t.Errorf("goal status after starting a task = %q, want %q", started.Status, engine.GoalInProgress)
+	}
+}
@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestExecuteActionsHabitNeedsFrequency(t *testing.T) {
+	db := newTestDB(t)
+
+	user := newTestUser(t, db, "chat-actions@example.com")
+
+	handler := &handlers.ChatHandler{DB: db}
+	app := newTestApp(user.ID)
+	app.Post("/chat/actions", handler.ExecuteActions)
+
+	execute := func(goal map[string]any) int {
+		status, _ := send(t, app, "POST", "/chat/actions", map[string]any{
+			"actions": []map[string]any{{"type": "create_goal", "goal": goal}},
+		})
+		return status
+	}
+
+	if status := execute(map[string]any{"title": "Run", "goal_type": "habit"}); status == fiber.StatusOK {
+		t.Errorf("Expected a habit goal without a frequency to be rejected")
+	}
+	var count int64
+	db.Model(&models.Goal{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected no goal to be created, got %d", count)
+	}
+
+	if status := execute(map[string]any{"title": "Run", "goal_type": "habit", "frequency": 3}); status != fiber.StatusOK {
+		t.Fatalf("Expected 200 for a habit goal with a frequency, got %d", status)
+	}
+	var goal models.Goal
+	db.First(&goal, "user_id = ?", user.ID)
+	if goal.Frequency == nil || *goal.Frequency != 3 {
+		t.Errorf("Expected the habit goal with frequency 3, got %+v", goal)
+	}
+}
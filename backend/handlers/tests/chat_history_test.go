@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/llm"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// scriptedLLM replies with a fixed message, or fails when err is set, and remembers
+// the conversations it was sent
+type scriptedLLM struct {
+	reply string
+	err   error
+	sent  [][]models.ChatMessage
+}
+
+func (s *scriptedLLM) Chat(ctx context.Context, systemPrompt string, chatHistory []models.ChatMessage) (*llm.LLMResponse, error) {
+	s.sent = append(s.sent, chatHistory)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &llm.LLMResponse{Message: s.reply}, nil
+}
+
+func setupChatHistoryApp(t *testing.T) (*fiber.App, *gorm.DB, *scriptedLLM, uuid.UUID) {
+	db := newTestDB(t)
+
+	user := newTestUser(t, db, "chat-history@example.com")
+
+	model := &scriptedLLM{reply: "A fresh reply"}
+	handler := &handlers.ChatHandler{DB: db, Gemini: model}
+	app := newTestApp(user.ID)
+	app.Post("/chat/regenerate", handler.RegenerateReply)
+	app.Put("/chat/:id", handler.EditMessage)
+	return app, db, model, user.ID
+}
+
+// seedChat stores the messages in order, a minute apart
+func seedChat(db *gorm.DB, userID uuid.UUID, messages ...*models.ChatMessage) {
+	start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	for i, message := range messages {
+		message.UserID = userID
+		message.CreatedAt = start.Add(time.Duration(i) * time.Minute)
+		db.Create(message)
+	}
+}
+
+func countRows(db *gorm.DB, model any) int64 {
+	var count int64
+	db.Model(model).Count(&count)
+	return count
+}
+
+func TestRegenerateReply(t *testing.T) {
+	app, db, model, userID := setupChatHistoryApp(t)
+
+	question := models.ChatMessage{Role: "user", Message: "Plan my week"}
+	answer := models.ChatMessage{Role: "assistant", Message: "Here is a plan"}
+	seedChat(db, userID, &question, &answer)
+	db.Create(&models.MessageFeedback{UserID: userID, ChatMessageID: answer.ID, Rating: -1})
+	db.Create(&models.ActionOutcome{UserID: userID, ChatMessageID: answer.ID, ActionIndex: 0, ActionType: "create_task", Accepted: false})
+
+	regenerate := func() int {
+		status, _ := send(t, app, "POST", "/chat/regenerate", nil)
+		return status
+	}
+
+	// A failed reply leaves the old one and its feedback alone
+	model.err = errors.New("unavailable")
+	if status := regenerate(); status != fiber.StatusInternalServerError {
+		t.Fatalf("Expected 500 when the LLM fails, got %d", status)
+	}
+	if countRows(db, &models.ChatMessage{}) != 2 || countRows(db, &models.MessageFeedback{}) != 1 {
+		t.Fatalf("Expected the history to be untouched after a failed regenerate")
+	}
+
+	model.err = nil
+	if status := regenerate(); status != fiber.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+
+	if sent := model.sent[len(model.sent)-1]; len(sent) != 1 || sent[0].ID != question.ID {
+		t.Errorf("Expected only the question to be sent, got %+v", sent)
+	}
+	var messages []models.ChatMessage
+	db.Order("created_at").Find(&messages)
+	if len(messages) != 2 || messages[1].ID == answer.ID || messages[1].Message != "A fresh reply" {
+		t.Fatalf("Expected the old answer replaced by the fresh reply, got %+v", messages)
+	}
+	if feedback, outcomes := countRows(db, &models.MessageFeedback{}), countRows(db, &models.ActionOutcome{}); feedback != 0 || outcomes != 0 {
+		t.Errorf("Expected the replaced answer's feedback and outcomes deleted, got %d feedback, %d outcomes", feedback, outcomes)
+	}
+}
+
+func TestEditMessage(t *testing.T) {
+	app, db, model, userID := setupChatHistoryApp(t)
+
+	first := models.ChatMessage{Role: "user", Message: "Plan my week"}
+	firstAnswer := models.ChatMessage{Role: "assistant", Message: "Here is a plan"}
+	second := models.ChatMessage{Role: "user", Message: "Make it shorter"}
+	secondAnswer := models.ChatMessage{Role: "assistant", Message: "A shorter plan"}
+	seedChat(db, userID, &first, &firstAnswer, &second, &secondAnswer)
+	db.Create(&models.MessageFeedback{UserID: userID, ChatMessageID: firstAnswer.ID, Rating: 1})
+	db.Create(&models.ActionOutcome{UserID: userID, ChatMessageID: secondAnswer.ID, ActionIndex: 0, ActionType: "create_task", Accepted: true})
+
+	edit := func(id uuid.UUID, message string) int {
+		status, _ := send(t, app, "PUT", "/chat/"+id.String(), map[string]any{"message": message})
+		return status
+	}
+
+	if status := edit(firstAnswer.ID, "Rewritten"); status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 editing an assistant message, got %d", status)
+	}
+	if status := edit(first.ID, " "); status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 for an empty message, got %d", status)
+	}
+
+	if status := edit(first.ID, "Plan my month"); status != fiber.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+
+	if sent := model.sent[len(model.sent)-1]; len(sent) != 1 || sent[0].Message != "Plan my month" {
+		t.Errorf("Expected only the edited message to be sent, got %+v", sent)
+	}
+	var messages []models.ChatMessage
+	db.Order("created_at").Find(&messages)
+	if len(messages) != 2 || messages[0].ID != first.ID || messages[0].Message != "Plan my month" || messages[1].Message != "A fresh reply" {
+		t.Fatalf("Expected the edited message followed by the fresh reply, got %+v", messages)
+	}
+	if feedback, outcomes := countRows(db, &models.MessageFeedback{}), countRows(db, &models.ActionOutcome{}); feedback != 0 || outcomes != 0 {
+		t.Errorf("Expected feedback and outcomes of the dropped messages deleted, got %d feedback, %d outcomes", feedback, outcomes)
+	}
+}
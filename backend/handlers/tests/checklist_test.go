@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+func TestTaskChecklist(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+
+	user := newTestUser(t, db, "checklist@example.com")
+	goal := models.Goal{UserID: user.ID, Title: "Move house", GoalType: "deadline", Status: engine.GoalInProgress}
+	db.Create(&goal)
+	packing := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Pack", UserPriority: 2, UpdatedAt: now.AddDate(0, 0, -5)}
+	cleaning := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Clean", UserPriority: 2}
+	db.Create(&packing)
+	db.Create(&cleaning)
+
+	clock := engine.FixedClock{Time: now}
+	taskHandler := &handlers.TaskHandler{DB: db, Clock: clock}
+	goalHandler := &handlers.GoalHandler{DB: db, Clock: clock}
+	app := newTestApp(user.ID)
+	app.Get("/goals", goalHandler.GetGoals)
+	app.Get("/tasks/:id/checklist", taskHandler.GetChecklist)
+	app.Post("/tasks/:id/checklist", taskHandler.AddChecklistItem)
+	app.Put("/tasks/:id/checklist/:itemId", taskHandler.UpdateChecklistItem)
+	app.Delete("/tasks/:id/checklist/:itemId", taskHandler.DeleteChecklistItem)
+
+	checklist := func() []models.ChecklistItem {
+		_, body := send(t, app, "GET", "/tasks/"+packing.ID.String()+"/checklist", nil)
+		var items []models.ChecklistItem
+		decodeData(t, body, &items)
+		return items
+	}
+	titles := func(items []models.ChecklistItem) []string {
+		out := make([]string, 0, len(items))
+		for _, item := range items {
+			out = append(out, item.Title)
+		}
+		return out
+	}
+
+	base := "/tasks/" + packing.ID.String() + "/checklist"
+	for _, title := range []string{"Books", "Kitchen", "Clothes"} {
+		if status, _ := send(t, app, "POST", base, map[string]any{"title": title}); status != fiber.StatusCreated {
+			t.Fatalf("Expected 201 adding a checklist item, got %d", status)
+		}
+	}
+	send(t, app, "POST", base, map[string]any{"title": "Boxes", "position": 0})
+	if status, _ := send(t, app, "POST", base, map[string]any{"title": ""}); status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 for an empty title, got %d", status)
+	}
+	if status, _ := send(t, app, "POST", "/tasks/"+uuid.New().String()+"/checklist", map[string]any{"title": "Tape"}); status != fiber.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown task, got %d", status)
+	}
+
+	items := checklist()
+	if got := titles(items); len(got) != 4 || got[0] != "Boxes" || got[3] != "Clothes" {
+		t.Fatalf("Expected Boxes to be inserted first, got %v", got)
+	}
+
+	// Move Boxes to the end, then tick Books and Kitchen
+	send(t, app, "PUT", base+"/"+items[0].ID.String(), map[string]any{"position": 10})
+	items = checklist()
+	if got := titles(items); got[0] != "Books" || got[3] != "Boxes" || items[3].Position != 3 {
+		t.Errorf("Expected Boxes to move to the end, got %v", got)
+	}
+	send(t, app, "PUT", base+"/"+items[0].ID.String(), map[string]any{"is_completed": true})
+	send(t, app, "PUT", base+"/"+items[1].ID.String(), map[string]any{"is_completed": true})
+
+	var touched models.Task
+	db.First(&touched, "id = ?", packing.ID)
+	if !touched.UpdatedAt.Equal(now) {
+		t.Errorf("Expected ticking an item to count as activity on the task, updated at %s", touched.UpdatedAt)
+	}
+
+	// Pack is half done and Clean untouched, so the goal is a quarter done
+	_, body := send(t, app, "GET", "/goals", nil)
+	var goals []struct {
+		Progress       float64 `json:"progress"`
+		CompletedTasks int     `json:"completed_tasks"`
+	}
+	decodeData(t, body, &goals)
+	if len(goals) != 1 || goals[0].Progress != 0.25 || goals[0].CompletedTasks != 0 {
+		t.Errorf("Expected goal progress 0.25 with no completed tasks, got %+v", goals)
+	}
+
+	if status, _ := send(t, app, "DELETE", base+"/"+items[1].ID.String(), nil); status != fiber.StatusNoContent {
+		t.Fatalf("Expected 204 deleting a checklist item, got %d", status)
+	}
+	items = checklist()
+	if got := titles(items); len(got) != 3 || got[1] != "Clothes" || items[1].Position != 1 || items[2].Position != 2 {
+		t.Errorf("Expected positions to close up after deleting, got %v", items)
+	}
+	if status, _ := send(t, app, "DELETE", base+"/"+items[1].ID.String()+"x", nil); status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid item ID, got %d", status)
+	}
+}
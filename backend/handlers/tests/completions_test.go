@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestTaskCompletionHistory(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+
+	user := newTestUser(t, db, "completions@example.com")
+	goal := models.Goal{UserID: user.ID, Title: "Tidy up", GoalType: "deadline", Status: engine.GoalInProgress}
+	db.Create(&goal)
+	task := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Sort the garage", UserPriority: 2}
+	db.Create(&task)
+
+	clock := &engine.FixedClock{Time: now}
+	taskHandler := &handlers.TaskHandler{DB: db, Clock: clock}
+	app := newTestApp(user.ID)
+	app.Patch("/tasks/:id/complete", taskHandler.CompleteTask)
+	app.Put("/tasks/:id", taskHandler.UpdateTask)
+	app.Get("/tasks/:id/completions", taskHandler.GetTaskCompletions)
+
+	path := "/tasks/" + task.ID.String()
+	steps := []struct {
+		method string
+		path   string
+		body   map[string]any
+	}{
+		{"PATCH", path + "/complete", map[string]any{"is_completed": true, "source": "quick_add"}},
+		{"PATCH", path + "/complete", map[string]any{"is_completed": true}}, // Already done: no event
+		{"PUT", path, map[string]any{"status": "in_progress"}},
+		{"PUT", path, map[string]any{"status": "done"}},
+	}
+	for i, step := range steps {
+		clock.Time = now.Add(time.Duration(i) * time.Hour)
+		if status, body := send(t, app, step.method, step.path, step.body); status != fiber.StatusOK {
+			t.Fatalf("%s %s = %d: %s", step.method, step.path, status, body)
+		}
+	}
+
+	if status, _ := send(t, app, "PATCH", path+"/complete", map[string]any{"is_completed": false, "source": "email"}); status != fiber.StatusBadRequest {
+		t.Errorf("unknown source = %d, want 400", status)
+	}
+
+	_, body := send(t, app, "GET", path+"/completions", nil)
+	var events []models.TaskCompletionEvent
+	decodeData(t, body, &events)
+
+	want := []struct {
+		event  string
+		source string
+		at     time.Time
+	}{
+		{engine.CompletionCompleted, engine.SourceQuickAdd, now},
+		{engine.CompletionReopened, engine.SourceREST, now.Add(2 * time.Hour)},
+		{engine.CompletionCompleted, engine.SourceREST, now.Add(3 * time.Hour)},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d completion events, got %+v", len(want), events)
+	}
+	for i, w := range want {
+		got := events[i]
+		if got.Event != w.event || got.Source != w.source || !got.OccurredAt.Equal(w.at) {
+			t.Errorf("event %d = %s from %s at %v, want %s from %s at %v", i, got.Event, got.Source, got.OccurredAt, w.event, w.source, w.at)
+		}
+	}
+
+	var saved models.Task
+	db.First(&saved, "id = ?", task.ID)
+	if saved.CompletedAt == nil || !saved.CompletedAt.Equal(now.Add(3*time.Hour)) {
+		t.Errorf("completed_at = %v, want the last completion", saved.CompletedAt)
+	}
+
+	// Cancelling the done task is its own event, not a reopen
+	clock.Time = now.Add(4 * time.Hour)
+	if status, body := send(t, app, "PUT", path, map[string]any{"status": "cancelled"}); status != fiber.StatusOK {
+		t.Fatalf("PUT cancelled = %d: %s", status, body)
+	}
+	_, body = send(t, app, "GET", path+"/completions", nil)
+	events = nil
+	decodeData(t, body, &events)
+	if n := len(events); n != len(want)+1 || events[n-1].Event != engine.CompletionCancelled {
+		t.Errorf("Expected a cancelled event last, got %+v", events)
+	}
+}
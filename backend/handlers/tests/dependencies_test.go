@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+func TestTaskDependencies(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	user := newTestUser(t, db, "dependencies@example.com")
+	deadline := now.AddDate(0, 0, 14)
+	goal := models.Goal{UserID: user.ID, Title: "Launch the site", GoalType: "deadline", Status: engine.GoalInProgress, Deadline: &deadline}
+	db.Create(&goal)
+
+	hours := func(h float64) *float64 { return &h }
+	design := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Design", UserPriority: 1, EstimatedHours: hours(3), Deadline: now.AddDate(0, 0, 10)}
+	build := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Build", UserPriority: 1, EstimatedHours: hours(6), Deadline: now.AddDate(0, 0, 10)}
+	launch := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Launch", UserPriority: 3, EstimatedHours: hours(1), Deadline: now.AddDate(0, 0, 1)}
+	db.Create(&design)
+	db.Create(&build)
+	db.Create(&launch)
+
+	clock := engine.FixedClock{Time: now}
+	taskHandler := &handlers.TaskHandler{DB: db, Clock: clock}
+	goalHandler := &handlers.GoalHandler{DB: db, Clock: clock}
+	app := newTestApp(user.ID)
+	app.Get("/tasks", taskHandler.GetTasks)
+	app.Get("/tasks/:id/urgency", taskHandler.ExplainUrgency)
+	app.Get("/tasks/:id/dependencies", taskHandler.GetTaskDependencies)
+	app.Post("/tasks/:id/dependencies", taskHandler.AddTaskDependency)
+	app.Delete("/tasks/:id/dependencies/:dependsOnId", taskHandler.RemoveTaskDependency)
+	app.Patch("/tasks/:id/complete", taskHandler.CompleteTask)
+	app.Get("/goals/:id/critical-path", goalHandler.GetCriticalPath)
+
+	depend := func(task, on models.Task) int {
+		status, _ := send(t, app, "POST", "/tasks/"+task.ID.String()+"/dependencies", map[string]any{"depends_on_id": on.ID})
+		return status
+	}
+
+	if status := depend(build, design); status != fiber.StatusCreated {
+		t.Fatalf("Expected 201 adding a dependency, got %d", status)
+	}
+	if status := depend(launch, build); status != fiber.StatusCreated {
+		t.Fatalf("Expected 201 adding a dependency, got %d", status)
+	}
+	if status := depend(launch, build); status != fiber.StatusConflict {
+		t.Errorf("Expected 409 adding the same dependency twice, got %d", status)
+	}
+	if status := depend(design, launch); status != fiber.StatusConflict {
+		t.Errorf("Expected 409 for a dependency cycle, got %d", status)
+	}
+	if status := depend(design, design); status != fiber.StatusConflict {
+		t.Errorf("Expected 409 for a task depending on itself, got %d", status)
+	}
+	if status := depend(design, models.Task{ID: uuid.New()}); status != fiber.StatusNotFound {
+		t.Errorf("Expected 404 depending on an unknown task, got %d", status)
+	}
+
+	_, body := send(t, app, "GET", "/tasks", nil)
+	var tasks []struct {
+		ID        uuid.UUID   `json:"id"`
+		Blocked   bool        `json:"blocked"`
+		BlockedBy []uuid.UUID `json:"blocked_by"`
+	}
+	decodeData(t, body, &tasks)
+	blocked := map[uuid.UUID]bool{}
+	for _, task := range tasks {
+		blocked[task.ID] = task.Blocked
+	}
+	if blocked[design.ID] || !blocked[build.ID] || !blocked[launch.ID] {
+		t.Errorf("Expected build and launch to be blocked, got %v", blocked)
+	}
+
+	// Design holds up the urgent launch through build, so it inherits launch's urgency
+	var launchUrgency, designUrgency struct {
+		Urgency   int              `json:"urgency"`
+		Breakdown engine.Breakdown `json:"breakdown"`
+	}
+	_, body = send(t, app, "GET", "/tasks/"+launch.ID.String()+"/urgency", nil)
+	decodeData(t, body, &launchUrgency)
+	_, body = send(t, app, "GET", "/tasks/"+design.ID.String()+"/urgency", nil)
+	decodeData(t, body, &designUrgency)
+	if designUrgency.Urgency != launchUrgency.Urgency || designUrgency.Breakdown.BlockingUrgency != launchUrgency.Urgency {
+		t.Errorf("Expected design to inherit launch's urgency %d, got %+v", launchUrgency.Urgency, designUrgency)
+	}
+
+	status, body := send(t, app, "GET", "/goals/"+goal.ID.String()+"/critical-path", nil)
+	if status != fiber.StatusOK {
+		t.Fatalf("Expected 200 for the critical path, got %d", status)
+	}
+	var path struct {
+		Tasks []struct {
+			TaskID uuid.UUID `json:"task_id"`
+		} `json:"tasks"`
+		TotalHours         float64 `json:"total_hours"`
+		FitsBeforeDeadline bool    `json:"fits_before_deadline"`
+	}
+	decodeData(t, body, &path)
+	if len(path.Tasks) != 3 || path.Tasks[0].TaskID != design.ID || path.Tasks[2].TaskID != launch.ID || path.TotalHours != 10 {
+		t.Errorf("Expected design, build, launch over 10 hours, got %+v", path)
+	}
+	if !path.FitsBeforeDeadline {
+		t.Error("Expected 10 hours to fit in two weeks")
+	}
+
+	// Completing design unblocks build
+	send(t, app, "PATCH", "/tasks/"+design.ID.String()+"/complete", map[string]any{"is_completed": true})
+	_, body = send(t, app, "GET", "/tasks/"+build.ID.String()+"/dependencies", nil)
+	var deps struct {
+		Blocked   bool          `json:"blocked"`
+		DependsOn []models.Task `json:"depends_on"`
+		Blocks    []models.Task `json:"blocks"`
+	}
+	decodeData(t, body, &deps)
+	if deps.Blocked || len(deps.DependsOn) != 1 || len(deps.Blocks) != 1 {
+		t.Errorf("Expected build to be unblocked with one dependency each way, got %+v", deps)
+	}
+
+	if status, _ := send(t, app, "DELETE", "/tasks/"+launch.ID.String()+"/dependencies/"+build.ID.String(), nil); status != fiber.StatusNoContent {
+		t.Errorf("Expected 204 removing a dependency, got %d", status)
+	}
+	if status, _ := send(t, app, "DELETE", "/tasks/"+launch.ID.String()+"/dependencies/"+build.ID.String(), nil); status != fiber.StatusNotFound {
+		t.Errorf("Expected 404 removing a missing dependency, got %d", status)
+	}
+}
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func setupFeedbackApp(t *testing.T, email string) (*fiber.App, *gorm.DB, uuid.UUID) {
+	db := newTestDB(t)
+
+	user := newTestUser(t, db, email)
+	handler := &handlers.ChatHandler{DB: db}
+	app := newTestApp(user.ID)
+	app.Post("/chat/execute", handler.ExecuteActions)
+	app.Put("/chat/:id/feedback", handler.SubmitFeedback)
+	app.Post("/chat/:id/discard", handler.DiscardActions)
+	app.Get("/chat/feedback/export", handler.ExportFeedback)
+	return app, db, user.ID
+}
+
+func TestFeedbackExport(t *testing.T) {
+	app, db, userID := setupFeedbackApp(t, "feedback@example.com")
+
+	db.Create(&models.ChatMessage{UserID: userID, Message: "Plan my week", Role: "user"})
+	assistant := models.ChatMessage{
+		UserID:  userID,
+		Message: "Here is a plan",
+		Role:    "assistant",
+		Actions: json.RawMessage(`[{"type":"delete_task","delete_task":{"task_id":"a"}},{"type":"delete_task","delete_task":{"task_id":"b"}}]`),
+	}
+	db.Create(&assistant)
+
+	if status, raw := send(t, app, "PUT", "/chat/"+assistant.ID.String()+"/feedback", map[string]any{"rating": -1, "comment": "too many tasks"}); status != fiber.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", status, raw)
+	}
+	if status, _ := send(t, app, "POST", "/chat/"+assistant.ID.String()+"/discard", map[string]any{"action_indexes": []int{1}}); status != fiber.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+
+	_, raw := send(t, app, "GET", "/chat/feedback/export", nil)
+	var records []struct {
+		Prompt           string `json:"prompt"`
+		Rating           *int   `json:"rating"`
+		Comment          string `json:"comment"`
+		DiscardedActions []int  `json:"discarded_actions"`
+	}
+	decodeData(t, raw, &records)
+
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 export record, got %d", len(records))
+	}
+	record := records[0]
+	if record.Prompt != "Plan my week" || record.Rating == nil || *record.Rating != -1 || record.Comment != "too many tasks" {
+		t.Fatalf("Unexpected export record: %+v", record)
+	}
+	if len(record.DiscardedActions) != 1 || record.DiscardedActions[0] != 1 {
+		t.Fatalf("Expected action 1 to be discarded, got %v", record.DiscardedActions)
+	}
+}
+
+func TestFeedbackInvalidRating(t *testing.T) {
+	app, db, userID := setupFeedbackApp(t, "rating@example.com")
+
+	assistant := models.ChatMessage{UserID: userID, Message: "Hi", Role: "assistant"}
+	db.Create(&assistant)
+
+	if status, _ := send(t, app, "PUT", "/chat/"+assistant.ID.String()+"/feedback", map[string]any{"rating": 5}); status != fiber.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d", status)
+	}
+}
+
+func TestActionOutcomesMatchByIndex(t *testing.T) {
+	app, db, userID := setupFeedbackApp(t, "outcomes@example.com")
+
+	assistant := models.ChatMessage{
+		UserID:  userID,
+		Message: "Two goals",
+		Role:    "assistant",
+		Actions: json.RawMessage(`[{"type":"create_goal","goal":{"title":"Read","description":"","goal_type":"deadline"}},{"type":"create_goal","goal":{"title":"Write","description":"","goal_type":"deadline"}}]`),
+	}
+	db.Create(&assistant)
+
+	post := func(path string, body map[string]any) int {
+		status, _ := send(t, app, "POST", path, body)
+		return status
+	}
+
+	// The user reworded the second goal before approving it, so it no longer matches the proposal word for word
+	edited := map[string]any{"type": "create_goal", "goal": map[string]any{"title": "Write daily", "goal_type": "deadline"}}
+	if status := post("/chat/execute", map[string]any{"message_id": assistant.ID, "actions": []any{edited}}); status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 without action indexes, got %d", status)
+	}
+	if status := post("/chat/execute", map[string]any{"message_id": assistant.ID, "action_indexes": []int{1}, "actions": []any{edited}}); status != fiber.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+	if status := post("/chat/"+assistant.ID.String()+"/discard", map[string]any{"action_indexes": []int{2}}); status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 for an index outside the proposal, got %d", status)
+	}
+	if status := post("/chat/"+assistant.ID.String()+"/discard", map[string]any{"action_indexes": []int{0}}); status != fiber.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+
+	var outcomes []models.ActionOutcome
+	db.Order("action_index").Find(&outcomes)
+	if len(outcomes) != 2 || outcomes[0].ActionIndex != 0 || outcomes[0].Accepted || outcomes[1].ActionIndex != 1 || !outcomes[1].Accepted {
+		t.Errorf("Expected action 0 discarded and action 1 accepted, got %+v", outcomes)
+	}
+}
@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestGoalForecast(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)
+	deadline := time.Date(2026, 3, 20, 23, 59, 59, 0, time.UTC)
+
+	user := newTestUser(t, db, "forecast@example.com")
+	goal := models.Goal{UserID: user.ID, Title: "Ship v2", GoalType: "deadline", Status: engine.GoalInProgress, Deadline: &deadline, CreatedAt: now.AddDate(0, 0, -28)}
+	habit := models.Goal{UserID: user.ID, Title: "Run", GoalType: "habit", Status: engine.GoalInProgress}
+	db.Create(&goal)
+	db.Create(&habit)
+
+	// One task a week for the last four weeks, and six still to go
+	for week := range 4 {
+		done := now.AddDate(0, 0, -7*week-1)
+		db.Create(&models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Done", UserPriority: 2, IsCompleted: true, CompletedAt: &done})
+	}
+	for range 6 {
+		db.Create(&models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Open", UserPriority: 2})
+	}
+	// Cancelled tasks are neither remaining work nor pace
+	db.Create(&models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Dropped", UserPriority: 2, Status: engine.TaskCancelled})
+
+	goalHandler := &handlers.GoalHandler{DB: db, Clock: engine.FixedClock{Time: now}}
+	app := newTestApp(user.ID)
+	app.Get("/goals/:id/forecast", goalHandler.GetForecast)
+
+	status, body := send(t, app, "GET", "/goals/"+goal.ID.String()+"/forecast", nil)
+	if status != fiber.StatusOK {
+		t.Fatalf("GET forecast = %d: %s", status, body)
+	}
+	var got struct {
+		RemainingTasks int     `json:"remaining_tasks"`
+		TasksPerWeek   float64 `json:"tasks_per_week"`
+		ProjectedLocal string  `json:"projected_local"`
+		AtRisk         bool    `json:"at_risk"`
+	}
+	decodeData(t, body, &got)
+	if got.RemainingTasks != 6 || got.TasksPerWeek != 1 || got.ProjectedLocal != "2026-04-22" || !got.AtRisk {
+		t.Errorf("forecast = %+v, want six weeks out at one task a week and at risk", got)
+	}
+
+	if status, _ := send(t, app, "GET", "/goals/"+habit.ID.String()+"/forecast", nil); status != fiber.StatusBadRequest {
+		t.Errorf("habit goal forecast = %d, want 400", status)
+	}
+}
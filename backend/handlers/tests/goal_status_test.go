@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestGoalStatusLifecycle(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+
+	user := newTestUser(t, db, "lifecycle@example.com")
+	deadline := now.AddDate(0, 0, 10)
+	goal := models.Goal{UserID: user.ID, Title: "Ship the app", GoalType: "deadline", Status: engine.GoalNotStarted, Deadline: &deadline}
+	db.Create(&goal)
+	first := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Build", UserPriority: 2}
+	second := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Release", UserPriority: 2}
+	db.Create(&first)
+	db.Create(&second)
+
+	clock := engine.FixedClock{Time: now}
+	goalHandler := &handlers.GoalHandler{DB: db, Clock: clock}
+	taskHandler := &handlers.TaskHandler{DB: db, Clock: clock}
+	app := newTestApp(user.ID)
+	app.Put("/goals/:id", goalHandler.UpdateGoal)
+	app.Get("/goals/:id/status-history", goalHandler.GetStatusHistory)
+	app.Patch("/tasks/:id/complete", taskHandler.CompleteTask)
+
+	reload := func() models.Goal {
+		var current models.Goal
+		db.First(&current, "id = ?", goal.ID)
+		return current
+	}
+
+	send(t, app, "PATCH", "/tasks/"+first.ID.String()+"/complete", map[string]any{"is_completed": true})
+	if current := reload(); current.Status != engine.GoalInProgress || current.CompletionProposedAt != nil {
+		t.Errorf("Expected the first completed task to start the goal, got %s", current.Status)
+	}
+
+	send(t, app, "PATCH", "/tasks/"+second.ID.String()+"/complete", map[string]any{"is_completed": true})
+	if current := reload(); current.Status != engine.GoalInProgress || current.CompletionProposedAt == nil {
+		t.Errorf("Expected completion to be proposed once every task is done, got %+v", current)
+	}
+
+	if status, _ := send(t, app, "PUT", "/goals/"+goal.ID.String(), map[string]any{"status": "overdue"}); status != fiber.StatusConflict {
+		t.Errorf("Expected 409 for setting overdue by hand, got %d", status)
+	}
+	if status, _ := send(t, app, "PUT", "/goals/"+goal.ID.String(), map[string]any{"status": "finished"}); status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown status, got %d", status)
+	}
+	if status, _ := send(t, app, "PUT", "/goals/"+goal.ID.String(), map[string]any{"status": "completed"}); status != fiber.StatusOK {
+		t.Fatalf("Expected 200 completing the goal, got %d", status)
+	}
+	if current := reload(); current.Status != engine.GoalCompleted || current.CompletionProposedAt != nil || current.StatusChangedAt == nil {
+		t.Errorf("Expected a completed goal with the proposal withdrawn, got %+v", current)
+	}
+	if status, _ := send(t, app, "PUT", "/goals/"+goal.ID.String(), map[string]any{"status": "abandoned"}); status != fiber.StatusConflict {
+		t.Errorf("Expected 409 abandoning a completed goal, got %d", status)
+	}
+
+	_, raw := send(t, app, "GET", "/goals/"+goal.ID.String()+"/status-history", nil)
+	var history struct {
+		Transitions []models.GoalStatusTransition `json:"transitions"`
+	}
+	decodeData(t, raw, &history)
+	transitions := history.Transitions
+	if len(transitions) != 2 ||
+		transitions[0].Trigger != engine.TriggerTaskActivity || transitions[0].ToStatus != engine.GoalInProgress ||
+		transitions[1].Trigger != engine.TriggerUser || transitions[1].ToStatus != engine.GoalCompleted {
+		t.Errorf("Expected a task activity and a user transition, got %+v", transitions)
+	}
+}
+
+func TestGoalBecomesOverdue(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+
+	user := newTestUser(t, db, "overdue@example.com")
+	deadline := now.AddDate(0, 0, -2)
+	goal := models.Goal{UserID: user.ID, Title: "File taxes", GoalType: "deadline", Status: engine.GoalInProgress, Deadline: &deadline}
+	db.Create(&goal)
+	db.Create(&models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Gather receipts", UserPriority: 3})
+
+	handler := &handlers.GoalHandler{DB: db, Clock: engine.FixedClock{Time: now}}
+	app := newTestApp(user.ID)
+	app.Put("/goals/:id", handler.UpdateGoal)
+
+	update := func(body map[string]any) map[string]any {
+		_, raw := send(t, app, "PUT", "/goals/"+goal.ID.String(), body)
+		var updated map[string]any
+		decodeData(t, raw, &updated)
+		return updated
+	}
+
+	if got := update(map[string]any{"description": "Before April"}); got["status"] != engine.GoalOverdue {
+		t.Errorf("Expected a passed deadline to flag the goal overdue, got %v", got["status"])
+	}
+	if got := update(map[string]any{"deadline": "2026-04-15"}); got["status"] != engine.GoalInProgress {
+		t.Errorf("Expected moving the deadline out to lift overdue, got %v", got["status"])
+	}
+}
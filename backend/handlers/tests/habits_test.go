@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestHabitCheckIns(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC) // Saturday
+
+	user := newTestUser(t, db, "habits@example.com")
+	frequency := 3
+	habit := models.Goal{UserID: user.ID, Title: "Run", GoalType: "habit", Status: "in_progress", Frequency: &frequency, CreatedAt: now.AddDate(0, 0, -30)}
+	db.Create(&habit)
+
+	handler := &handlers.GoalHandler{DB: db, Clock: engine.FixedClock{Time: now}}
+	app := newTestApp(user.ID)
+	app.Post("/goals/:id/checkins", handler.CheckInHabit)
+	app.Delete("/goals/:id/checkins/:date", handler.DeleteCheckIn)
+	app.Get("/habits/reminders", handler.GetHabitReminders)
+
+	checkIn := func(body map[string]any) int {
+		status, _ := send(t, app, "POST", "/goals/"+habit.ID.String()+"/checkins", body)
+		return status
+	}
+
+	reminders := func() []map[string]any {
+		_, raw := send(t, app, "GET", "/habits/reminders", nil)
+		var due []map[string]any
+		decodeData(t, raw, &due)
+		return due
+	}
+
+	if got := reminders(); len(got) != 1 {
+		t.Fatalf("Expected a reminder for a habit with no check-ins this week, got %v", got)
+	}
+
+	if status := checkIn(map[string]any{"date": "2026-03-05"}); status != fiber.StatusCreated {
+		t.Fatalf("Expected 201 for a back-dated check-in, got %d", status)
+	}
+	var updated models.Goal
+	db.First(&updated, "id = ?", habit.ID)
+	backdated := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	if updated.LastActiveAt == nil || !updated.LastActiveAt.Equal(backdated) {
+		t.Errorf("LastActiveAt = %v, want the back-dated day %v", updated.LastActiveAt, backdated)
+	}
+	if status := checkIn(map[string]any{"date": "2026-03-05"}); status != fiber.StatusConflict {
+		t.Errorf("Expected 409 for a second check-in on the same day, got %d", status)
+	}
+	if status := checkIn(map[string]any{"date": "2026-03-09"}); status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 for a future check-in, got %d", status)
+	}
+	if status := checkIn(map[string]any{}); status != fiber.StatusCreated {
+		t.Fatalf("Expected 201 for today's check-in, got %d", status)
+	}
+
+	db.First(&updated, "id = ?", habit.ID)
+	if updated.LastActiveAt == nil || !updated.LastActiveAt.Equal(now) {
+		t.Errorf("LastActiveAt = %v, want %v", updated.LastActiveAt, now)
+	}
+
+	if status := checkIn(map[string]any{"date": "2026-03-06"}); status != fiber.StatusCreated {
+		t.Fatalf("Expected 201, got %d", status)
+	}
+	if got := reminders(); len(got) != 0 {
+		t.Errorf("Expected no reminders once the weekly target is met, got %v", got)
+	}
+
+	if status, _ := send(t, app, "DELETE", "/goals/"+habit.ID.String()+"/checkins/2026-03-07", nil); status != fiber.StatusNoContent {
+		t.Fatalf("Expected 204 deleting a check-in, got %d", status)
+	}
+	db.First(&updated, "id = ?", habit.ID)
+	if latest := time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC); updated.LastActiveAt == nil || !updated.LastActiveAt.Equal(latest) {
+		t.Errorf("LastActiveAt should fall back to the latest remaining check-in, got %v", updated.LastActiveAt)
+	}
+}
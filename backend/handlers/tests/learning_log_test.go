@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestLearningLog(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC) // Thursday
+	earlier := now.AddDate(0, 0, -2)
+
+	user := newTestUser(t, db, "explore@example.com")
+	budget := 4.0
+	exploration := models.Goal{UserID: user.ID, Title: "Learn Rust", GoalType: "exploration", Status: "in_progress", WeeklyHoursBudget: &budget, CreatedAt: now.AddDate(0, 0, -20)}
+	db.Create(&exploration)
+	habit := models.Goal{UserID: user.ID, Title: "Run", GoalType: "habit", Status: "in_progress"}
+	db.Create(&habit)
+
+	handler := &handlers.GoalHandler{DB: db, Clock: engine.FixedClock{Time: now}}
+	app := newTestApp(user.ID)
+	app.Post("/goals/:id/log", handler.AddLearningLogEntry)
+	app.Get("/goals/:id/log", handler.GetLearningLog)
+	app.Delete("/goals/:id/log/:entryId", handler.DeleteLearningLogEntry)
+	app.Put("/goals/:id", handler.UpdateGoal)
+
+	addEntry := func(goalID string, body map[string]any) (int, map[string]any) {
+		status, raw := send(t, app, "POST", "/goals/"+goalID+"/log", body)
+		var entry map[string]any
+		if status == fiber.StatusCreated {
+			decodeData(t, raw, &entry)
+		}
+		return status, entry
+	}
+
+	if status, _ := addEntry(habit.ID.String(), map[string]any{"kind": "note", "content": "x"}); status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 for a log entry on a habit goal, got %d", status)
+	}
+	if status, _ := addEntry(exploration.ID.String(), map[string]any{"kind": "link", "url": "not a url"}); status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 for a link without a valid URL, got %d", status)
+	}
+	if status, _ := addEntry(exploration.ID.String(), map[string]any{"kind": "note", "content": "Read the book", "hours_spent": 1.5, "logged_at": earlier}); status != fiber.StatusCreated {
+		t.Fatalf("Expected 201 for a note, got %d", status)
+	}
+	status, link := addEntry(exploration.ID.String(), map[string]any{"kind": "link", "url": "https://doc.rust-lang.org/book/", "hours_spent": 0.5})
+	if status != fiber.StatusCreated {
+		t.Fatalf("Expected 201 for a link, got %d", status)
+	}
+
+	var goal models.Goal
+	db.First(&goal, "id = ?", exploration.ID)
+	if goal.LastActiveAt == nil || !goal.LastActiveAt.Equal(now) {
+		t.Errorf("Expected logging to mark the goal active, got %v", goal.LastActiveAt)
+	}
+
+	_, raw := send(t, app, "GET", "/goals/"+exploration.ID.String()+"/log", nil)
+	var log struct {
+		Entries []models.LearningLogEntry `json:"entries"`
+		Stats   engine.ExplorationStats   `json:"stats"`
+	}
+	decodeData(t, raw, &log)
+	if len(log.Entries) != 2 || log.Stats.HoursThisWeek != 2 || log.Stats.BudgetUsed == nil || *log.Stats.BudgetUsed != 0.5 {
+		t.Errorf("Expected 2 entries and half the budget used, got %+v", log)
+	}
+
+	entryURL := "/goals/" + exploration.ID.String() + "/log/" + link["id"].(string)
+	if status, _ := send(t, app, "DELETE", entryURL, nil); status != fiber.StatusNoContent {
+		t.Errorf("Expected 204 deleting an entry, got %d", status)
+	}
+	if status, _ := send(t, app, "DELETE", entryURL, nil); status != fiber.StatusNotFound {
+		t.Errorf("Expected 404 deleting it twice, got %d", status)
+	}
+
+	db.First(&goal, "id = ?", exploration.ID)
+	if goal.LastActiveAt == nil || !goal.LastActiveAt.Equal(earlier) {
+		t.Errorf("Expected LastActiveAt to fall back to the remaining entry at %v, got %v", earlier, goal.LastActiveAt)
+	}
+
+	updateBudget := func(goalID string, budget float64) (int, string) {
+		status, raw := send(t, app, "PUT", "/goals/"+goalID, map[string]any{"weekly_hours_budget": budget})
+		var result struct {
+			Error string `json:"error"`
+		}
+		json.Unmarshal(raw, &result)
+		return status, result.Error
+	}
+
+	if status, _ := updateBudget(habit.ID.String(), 3); status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 setting a weekly hours budget on a habit goal, got %d", status)
+	}
+	if status, message := updateBudget(exploration.ID.String(), 0); status != fiber.StatusBadRequest || message != "Weekly hours budget must be more than 0 and at most 80" {
+		t.Errorf("Expected 400 for a zero budget, got %d %q", status, message)
+	}
+	if status, _ := updateBudget(exploration.ID.String(), 6); status != fiber.StatusOK {
+		t.Errorf("Expected 200 updating the budget, got %d", status)
+	}
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// dailyPlan is the part of a plan response the tests look at
+type dailyPlan struct {
+	Status         string      `json:"status"`
+	AvailableHours float64     `json:"available_hours"`
+	Skipped        []uuid.UUID `json:"skipped"`
+	Items          []struct {
+		TaskID    uuid.UUID `json:"task_id"`
+		Completed bool      `json:"completed"`
+	} `json:"items"`
+	Completion struct {
+		Rate float64 `json:"rate"`
+	} `json:"completion"`
+}
+
+func TestDailyPlanPinSkipAndAccept(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) // Monday, start of the default working day
+
+	user := newTestUser(t, db, "plan@example.com")
+	goal := models.Goal{UserID: user.ID, Title: "Launch", GoalType: "deadline", Status: "in_progress"}
+	db.Create(&goal)
+
+	estimate := func(h float64) *float64 { return &h }
+	urgent := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Urgent", AIUrgency: 9, EstimatedHours: estimate(2)}
+	medium := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Medium", AIUrgency: 7, EstimatedHours: estimate(1)}
+	low := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Low", AIUrgency: 5, EstimatedHours: estimate(2)}
+	db.Create(&urgent)
+	db.Create(&medium)
+	db.Create(&low)
+
+	handler := &handlers.PlanHandler{DB: db, Clock: engine.FixedClock{Time: now}}
+	app := newTestApp(user.ID)
+	app.Get("/plan/today", handler.GetTodayPlan)
+	app.Put("/plan/today/tasks/:id", handler.UpdatePlanTask)
+	app.Post("/plan/today/accept", handler.AcceptTodayPlan)
+
+	request := func(method, path string, body any) (int, dailyPlan) {
+		status, raw := send(t, app, method, path, body)
+		var plan dailyPlan
+		if status == fiber.StatusOK {
+			decodeData(t, raw, &plan)
+		}
+		return status, plan
+	}
+
+	_, plan := request("GET", "/plan/today", nil)
+	if plan.Status != "draft" || plan.AvailableHours != 8 || len(plan.Items) != 3 {
+		t.Fatalf("Unexpected proposal: %+v", plan)
+	}
+
+	status, plan := request("PUT", "/plan/today/tasks/"+urgent.ID.String(), map[string]any{"skipped": true})
+	if status != fiber.StatusOK {
+		t.Fatalf("Expected 200 skipping a task, got %d", status)
+	}
+	if len(plan.Items) != 2 || len(plan.Skipped) != 1 || plan.Skipped[0] != urgent.ID {
+		t.Fatalf("Skipped task still planned: %+v", plan)
+	}
+
+	status, plan = request("POST", "/plan/today/accept?hours=1", nil)
+	if status != fiber.StatusOK {
+		t.Fatalf("Expected 200 accepting the plan, got %d", status)
+	}
+	if plan.Status != "accepted" || len(plan.Items) != 1 || plan.Items[0].TaskID != medium.ID {
+		t.Fatalf("Unexpected accepted plan: %+v", plan)
+	}
+
+	db.Model(&medium).UpdateColumns(map[string]any{"is_completed": true, "updated_at": now.Add(3 * time.Hour)})
+
+	_, plan = request("GET", "/plan/today", nil)
+	if plan.Status != "accepted" || !plan.Items[0].Completed || plan.Completion.Rate != 1 {
+		t.Fatalf("Expected the accepted plan to be fully completed: %+v", plan)
+	}
+
+	if status, _ := request("POST", "/plan/today/accept", nil); status != fiber.StatusConflict {
+		t.Errorf("Expected 409 accepting twice, got %d", status)
+	}
+}
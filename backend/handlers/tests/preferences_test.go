@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// recordingNotifier remembers which users' urgency was asked to be recomputed
+type recordingNotifier struct {
+	users []uuid.UUID
+}
+
+func (n *recordingNotifier) Notify(userID uuid.UUID) {
+	n.users = append(n.users, userID)
+}
+
+func setupPreferencesApp(t *testing.T) (*fiber.App, *recordingNotifier) {
+	db := newTestDB(t)
+
+	user := newTestUser(t, db, "prefs@example.com")
+
+	notifier := &recordingNotifier{}
+	handler := &handlers.PreferencesHandler{DB: db, Urgency: notifier}
+	app := newTestApp(user.ID)
+	app.Get("/preferences", handler.GetPreferences)
+	app.Put("/preferences", handler.UpdatePreferences)
+	return app, notifier
+}
+
+func TestPreferencesDefaultsAndUpdate(t *testing.T) {
+	app, notifier := setupPreferencesApp(t)
+
+	status, _ := send(t, app, "PUT", "/preferences", map[string]any{
+		"tasks_per_goal": 2,
+		"tone":           "direct",
+		"work_days":      "Mon, tue,mon",
+		"timezone":       "Europe/Berlin",
+		"board_columns":  "todo, Waiting,done",
+		"briefing":       true,
+		"briefing_hour":  7,
+	})
+	if status != fiber.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+	if len(notifier.users) != 1 {
+		t.Errorf("Expected saving preferences to recompute urgency once, got %d", len(notifier.users))
+	}
+
+	_, raw := send(t, app, "GET", "/preferences", nil)
+	var got struct {
+		TasksPerGoal  int       `json:"tasks_per_goal"`
+		Tone          string    `json:"tone"`
+		WorkDays      string    `json:"work_days"`
+		WorkStartHour int       `json:"work_start_hour"`
+		Timezone      string    `json:"timezone"`
+		BoardColumns  string    `json:"board_columns"`
+		Briefing      bool      `json:"briefing"`
+		BriefingHour  int       `json:"briefing_hour"`
+		UserID        uuid.UUID `json:"user_id"`
+	}
+	decodeData(t, raw, &got)
+
+	if got.TasksPerGoal != 2 || got.Tone != "direct" || got.WorkDays != "mon,tue" || got.WorkStartHour != 9 || got.Timezone != "Europe/Berlin" || got.BoardColumns != "todo,waiting,done" || !got.Briefing || got.BriefingHour != 7 {
+		t.Fatalf("Unexpected preferences: %+v", got)
+	}
+}
+
+func TestPreferencesValidation(t *testing.T) {
+	app, notifier := setupPreferencesApp(t)
+
+	invalid := []map[string]any{
+		{"work_start_hour": 18, "work_end_hour": 9},
+		{"tasks_per_goal": 0},
+		{"tone": "sarcastic"},
+		{"work_days": "monday"},
+		{"timezone": "Mars/Olympus"},
+		{"board_columns": "todo,archived"},
+		{"briefing_hour": 24},
+	}
+
+	for _, body := range invalid {
+		if status, _ := send(t, app, "PUT", "/preferences", body); status != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 for %v, got %d", body, status)
+		}
+	}
+	if len(notifier.users) != 0 {
+		t.Errorf("Expected rejected preferences not to recompute urgency, got %d", len(notifier.users))
+	}
+}
@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+func TestRecurringTasks(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC) // Wednesday
+
+	user := newTestUser(t, db, "recurring@example.com")
+	goal := models.Goal{UserID: user.ID, Title: "Stay on top of chores", GoalType: "deadline", Status: engine.GoalInProgress}
+	db.Create(&goal)
+
+	taskHandler := &handlers.TaskHandler{DB: db, Clock: engine.FixedClock{Time: now}}
+	app := newTestApp(user.ID)
+	app.Post("/tasks", taskHandler.CreateTask)
+	app.Put("/tasks/:id", taskHandler.UpdateTask)
+	app.Patch("/tasks/:id/complete", taskHandler.CompleteTask)
+	app.Delete("/tasks/:id", taskHandler.DeleteTask)
+
+	occurrences := func(seriesID uuid.UUID) []models.Task {
+		var tasks []models.Task
+		db.Where("series_id = ?", seriesID).Order("deadline").Find(&tasks)
+		return tasks
+	}
+
+	status, _ := send(t, app, "POST", "/tasks", map[string]any{
+		"title": "Take out the bins", "goal_id": goal.ID, "user_priority": 2, "recurrence": "FREQ=WEEKLY;BYDAY=FR",
+	})
+	if status != fiber.StatusCreated {
+		t.Fatalf("Expected 201 creating a recurring task, got %d", status)
+	}
+	status, _ = send(t, app, "POST", "/tasks", map[string]any{
+		"title": "Water plants", "goal_id": goal.ID, "user_priority": 2, "recurrence": "FREQ=YEARLY",
+	})
+	if status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 for an unsupported rule, got %d", status)
+	}
+
+	var first models.Task
+	db.Where("title = ?", "Take out the bins").First(&first)
+	if first.SeriesID == nil || first.Deadline.Weekday() != time.Friday || first.Deadline.Day() != 6 {
+		t.Fatalf("Expected the first occurrence on Friday the 6th, got %s", first.Deadline)
+	}
+
+	send(t, app, "PATCH", "/tasks/"+first.ID.String()+"/complete", map[string]any{"is_completed": true})
+	tasks := occurrences(*first.SeriesID)
+	if len(tasks) != 2 || tasks[1].Deadline.Day() != 13 || tasks[1].Occurrence != 2 || tasks[1].IsCompleted {
+		t.Fatalf("Expected completing an occurrence to create the next one on the 13th, got %+v", tasks)
+	}
+	second := tasks[1]
+
+	// Reopening and completing again must not create a second next occurrence
+	send(t, app, "PATCH", "/tasks/"+first.ID.String()+"/complete", map[string]any{"is_completed": false})
+	send(t, app, "PATCH", "/tasks/"+first.ID.String()+"/complete", map[string]any{"is_completed": true})
+	if tasks := occurrences(*first.SeriesID); len(tasks) != 2 {
+		t.Errorf("Expected 2 occurrences after completing twice, got %d", len(tasks))
+	}
+
+	status, _ = send(t, app, "PUT", "/tasks/"+second.ID.String(), map[string]any{"recurrence": "FREQ=WEEKLY;BYDAY=MO"})
+	if status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 changing the rule of a single occurrence, got %d", status)
+	}
+	status, _ = send(t, app, "PUT", "/tasks/"+second.ID.String(), map[string]any{"title": "Bins", "scope": "all"})
+	if status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown scope, got %d", status)
+	}
+
+	// Editing this occurrence only leaves the series alone
+	send(t, app, "PUT", "/tasks/"+second.ID.String(), map[string]any{"title": "Bins and recycling"})
+	var series models.TaskSeries
+	db.First(&series, "id = ?", first.SeriesID)
+	if series.Title != "Take out the bins" {
+		t.Errorf("Expected a single-occurrence edit to keep the series title, got %q", series.Title)
+	}
+
+	// Editing all future occurrences moves the series to Mondays from this occurrence on
+	status, body := send(t, app, "PUT", "/tasks/"+second.ID.String(), map[string]any{
+		"title": "Bins", "recurrence": "FREQ=WEEKLY;BYDAY=MO", "scope": "future",
+	})
+	if status != fiber.StatusOK {
+		t.Fatalf("Expected 200 editing future occurrences, got %d: %s", status, body)
+	}
+	var updated struct {
+		Title      string `json:"title"`
+		Recurrence string `json:"recurrence"`
+	}
+	decodeData(t, body, &updated)
+	if updated.Title != "Bins" || updated.Recurrence != "FREQ=WEEKLY;BYDAY=MO" {
+		t.Errorf("Expected the response to carry the new title and rule, got %+v", updated)
+	}
+	db.First(&series, "id = ?", first.SeriesID)
+	db.First(&second, "id = ?", second.ID)
+	if series.Title != "Bins" || series.Rule != "FREQ=WEEKLY;BYDAY=MO" || second.Deadline.Day() != 16 {
+		t.Errorf("Expected the series to restart on Monday the 16th, got %q %q %s", series.Title, series.Rule, second.Deadline)
+	}
+
+	send(t, app, "PATCH", "/tasks/"+second.ID.String()+"/complete", map[string]any{"is_completed": true})
+	tasks = occurrences(*first.SeriesID)
+	if len(tasks) != 3 || tasks[2].Deadline.Day() != 23 || tasks[2].Title != "Bins" {
+		t.Fatalf("Expected the next occurrence on Monday the 23rd, got %+v", tasks)
+	}
+
+	// Stopping the series with a new deadline moves this occurrence without restarting the series
+	status, body = send(t, app, "PUT", "/tasks/"+tasks[2].ID.String(), map[string]any{"recurrence": "", "deadline": "2026-03-25", "scope": "future"})
+	if status != fiber.StatusOK {
+		t.Fatalf("Expected 200 stopping the series, got %d: %s", status, body)
+	}
+	db.First(&series, "id = ?", first.SeriesID)
+	var third models.Task
+	db.First(&third, "id = ?", tasks[2].ID)
+	if series.EndedAt == nil || third.Deadline.Day() != 25 || third.Occurrence != 3 {
+		t.Errorf("Expected the series to stay stopped with occurrence 3 moved to the 25th, got ended %v, %s, occurrence %d", series.EndedAt, third.Deadline, third.Occurrence)
+	}
+
+	if status, _ := send(t, app, "DELETE", "/tasks/"+tasks[2].ID.String()+"?scope=future", nil); status != fiber.StatusNoContent {
+		t.Fatalf("Expected 204 deleting future occurrences, got %d", status)
+	}
+	db.First(&series, "id = ?", first.SeriesID)
+	if series.EndedAt == nil {
+		t.Error("Expected deleting future occurrences to end the series")
+	}
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestWeeklyReview(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)
+	day := func(d, h int) time.Time { return time.Date(2026, 3, d, h, 0, 0, 0, time.UTC) }
+	at := func(t time.Time) *time.Time { return &t }
+	three := 3
+
+	user := newTestUser(t, db, "review@example.com")
+	goal := models.Goal{UserID: user.ID, Title: "Ship", GoalType: "deadline", Status: engine.GoalInProgress, CreatedAt: day(1, 9)}
+	habit := models.Goal{UserID: user.ID, Title: "Run", GoalType: "habit", Status: engine.GoalInProgress, Frequency: &three, CreatedAt: day(1, 9)}
+	db.Create(&goal)
+	db.Create(&habit)
+	db.Create(&models.HabitCheckIn{GoalID: habit.ID, UserID: user.ID, Date: "2026-03-06", CheckedAt: day(6, 8)})
+
+	db.Create(&models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Done", UserPriority: 2, IsCompleted: true, Status: engine.TaskDone, Deadline: day(10, 23), CompletedAt: at(day(9, 10))})
+	db.Create(&models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Missed", UserPriority: 2, Deadline: day(7, 23)})
+	db.Create(&models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Last month", UserPriority: 2, IsCompleted: true, Status: engine.TaskDone, CompletedAt: at(day(1, 10).AddDate(0, -1, 0))})
+
+	chatHandler := &handlers.ChatHandler{DB: db, Clock: engine.FixedClock{Time: now}}
+	app := newTestApp(user.ID)
+	app.Get("/reviews/weekly", chatHandler.GetWeeklyReview)
+	app.Post("/reviews/weekly/narrative", chatHandler.WriteWeeklyReview)
+
+	type review struct {
+		Review    engine.Review   `json:"review"`
+		Narrative json.RawMessage `json:"narrative"`
+	}
+
+	status, body := send(t, app, "GET", "/reviews/weekly", nil)
+	if status != fiber.StatusOK {
+		t.Fatalf("GET review = %d: %s", status, body)
+	}
+	var resp review
+	decodeData(t, body, &resp)
+	got := resp.Review
+	if got.From != "2026-03-05" || got.To != "2026-03-11" {
+		t.Errorf("default range = %s to %s, want the last seven days", got.From, got.To)
+	}
+	if len(got.Completed) != 1 || got.Completed[0].Title != "Done" {
+		t.Errorf("completed = %+v, want Done", got.Completed)
+	}
+	if len(got.Slipped) != 1 || got.Slipped[0].Title != "Missed" {
+		t.Errorf("slipped = %+v, want Missed", got.Slipped)
+	}
+	if len(got.NeglectedHabits) != 1 || got.NeglectedHabits[0].CheckIns != 1 || got.NeglectedHabits[0].Expected != 3 {
+		t.Errorf("neglected habits = %+v, want Run at 1 of 3", got.NeglectedHabits)
+	}
+	if resp.Narrative != nil {
+		t.Errorf("narrative = %s, want none unless asked for", resp.Narrative)
+	}
+
+	status, body = send(t, app, "GET", "/reviews/weekly?from=2026-02-01&to=2026-02-28", nil)
+	if status != fiber.StatusOK {
+		t.Fatalf("GET February review = %d: %s", status, body)
+	}
+	resp = review{}
+	decodeData(t, body, &resp)
+	if got := resp.Review.Completed; len(got) != 1 || got[0].Title != "Last month" {
+		t.Errorf("February completed = %+v, want Last month", got)
+	}
+
+	for _, query := range []string{"from=2026-03-10&to=2026-03-01", "from=2026-01-01&to=2026-03-01", "from=yesterday"} {
+		if status, body := send(t, app, "GET", "/reviews/weekly?"+query, nil); status != fiber.StatusBadRequest {
+			t.Errorf("GET review?%s = %d, want 400: %s", query, status, body)
+		}
+	}
+
+	// Reading the review never writes to the chat
+	send(t, app, "GET", "/reviews/weekly?narrative=true", nil)
+	var messages int64
+	db.Model(&models.ChatMessage{}).Count(&messages)
+	if messages != 0 {
+		t.Errorf("GET review saved %d chat message(s), want none", messages)
+	}
+
+	// Without an LLM configured there is no one to write the narrative
+	if status, _ := send(t, app, "POST", "/reviews/weekly/narrative", nil); status != fiber.StatusServiceUnavailable {
+		t.Errorf("POST narrative = %d, want 503", status)
+	}
+}
@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+func TestBusyBlocksShapeSchedule(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) // Monday, start of the default working day
+
+	user := newTestUser(t, db, "schedule@example.com")
+	goal := models.Goal{UserID: user.ID, Title: "Launch", GoalType: "deadline", Status: "in_progress"}
+	db.Create(&goal)
+	hours := 2.0
+	task := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Write the spec", UserPriority: 1, EstimatedHours: &hours, Deadline: now.AddDate(0, 0, 1)}
+	db.Create(&task)
+
+	handler := &handlers.PlanHandler{DB: db, Clock: engine.FixedClock{Time: now}}
+	app := newTestApp(user.ID)
+	app.Get("/schedule", handler.GetSchedule)
+	app.Get("/busy-blocks", handler.GetBusyBlocks)
+	app.Post("/busy-blocks", handler.CreateBusyBlock)
+	app.Put("/busy-blocks/:id", handler.UpdateBusyBlock)
+	app.Delete("/busy-blocks/:id", handler.DeleteBusyBlock)
+
+	// firstBlockStart is when the task is first scheduled today
+	firstBlockStart := func() time.Time {
+		status, raw := send(t, app, "GET", "/schedule?days=1", nil)
+		if status != fiber.StatusOK {
+			t.Fatalf("Expected 200 for the schedule, got %d", status)
+		}
+		var schedule struct {
+			Blocks []struct {
+				TaskID uuid.UUID `json:"task_id"`
+				Start  time.Time `json:"start"`
+			} `json:"blocks"`
+		}
+		decodeData(t, raw, &schedule)
+		if len(schedule.Blocks) == 0 || schedule.Blocks[0].TaskID != task.ID {
+			t.Fatalf("Expected the task to be scheduled, got %+v", schedule.Blocks)
+		}
+		return schedule.Blocks[0].Start
+	}
+
+	if status, _ := send(t, app, "GET", "/schedule?days=15", nil); status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 for a schedule over 14 days, got %d", status)
+	}
+	if start := firstBlockStart(); !start.Equal(now) {
+		t.Errorf("Expected the task at the start of the day, got %s", start)
+	}
+
+	invalid := map[string]any{"title": "Meeting", "starts_at": now.Add(time.Hour), "ends_at": now}
+	if status, _ := send(t, app, "POST", "/busy-blocks", invalid); status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 for a block that ends before it starts, got %d", status)
+	}
+
+	meeting := map[string]any{"title": "Meeting", "starts_at": now, "ends_at": now.Add(3 * time.Hour)}
+	status, raw := send(t, app, "POST", "/busy-blocks", meeting)
+	if status != fiber.StatusCreated {
+		t.Fatalf("Expected 201 creating a busy block, got %d", status)
+	}
+	var created models.BusyBlock
+	decodeData(t, raw, &created)
+	if start := firstBlockStart(); !start.Equal(now.Add(3 * time.Hour)) {
+		t.Errorf("Expected the task after the meeting, got %s", start)
+	}
+
+	status, raw = send(t, app, "GET", "/busy-blocks", nil)
+	var listed []models.BusyBlock
+	decodeData(t, raw, &listed)
+	if status != fiber.StatusOK || len(listed) != 1 {
+		t.Errorf("Expected the one busy block listed, got %d %+v", status, listed)
+	}
+
+	blockURL := "/busy-blocks/" + created.ID.String()
+	if status, _ := send(t, app, "PUT", blockURL, map[string]any{"ends_at": now.Add(time.Hour)}); status != fiber.StatusOK {
+		t.Fatalf("Expected 200 shortening the busy block, got %d", status)
+	}
+	if start := firstBlockStart(); !start.Equal(now.Add(time.Hour)) {
+		t.Errorf("Expected the task after the shortened meeting, got %s", start)
+	}
+
+	if status, _ := send(t, app, "DELETE", blockURL, nil); status != fiber.StatusNoContent {
+		t.Fatalf("Expected 204 deleting the busy block, got %d", status)
+	}
+	if status, _ := send(t, app, "DELETE", blockURL, nil); status != fiber.StatusNotFound {
+		t.Errorf("Expected 404 deleting it twice, got %d", status)
+	}
+	if status, _ := send(t, app, "PUT", blockURL, map[string]any{"title": "Gone"}); status != fiber.StatusNotFound {
+		t.Errorf("Expected 404 updating a deleted block, got %d", status)
+	}
+	if start := firstBlockStart(); !start.Equal(now) {
+		t.Errorf("Expected the task back at the start of the day, got %s", start)
+	}
+}
+
+func TestScheduleWaitsForBlockers(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) // Monday, start of the default working day
+
+	user := newTestUser(t, db, "schedule-blockers@example.com")
+	goal := models.Goal{UserID: user.ID, Title: "Launch", GoalType: "deadline", Status: "in_progress"}
+	db.Create(&goal)
+	hours := 2.0
+	design := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Design", UserPriority: 1, EstimatedHours: &hours, Deadline: now.AddDate(0, 0, 3)}
+	launch := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Launch", UserPriority: 3, EstimatedHours: &hours, Deadline: now.AddDate(0, 0, 1)}
+	db.Create(&design)
+	db.Create(&launch)
+	db.Create(&models.TaskDependency{UserID: user.ID, TaskID: launch.ID, DependsOnID: design.ID})
+
+	handler := &handlers.PlanHandler{DB: db, Clock: engine.FixedClock{Time: now}}
+	app := newTestApp(user.ID)
+	app.Get("/schedule", handler.GetSchedule)
+
+	status, raw := send(t, app, "GET", "/schedule?days=1", nil)
+	if status != fiber.StatusOK {
+		t.Fatalf("Expected 200 for the schedule, got %d", status)
+	}
+	var schedule struct {
+		Blocks []struct {
+			TaskID uuid.UUID `json:"task_id"`
+			Start  time.Time `json:"start"`
+		} `json:"blocks"`
+	}
+	decodeData(t, raw, &schedule)
+	if len(schedule.Blocks) != 2 || schedule.Blocks[0].TaskID != design.ID || schedule.Blocks[1].TaskID != launch.ID {
+		t.Fatalf("Expected design before the launch it blocks, got %+v", schedule.Blocks)
+	}
+	if start := schedule.Blocks[1].Start; !start.Equal(now.Add(2 * time.Hour)) {
+		t.Errorf("Expected the launch once design is done, got %s", start)
+	}
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/Pranay0205/velo/backend/database"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// newTestDB opens an in-memory database with the full schema migrated
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal("Failed to connect test DB:", err)
+	}
+	if err := database.Migrate(db); err != nil {
+		t.Fatal("Failed to migrate test DB:", err)
+	}
+	return db
+}
+
+// newTestUser creates the user the test's requests run as
+func newTestUser(t *testing.T, db *gorm.DB, email string) models.User {
+	user := models.User{Name: "Test", Email: email}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatal("Failed to create test user:", err)
+	}
+	return user
+}
+
+// newTestApp is an app whose requests all run as userID, as if the auth middleware had let them in
+func newTestApp(userID uuid.UUID) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error {
+		c.Locals("userID", userID)
+		return c.Next()
+	})
+	return app
+}
+
+// send makes a request with body encoded as JSON, or without a body when it is nil,
+// and returns the status code and the raw response body
+func send(t *testing.T, app *fiber.App, method, path string, body any) (int, []byte) {
+	t.Helper()
+	var payload io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal("Failed to encode request body:", err)
+		}
+		payload = bytes.NewReader(encoded)
+	}
+	req, _ := http.NewRequest(method, path, payload)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal("Request failed:", err)
+	}
+	raw, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, raw
+}
+
+// decodeData decodes the data field of a response body into out
+func decodeData(t *testing.T, raw []byte, out any) {
+	t.Helper()
+	envelope := struct {
+		Data any `json:"data"`
+	}{Data: out}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		t.Fatalf("Failed to decode response %s: %v", raw, err)
+	}
+}
@@ -8,18 +8,11 @@ import (
 	"testing"
 
 	"github.com/Pranay0205/velo/backend/handlers"
-	"github.com/Pranay0205/velo/backend/models"
 	"github.com/gofiber/fiber/v3"
-	"gorm.io/driver/sqlite"
-	"gorm.io/gorm"
 )
 
 func setupTestApp(t *testing.T) *fiber.App {
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	if err != nil {
-		t.Fatal("Failed to connect test DB:", err)
-	}
-	db.AutoMigrate(&models.User{})
+	db := newTestDB(t)
 
 	handler := &handlers.AuthHandler{DB: db}
 	app := fiber.New()
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestTrashAndRestore(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+
+	user := newTestUser(t, db, "trash@example.com")
+	goal := models.Goal{UserID: user.ID, Title: "Move house", GoalType: "deadline", Status: engine.GoalInProgress}
+	other := models.Goal{UserID: user.ID, Title: "Read more", GoalType: "deadline", Status: engine.GoalInProgress}
+	db.Create(&goal)
+	db.Create(&other)
+	packing := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Pack boxes", UserPriority: 2}
+	library := models.Task{UserID: user.ID, GoalID: other.ID, Title: "Renew library card", UserPriority: 1}
+	db.Create(&packing)
+	db.Create(&library)
+
+	clock := engine.FixedClock{Time: now}
+	goalHandler := &handlers.GoalHandler{DB: db, Clock: clock}
+	taskHandler := &handlers.TaskHandler{DB: db, Clock: clock}
+	app := newTestApp(user.ID)
+	app.Delete("/goals/:id", goalHandler.DeleteGoal)
+	app.Post("/goals/:id/restore", goalHandler.RestoreGoal)
+	app.Delete("/tasks/:id", taskHandler.DeleteTask)
+	app.Post("/tasks/:id/restore", taskHandler.RestoreTask)
+	app.Get("/trash", goalHandler.GetTrash)
+
+	if status, _ := send(t, app, "DELETE", "/goals/"+goal.ID.String(), nil); status != fiber.StatusNoContent {
+		t.Fatalf("Expected 204 deleting a goal, got %d", status)
+	}
+	if status, _ := send(t, app, "DELETE", "/goals/"+goal.ID.String(), nil); status != fiber.StatusNotFound {
+		t.Errorf("Expected 404 deleting a goal that is already in the trash, got %d", status)
+	}
+	if status, _ := send(t, app, "DELETE", "/tasks/"+library.ID.String(), nil); status != fiber.StatusNoContent {
+		t.Fatalf("Expected 204 deleting a task, got %d", status)
+	}
+	if status, _ := send(t, app, "POST", "/tasks/"+packing.ID.String()+"/restore", nil); status != fiber.StatusConflict {
+		t.Errorf("Expected 409 restoring a task whose goal is in the trash, got %d", status)
+	}
+
+	_, raw := send(t, app, "GET", "/trash", nil)
+	var trash struct {
+		Goals []struct {
+			ID        string `json:"id"`
+			TaskCount int    `json:"task_count"`
+		} `json:"goals"`
+		Tasks []models.Task `json:"tasks"`
+	}
+	decodeData(t, raw, &trash)
+	if len(trash.Goals) != 1 || trash.Goals[0].TaskCount != 1 || len(trash.Tasks) != 1 || trash.Tasks[0].ID != library.ID {
+		t.Errorf("Expected the goal with its task and the loose task in the trash, got %+v", trash)
+	}
+
+	if status, _ := send(t, app, "POST", "/goals/"+goal.ID.String()+"/restore", nil); status != fiber.StatusOK {
+		t.Fatalf("Expected 200 restoring the goal, got %d", status)
+	}
+	if status, _ := send(t, app, "POST", "/tasks/"+library.ID.String()+"/restore", nil); status != fiber.StatusOK {
+		t.Fatalf("Expected 200 restoring the task, got %d", status)
+	}
+
+	var tasks int64
+	db.Model(&models.Task{}).Count(&tasks)
+	if tasks != 2 {
+		t.Errorf("Expected both tasks back, got %d", tasks)
+	}
+}
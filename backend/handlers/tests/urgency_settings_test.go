@@ -0,0 +1,33 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestUpdateUrgencySettingsRecomputes(t *testing.T) {
+	db := newTestDB(t)
+
+	user := newTestUser(t, db, "settings@example.com")
+
+	notifier := &recordingNotifier{}
+	taskHandler := &handlers.TaskHandler{DB: db, Urgency: notifier}
+	app := newTestApp(user.ID)
+	app.Put("/urgency/settings", taskHandler.UpdateUrgencySettings)
+
+	if status, _ := send(t, app, "PUT", "/urgency/settings", map[string]any{"priority_weight": 9}); status != fiber.StatusBadRequest {
+		t.Errorf("Expected 400 for an out of range weight, got %d", status)
+	}
+	if len(notifier.users) != 0 {
+		t.Errorf("Expected rejected settings not to recompute urgency")
+	}
+
+	if status, _ := send(t, app, "PUT", "/urgency/settings", map[string]any{"strategy": "wsjf", "priority_weight": 2}); status != fiber.StatusOK {
+		t.Fatalf("Expected 200 saving settings, got %d", status)
+	}
+	if len(notifier.users) != 1 || notifier.users[0] != user.ID {
+		t.Errorf("Expected saved settings to recompute the user's urgency, got %v", notifier.users)
+	}
+}
@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/handlers"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+func TestUrgencyTrendStuckOrder(t *testing.T) {
+	db := newTestDB(t)
+
+	now := time.Date(2026, 3, 30, 12, 0, 0, 0, time.UTC)
+	longAgo := now.AddDate(0, 0, -40)
+
+	user := newTestUser(t, db, "trend@example.com")
+	goal := models.Goal{UserID: user.ID, Title: "Launch", GoalType: "deadline", Status: "in_progress"}
+	db.Create(&goal)
+
+	// Two tasks went critical on the same day, one a week before them
+	criticalFrom := map[string]time.Time{
+		"a": now.AddDate(0, 0, -20),
+		"b": now.AddDate(0, 0, -20),
+		"c": now.AddDate(0, 0, -27),
+	}
+	ids := map[string]uuid.UUID{}
+	for _, title := range []string{"a", "b", "c"} {
+		task := models.Task{UserID: user.ID, GoalID: goal.ID, Title: title, UserPriority: 1, AIUrgency: engine.CriticalUrgency, CreatedAt: longAgo, UpdatedAt: longAgo}
+		db.Create(&task)
+		ids[title] = task.ID
+		db.Create(&models.UrgencyHistory{TaskID: task.ID, GoalID: goal.ID, UserID: user.ID, Urgency: engine.CriticalUrgency, RecordedAt: criticalFrom[title]})
+	}
+
+	handler := &handlers.GoalHandler{DB: db, Clock: engine.FixedClock{Time: now}}
+	app := newTestApp(user.ID)
+	app.Get("/goals/:id/urgency-trend", handler.GetUrgencyTrend)
+
+	same := []uuid.UUID{ids["a"], ids["b"]}
+	sort.Slice(same, func(i, j int) bool { return same[i].String() < same[j].String() })
+	want := []uuid.UUID{ids["c"], same[0], same[1]}
+
+	// Map iteration order varies, so ask a few times
+	for range 5 {
+		_, raw := send(t, app, "GET", "/goals/"+goal.ID.String()+"/urgency-trend", nil)
+		var trend struct {
+			Stuck []struct {
+				TaskID uuid.UUID `json:"task_id"`
+			} `json:"stuck_critical"`
+		}
+		decodeData(t, raw, &trend)
+
+		if len(trend.Stuck) != len(want) {
+			t.Fatalf("Expected %d stuck tasks, got %+v", len(want), trend.Stuck)
+		}
+		for i, stuck := range trend.Stuck {
+			if stuck.TaskID != want[i] {
+				t.Fatalf("Expected stuck tasks longest first, then by ID: want %v, got %+v", want, trend.Stuck)
+			}
+		}
+	}
+}
@@ -13,6 +13,11 @@ import (
 	"google.golang.org/genai"
 )
 
+// ChatClient replies to a conversation; GeminiClient is the one the app runs with
+type ChatClient interface {
+	Chat(ctx context.Context, systemPrompt string, chatHistory []models.ChatMessage) (*LLMResponse, error)
+}
+
 type GeminiClient struct {
 	client *genai.Client
 }
@@ -137,6 +142,10 @@ func ValidateResponse(llmResponse string) (*LLMResponse, error) {
 			if action.DeleteTaskAction == nil {
 				return &LLMResponse{}, fmt.Errorf("action %d: delete_task missing data", i)
 			}
+		case "add_checklist_item", "update_checklist_item":
+			if action.ChecklistItem == nil {
+				return &LLMResponse{}, fmt.Errorf("action %d: %s missing data", i, action.Type)
+			}
 		default:
 			return &LLMResponse{}, fmt.Errorf("action %d: unknown type %s", i, action.Type)
 		}
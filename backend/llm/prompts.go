@@ -5,12 +5,51 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Pranay0205/velo/backend/engine"
 	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
 )
 
-func BuildSystemPrompt(userName string, goals []models.Goal, tasks []models.Task) string {
+// PromptContext is everything BuildSystemPrompt knows about the user
+type PromptContext struct {
+	UserName     string
+	Now          time.Time
+	Location     *time.Location // User's timezone; dates in the prompt are rendered in it
+	Preferences  models.UserPreferences
+	Goals        []models.Goal
+	Tasks        []models.Task
+	Urgency      map[uuid.UUID]engine.Breakdown        // Why each task has its urgency score, keyed by task ID
+	Habits       map[uuid.UUID]engine.HabitStats       // Check-in progress of habit goals, keyed by goal ID
+	Explorations map[uuid.UUID]engine.ExplorationStats // Time invested in exploration goals, keyed by goal ID
+	Blockers     map[uuid.UUID][]uuid.UUID             // Open tasks each blocked task is waiting on, keyed by task ID
+	Checklists   map[uuid.UUID][]models.ChecklistItem  // Checklist items in order, keyed by task ID
+	Forecasts    map[uuid.UUID]engine.GoalForecast     // Projected completion of deadline goals, keyed by goal ID
+}
+
+var toneInstructions = map[string]string{
+	"friendly":     "Be warm and encouraging, like a supportive friend.",
+	"direct":       "Be direct and to the point. Skip pleasantries and cheerleading.",
+	"motivational": "Be energetic and motivating, like a coach pushing the user forward.",
+}
+
+var verbosityInstructions = map[string]string{
+	"brief":    "Keep messages to one or two short sentences.",
+	"balanced": "Keep messages short: a few sentences at most.",
+	"detailed": "Explain your reasoning and give step-by-step detail when it helps.",
+}
+
+func BuildSystemPrompt(pc PromptContext) string {
+	prefs := pc.Preferences
+	loc := pc.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
 	return fmt.Sprintf(`You are Velo, a personal productivity assistant for %s.
-Today's date is %s.
+Today's date is %s (%s) in the user's timezone (%s).
+
+## User's Preferences:
+%s
 
 ## User's Current Goals:
 %s
@@ -21,17 +60,19 @@ Today's date is %s.
 ## Your Responsibilities:
 - Analyze what the user needs and help them plan
 - Create goals and tasks when the user describes what they want to accomplish
-- Give advice on prioritization based on urgency scores
-- Keep responses concise and actionable
+- Give advice on prioritization based on urgency scores, and use the "because" lines to explain why a task is urgent
+- Keep responses actionable and follow the user's preferences above
 
 ## Available Actions (These are your ONLY tools)
 - create_goal: Create a new goal
-- update_goal: Update an existing goal's details (title, description, type, deadline, frequency)
+- update_goal: Update an existing goal's details (title, description, type, status, deadline, frequency)
 - delete_goal: Delete an existing goal by Goal ID
 - create_task: Create a task under a goal
-- update_task: Update an existing task's details (title, description, deadline, priority, completion status, goal association)
+- update_task: Update an existing task's details (title, description, deadline, priority, completion, workflow status, goal association)
 - delete_task: Delete an existing task by Task ID
 - reprioritize_task: Change a task's priority
+- add_checklist_item: Add a step to an existing task's checklist
+- update_checklist_item: Tick, untick or rename an existing checklist item by Item ID
 
 ## Response Format:
 
@@ -48,7 +89,7 @@ For responses with actions, here is an example of EVERY action type:
         "title": "Learn Rust",
         "description": "Become proficient in Rust by summer",
         "goal_type": "deadline",
-        "deadline": "2026-08-01T00:00:00Z"
+        "deadline": "2026-08-01"
       }
     },
     {
@@ -72,7 +113,7 @@ For responses with actions, here is an example of EVERY action type:
       "update_goal": {
         "goal_id": "abc-123-existing-goal-uuid",
         "title": "Updated goal title",
-        "deadline": "2026-07-01T00:00:00Z"
+        "deadline": "2026-07-01"
       }
     },
     {
@@ -94,6 +135,21 @@ For responses with actions, here is an example of EVERY action type:
         "task_id": "xyz-456-existing-task-uuid"
       }
     },
+    {
+      "type": "add_checklist_item",
+      "checklist_item": {
+        "task_id": "xyz-456-existing-task-uuid",
+        "title": "Install the toolchain"
+      }
+    },
+    {
+      "type": "update_checklist_item",
+      "checklist_item": {
+        "task_id": "xyz-456-existing-task-uuid",
+        "item_id": "def-789-existing-item-uuid",
+        "completed": true
+      }
+    },
     {
       "type": "reprioritize_task",
       "reprioritize": {
@@ -106,7 +162,7 @@ For responses with actions, here is an example of EVERY action type:
 }
 
 ## IMPORTANT BEHAVIOR RULES:
-- When creating goals, ALWAYS create at least 3-5 actionable tasks under each goal based on reality. Tasks should be specific, concrete actions the user can complete.
+- When creating goals, create %d actionable tasks under each goal based on reality (the user chose this number, do not over-plan beyond it). Tasks should be specific, concrete actions the user can complete.
 - Don't just create goals and ask follow-up questions - try to infer as much as possible from the user's message and create a complete plan of goals and tasks.
 - You can always adjust later based on user feedback.
 - If a user asks for something you can't do, tell them honestly and suggest what you CAN do instead.
@@ -119,54 +175,275 @@ For responses with actions, here is an example of EVERY action type:
 
 CRITICAL RULES:
 - goal_type must be one of: deadline, habit, exploration
+- habit goals need "frequency": how many days per week the user wants to do the habit (1-7)
+- exploration goals are open-ended learning; give them "weekly_hours_budget" (hours per week) instead of a deadline when the user mentions a time box
 - user_priority must be 1 (Low), 2 (Medium), or 3 (High)
+- Task status is one of todo, in_progress, waiting, done, cancelled. Set "status" on update_task when the user starts, pauses on, or drops a task; use "completed" or status done to finish it. Cancelled tasks no longer count towards their goal
+- A blocked task cannot be started yet; suggest finishing the tasks it waits on first
+- Use checklist items for the steps of a single task instead of creating many small tasks. When every item of a task is ticked, ask the user whether to mark the task completed
+- For tasks that repeat, set "recurrence" on create_task to an RRULE using FREQ (DAILY, WEEKLY, MONTHLY), INTERVAL, BYDAY (MO-SU) and COUNT or UNTIL, e.g. "FREQ=WEEKLY;BYDAY=FR" for every Friday. The next occurrence appears by itself once one is completed
+- A goal marked AT RISK is projected to finish after its deadline: point this out and suggest cutting scope, moving the deadline, or focusing on its tasks
+- goal status moves on its own as tasks get done and deadlines pass; only set "status" to completed, abandoned, in_progress or not_started when the user asks. A goal marked "completion proposed" has every task done: ask the user whether to mark it completed
 - goal_index refers to the position of the goal in the actions array (0-based) — use this ONLY for tasks under a NEW goal being created in the same response
 - If tasks belong to an EXISTING goal, use "existing_goal_id" with the goal's UUID from the list above
 - For update_goal and update_task, only include the fields you want to change
+- Deadlines are calendar dates in the user's timezone written as YYYY-MM-DD; "tomorrow" means the day after today's date above
 - Use the exact goal/task IDs from the user's current goals and tasks listed above
 - If no actions needed, return: {"message": "your response", "actions": []}
 - ALWAYS return valid JSON. Never wrap in markdown code blocks.
 - Even when performing actions on multiple items, you MUST return JSON with the actions array. Plain text responses CANNOT modify any data.
 - Your ENTIRE response must be a single JSON object. Everything you want to say goes inside the "message" field. Never write text outside the JSON structure.
 `,
-		userName,
-		time.Now().Format("2006-01-02"),
-		formatGoals(goals),
-		formatTasks(tasks),
+		pc.UserName,
+		pc.Now.In(loc).Format("2006-01-02"),
+		pc.Now.In(loc).Weekday(),
+		loc,
+		formatPreferences(loc, prefs),
+		formatGoals(pc, loc),
+		formatTasks(pc, loc),
+		prefs.TasksPerGoal,
 	)
 }
 
-func formatDeadline(d *time.Time) string {
-	if d == nil {
+func formatPreferences(loc *time.Location, prefs models.UserPreferences) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("- Working hours: %02d:00-%02d:00 on %s (timezone: %s)\n",
+		prefs.WorkStartHour, prefs.WorkEndHour, prefs.WorkDays, loc))
+	sb.WriteString(fmt.Sprintf("- Always write the message field in %s.\n", prefs.Language))
+	if instruction, ok := toneInstructions[prefs.Tone]; ok {
+		sb.WriteString("- Tone: " + instruction + "\n")
+	}
+	if instruction, ok := verbosityInstructions[prefs.Verbosity]; ok {
+		sb.WriteString("- Length: " + instruction + "\n")
+	}
+	sb.WriteString("- Only plan work that fits inside the user's working hours.\n")
+	return sb.String()
+}
+
+func formatDeadline(d *time.Time, loc *time.Location) string {
+	if d == nil || d.IsZero() {
 		return "no deadline"
 	}
-	return d.Format("2006-01-02")
+	return d.In(loc).Format("2006-01-02")
 }
 
-func formatGoals(goals []models.Goal) string {
+func formatGoals(pc PromptContext, loc *time.Location) string {
+	goals := pc.Goals
 	if len(goals) == 0 {
 		return "There are no current goals for the user."
 	}
 
 	var sb strings.Builder
 	for i, goal := range goals {
-		sb.WriteString(fmt.Sprintf("%d. [ID: %s] %s - %s (%s, due: %s)\n",
-			i+1, goal.ID, goal.Title, goal.Description, goal.GoalType, formatDeadline(goal.Deadline)))
+		sb.WriteString(fmt.Sprintf("%d. [ID: %s] %s - %s (%s, %s, due: %s)\n",
+			i+1, goal.ID, goal.Title, goal.Description, goal.GoalType, engine.NormalizeGoalStatus(goal.Status), formatDeadline(goal.Deadline, loc)))
+		if goal.CompletionProposedAt != nil {
+			sb.WriteString("   completion proposed: every task is done\n")
+		}
+		if forecast, ok := pc.Forecasts[goal.ID]; ok && forecast.RemainingTasks > 0 {
+			sb.WriteString("   " + formatForecast(forecast, loc) + "\n")
+		}
+		if habit, ok := pc.Habits[goal.ID]; ok {
+			sb.WriteString(fmt.Sprintf("   habit: %d of %d check-ins this week, %d day(s) left, streak %d week(s), adherence %.0f%%\n",
+				habit.ThisWeek, habit.Frequency, habit.DaysLeft, habit.CurrentStreak, habit.Adherence*100))
+		}
+		if exploration, ok := pc.Explorations[goal.ID]; ok {
+			sb.WriteString(fmt.Sprintf("   exploration: %.1fh logged this week", exploration.HoursThisWeek))
+			if exploration.WeeklyBudget != nil {
+				sb.WriteString(fmt.Sprintf(" of a %.1fh budget", *exploration.WeeklyBudget))
+			}
+			sb.WriteString(fmt.Sprintf(", %d log entries, untouched for %d day(s)\n", exploration.TotalEntries, exploration.IdleDays))
+		}
 	}
 
 	return sb.String()
 }
 
-func formatTasks(tasks []models.Task) string {
+func formatForecast(f engine.GoalForecast, loc *time.Location) string {
+	line := fmt.Sprintf("forecast: %d task(s) left at %.1f per week", f.RemainingTasks, f.TasksPerWeek)
+	if f.Projected == nil {
+		line += ", no projected finish yet"
+	} else {
+		line += ", projected to finish " + formatDeadline(f.Projected, loc)
+		if f.Earliest != nil && f.Latest != nil {
+			line += fmt.Sprintf(" (likely between %s and %s)", formatDeadline(f.Earliest, loc), formatDeadline(f.Latest, loc))
+		}
+	}
+	if f.AtRisk {
+		line += ", AT RISK of missing the deadline"
+	}
+	return line
+}
+
+func formatTasks(pc PromptContext, loc *time.Location) string {
+	tasks := pc.Tasks
 	if len(tasks) == 0 {
 		return "There are no current tasks for the user."
 	}
 
 	var sb strings.Builder
 	for i, task := range tasks {
-		sb.WriteString(fmt.Sprintf("%d. [ID: %s] %s (priority: %d, urgency: %d, completed: %t, goal: %s)\n",
-			i+1, task.ID, task.Title, task.UserPriority, task.AIUrgency, task.IsCompleted, task.GoalID))
+		// Open tasks show their current breakdown rather than the stored score, which may be stale
+		urgency, reasons := task.AIUrgency, ""
+		if breakdown, ok := pc.Urgency[task.ID]; ok && engine.TaskOpen(task) {
+			urgency, reasons = breakdown.Urgency, breakdown.Summary()
+		}
+		sb.WriteString(fmt.Sprintf("%d. [ID: %s] %s (priority: %d, urgency: %d, status: %s, due: %s, goal: %s)\n",
+			i+1, task.ID, task.Title, task.UserPriority, urgency, engine.NormalizeTaskStatus(task.Status, task.IsCompleted), formatDeadline(&task.Deadline, loc), task.GoalID))
+		if reasons != "" {
+			sb.WriteString(fmt.Sprintf("   urgency because: %s\n", reasons))
+		}
+		for _, item := range pc.Checklists[task.ID] {
+			mark := " "
+			if item.IsCompleted {
+				mark = "x"
+			}
+			sb.WriteString(fmt.Sprintf("   [%s] [Item ID: %s] %s\n", mark, item.ID, item.Title))
+		}
+		if blockers := pc.Blockers[task.ID]; len(blockers) > 0 {
+			ids := make([]string, 0, len(blockers))
+			for _, id := range blockers {
+				ids = append(ids, id.String())
+			}
+			sb.WriteString(fmt.Sprintf("   blocked until these tasks are done: %s\n", strings.Join(ids, ", ")))
+		}
+	}
+
+	return sb.String()
+}
+
+// BuildReviewPrompt is the system prompt with a look back over a range of days added,
+// asking the assistant to write it up and propose actions for the days ahead
+func BuildReviewPrompt(pc PromptContext, review engine.Review) string {
+	loc := pc.Location
+	if loc == nil {
+		loc = time.UTC
 	}
 
+	return BuildSystemPrompt(pc) + fmt.Sprintf(`
+## Review of %s to %s:
+
+Completed:
+%s
+Slipped past their deadline:
+%s
+Going stale:
+%s
+Habits below target:
+%s
+New goals:
+%s
+## Writing the Review:
+- In the message field, sum up the period: celebrate what got done, then name what slipped, what is going stale and which habits fell behind
+- Propose actions for the week ahead, such as new deadlines for slipped tasks, reprioritizing or cancelling stale tasks, and tasks that help neglected habits. The user approves them before anything changes
+`,
+		review.From,
+		review.To,
+		formatReviewTasks(review.Completed, loc),
+		formatReviewTasks(review.Slipped, loc),
+		formatReviewTasks(review.Stale, loc),
+		formatReviewHabits(review.NeglectedHabits),
+		formatReviewGoals(review.NewGoals),
+	)
+}
+
+func formatReviewTasks(tasks []engine.ReviewTask, loc *time.Location) string {
+	if len(tasks) == 0 {
+		return "- none\n"
+	}
+
+	var sb strings.Builder
+	for _, task := range tasks {
+		sb.WriteString(fmt.Sprintf("- [ID: %s] %s (due: %s", task.TaskID, task.Title, formatDeadline(task.Deadline, loc)))
+		if task.CompletedAt != nil {
+			sb.WriteString(", completed: " + formatDeadline(task.CompletedAt, loc))
+		}
+		if task.IdleRatio > 0 {
+			sb.WriteString(fmt.Sprintf(", idle for %.0f%% of its window", task.IdleRatio*100))
+		}
+		sb.WriteString(")\n")
+	}
+	return sb.String()
+}
+
+func formatReviewHabits(habits []engine.ReviewHabit) string {
+	if len(habits) == 0 {
+		return "- none\n"
+	}
+
+	var sb strings.Builder
+	for _, habit := range habits {
+		sb.WriteString(fmt.Sprintf("- [ID: %s] %s: %d of %d check-ins\n", habit.GoalID, habit.Title, habit.CheckIns, habit.Expected))
+	}
+	return sb.String()
+}
+
+func formatReviewGoals(goals []models.Goal) string {
+	if len(goals) == 0 {
+		return "- none\n"
+	}
+
+	var sb strings.Builder
+	for _, goal := range goals {
+		sb.WriteString(fmt.Sprintf("- [ID: %s] %s (%s)\n", goal.ID, goal.Title, goal.GoalType))
+	}
+	return sb.String()
+}
+
+// BuildBriefingPrompt is the system prompt with the day's briefing added, asking the
+// assistant to turn it into a plan for the day
+func BuildBriefingPrompt(pc PromptContext, briefing engine.Briefing) string {
+	loc := pc.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return BuildSystemPrompt(pc) + fmt.Sprintf(`
+## Briefing for %s:
+
+Most urgent:
+%s
+Overdue:
+%s
+Habit streaks at risk:
+%s
+## Writing the Briefing:
+- The user has not said anything yet: this message is waiting for them when they open the app
+- In the message field, lay out a plan for today: what to start with and why, what is overdue, and which habits need a check-in to keep their streak
+- Propose reprioritize_task actions for tasks whose priority no longer matches their urgency, and update_task actions with a realistic new deadline, or status cancelled, for overdue tasks. Propose no other actions. The user approves them before anything changes
+`,
+		briefing.Date,
+		formatBriefingTasks(briefing.TopTasks, loc),
+		formatBriefingTasks(briefing.Overdue, loc),
+		formatBriefingHabits(briefing.HabitsAtRisk),
+	)
+}
+
+func formatBriefingTasks(tasks []engine.BriefingTask, loc *time.Location) string {
+	if len(tasks) == 0 {
+		return "- none\n"
+	}
+
+	var sb strings.Builder
+	for _, task := range tasks {
+		sb.WriteString(fmt.Sprintf("- [ID: %s] %s (urgency: %d, due: %s", task.TaskID, task.Title, task.Urgency, formatDeadline(task.Deadline, loc)))
+		if task.DaysOverdue > 0 {
+			sb.WriteString(fmt.Sprintf(", %d day(s) overdue", task.DaysOverdue))
+		}
+		sb.WriteString(")\n")
+	}
+	return sb.String()
+}
+
+func formatBriefingHabits(habits []engine.BriefingHabit) string {
+	if len(habits) == 0 {
+		return "- none\n"
+	}
+
+	var sb strings.Builder
+	for _, habit := range habits {
+		sb.WriteString(fmt.Sprintf("- [ID: %s] %s: %d more check-in(s) needed in %d day(s), streak %d week(s)\n",
+			habit.GoalID, habit.Title, habit.Remaining, habit.DaysLeft, habit.CurrentStreak))
+	}
 	return sb.String()
 }
@@ -1,12 +1,12 @@
 package llm
 
-import "time"
-
 type GoalAction struct {
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	GoalType    string     `json:"goal_type"`
-	Deadline    *time.Time `json:"deadline,omitempty"`
+	Title             string   `json:"title"`
+	Description       string   `json:"description"`
+	GoalType          string   `json:"goal_type"`
+	Deadline          *string  `json:"deadline,omitempty"`            // YYYY-MM-DD in the user's timezone, or RFC 3339
+	Frequency         *int     `json:"frequency,omitempty"`           // Check-ins per week for habit goals
+	WeeklyHoursBudget *float64 `json:"weekly_hours_budget,omitempty"` // Hours per week for exploration goals
 }
 
 type TaskAction struct {
@@ -14,6 +14,7 @@ type TaskAction struct {
 	GoalIndex      *int    `json:"goal_index,omitempty"`
 	ExistingGoalID *string `json:"existing_goal_id,omitempty"`
 	UserPriority   int     `json:"user_priority"`
+	Recurrence     *string `json:"recurrence,omitempty"` // RRULE, e.g. FREQ=WEEKLY;BYDAY=FR
 }
 
 type ReprioritizeAction struct {
@@ -31,6 +32,7 @@ type Action struct {
 	DeleteTaskAction *DeleteTaskAction   `json:"delete_task,omitempty"`
 	UpdateGoalAction *UpdateGoalAction   `json:"update_goal,omitempty"`
 	DeleteGoalAction *DeleteGoalAction   `json:"delete_goal,omitempty"`
+	ChecklistItem    *ChecklistAction    `json:"checklist_item,omitempty"`
 }
 
 type LLMResponse struct {
@@ -39,30 +41,40 @@ type LLMResponse struct {
 }
 
 type UpdateGoalAction struct {
-	GoalID      string     `json:"goal_id"`
-	Title       *string    `json:"title,omitempty"`
-	Description *string    `json:"description,omitempty"`
-	GoalType    *string    `json:"goal_type,omitempty"`
-	Status      *string    `json:"status,omitempty"`
-	Deadline    *time.Time `json:"deadline,omitempty"`
-	Frequency   *int       `json:"frequency,omitempty"`
+	GoalID            string   `json:"goal_id"`
+	Title             *string  `json:"title,omitempty"`
+	Description       *string  `json:"description,omitempty"`
+	GoalType          *string  `json:"goal_type,omitempty"`
+	Status            *string  `json:"status,omitempty"`
+	Deadline          *string  `json:"deadline,omitempty"`
+	Frequency         *int     `json:"frequency,omitempty"`
+	WeeklyHoursBudget *float64 `json:"weekly_hours_budget,omitempty"`
 }
 
 type UpdateTaskAction struct {
-	TaskID         string     `json:"task_id"`
-	Title          *string    `json:"title,omitempty"`
-	Description    *string    `json:"description,omitempty"`
-	Deadline       *time.Time `json:"deadline,omitempty"`
-	UserPriority   *int       `json:"user_priority,omitempty"`
-	Completed      *bool      `json:"completed,omitempty"`
-	GoalIndex      *int       `json:"goal_index,omitempty"`
-	ExistingGoalID *string    `json:"existing_goal_id,omitempty"`
+	TaskID         string  `json:"task_id"`
+	Title          *string `json:"title,omitempty"`
+	Description    *string `json:"description,omitempty"`
+	Deadline       *string `json:"deadline,omitempty"`
+	UserPriority   *int    `json:"user_priority,omitempty"`
+	Completed      *bool   `json:"completed,omitempty"`
+	Status         *string `json:"status,omitempty"` // todo, in_progress, waiting, done or cancelled
+	GoalIndex      *int    `json:"goal_index,omitempty"`
+	ExistingGoalID *string `json:"existing_goal_id,omitempty"`
 }
 
 type DeleteGoalAction struct {
 	GoalID string `json:"goal_id"`
 }
 
+// ChecklistAction adds an item to a task's checklist, or ticks, unticks or renames one when ItemID is set
+type ChecklistAction struct {
+	TaskID    string  `json:"task_id"`
+	ItemID    *string `json:"item_id,omitempty"`
+	Title     *string `json:"title,omitempty"`
+	Completed *bool   `json:"completed,omitempty"`
+}
+
 type DeleteTaskAction struct {
 	TaskID string `json:"task_id"`
 }
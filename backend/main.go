@@ -1,13 +1,20 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/Pranay0205/velo/backend/database"
+	"github.com/Pranay0205/velo/backend/engine"
 	"github.com/Pranay0205/velo/backend/handlers"
 	"github.com/Pranay0205/velo/backend/llm"
 	"github.com/Pranay0205/velo/backend/middleware"
+	"github.com/Pranay0205/velo/backend/services"
+	"github.com/Pranay0205/velo/backend/utils"
 	"github.com/joho/godotenv"
 
 	"github.com/gofiber/fiber/v3"
@@ -30,9 +37,20 @@ func main() {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
+	clock := engine.SystemClock{}
+
+	// Recompute urgency in the background instead of on every read
+	urgencyService := services.NewUrgencyService(db, clock, utils.DurationFromEnv("URGENCY_RECOMPUTE_INTERVAL", 15*time.Minute))
+
+	// Deleted goals and tasks stay restorable from the trash for this long
+	trashService := services.NewTrashService(db, clock, utils.DurationFromEnv("TRASH_RETENTION", 30*24*time.Hour))
+
 	authHandler := &handlers.AuthHandler{DB: db, JWTSecret: os.Getenv("JWT_SECRET")}
-	goalHandler := &handlers.GoalHandler{DB: db}
-	taskHandler := &handlers.TaskHandler{DB: db}
+	goalHandler := &handlers.GoalHandler{DB: db, Clock: clock, Urgency: urgencyService}
+	taskHandler := &handlers.TaskHandler{DB: db, Clock: clock, Urgency: urgencyService}
+	preferencesHandler := &handlers.PreferencesHandler{DB: db, Urgency: urgencyService}
+	planHandler := &handlers.PlanHandler{DB: db, Clock: clock}
+	analyticsHandler := &handlers.AnalyticsHandler{DB: db, Clock: clock}
 
 	geminiClient, err := llm.NewGeminiClient()
 	if err != nil {
@@ -40,10 +58,15 @@ func main() {
 	}
 
 	chatHandler := &handlers.ChatHandler{
-		DB:     db,
-		Gemini: geminiClient,
+		DB:      db,
+		Gemini:  geminiClient,
+		Clock:   clock,
+		Urgency: urgencyService,
 	}
 
+	// Write each subscribed user's daily briefing once their local briefing hour comes round
+	briefingService := services.NewBriefingService(db, clock, utils.DurationFromEnv("BRIEFING_CHECK_INTERVAL", 10*time.Minute), chatHandler.SendBriefing)
+
 	app := fiber.New()
 	app.Use(logger.New())
 
@@ -67,23 +90,151 @@ func main() {
 
 	api.Delete("/goals/:id", goalHandler.DeleteGoal)
 
+	api.Post("/goals/:id/restore", goalHandler.RestoreGoal)
+
+	api.Get("/goals/:id/urgency/trend", goalHandler.GetUrgencyTrend)
+
+	api.Get("/goals/:id/status-history", goalHandler.GetStatusHistory)
+
+	api.Get("/goals/:id/checkins", goalHandler.GetCheckIns)
+
+	api.Post("/goals/:id/checkins", goalHandler.CheckInHabit)
+
+	api.Delete("/goals/:id/checkins/:date", goalHandler.DeleteCheckIn)
+
+	api.Get("/goals/:id/log", goalHandler.GetLearningLog)
+
+	api.Post("/goals/:id/log", goalHandler.AddLearningLogEntry)
+
+	api.Delete("/goals/:id/log/:entryId", goalHandler.DeleteLearningLogEntry)
+
+	api.Get("/habits/reminders", goalHandler.GetHabitReminders)
+
+	api.Get("/goals/:id/critical-path", goalHandler.GetCriticalPath)
+
+	api.Get("/goals/:id/burndown", goalHandler.GetBurndown)
+
+	api.Get("/goals/:id/forecast", goalHandler.GetForecast)
+
+	api.Get("/analytics/velocity", analyticsHandler.GetVelocity)
+
+	api.Get("/analytics/completion", analyticsHandler.GetCompletionStats)
+
+	api.Get("/analytics/productivity", analyticsHandler.GetProductivity)
+
 	api.Get("/tasks", taskHandler.GetTasks)
 
+	api.Get("/tasks/forecast", taskHandler.ForecastUrgency)
+
+	api.Get("/tasks/:id/urgency", taskHandler.ExplainUrgency)
+
+	api.Get("/tasks/:id/urgency/history", taskHandler.GetUrgencyHistory)
+
+	api.Get("/tasks/:id/completions", taskHandler.GetTaskCompletions)
+
+	api.Get("/tasks/:id/checklist", taskHandler.GetChecklist)
+
+	api.Post("/tasks/:id/checklist", taskHandler.AddChecklistItem)
+
+	api.Put("/tasks/:id/checklist/:itemId", taskHandler.UpdateChecklistItem)
+
+	api.Delete("/tasks/:id/checklist/:itemId", taskHandler.DeleteChecklistItem)
+
+	api.Get("/tasks/:id/dependencies", taskHandler.GetTaskDependencies)
+
+	api.Post("/tasks/:id/dependencies", taskHandler.AddTaskDependency)
+
+	api.Delete("/tasks/:id/dependencies/:dependsOnId", taskHandler.RemoveTaskDependency)
+
+	api.Get("/board", taskHandler.GetBoard)
+
+	api.Put("/tasks/:id/move", taskHandler.MoveTask)
+
 	api.Post("/tasks", taskHandler.CreateTask)
 
+	api.Get("/urgency/settings", taskHandler.GetUrgencySettings)
+
+	api.Get("/capacity", taskHandler.GetCapacity)
+
+	api.Put("/urgency/settings", taskHandler.UpdateUrgencySettings)
+
 	api.Patch("/tasks/:id/complete", taskHandler.CompleteTask)
 
 	api.Put("/tasks/:id", taskHandler.UpdateTask)
 
 	api.Delete("/tasks/:id", taskHandler.DeleteTask)
 
+	api.Post("/tasks/:id/restore", taskHandler.RestoreTask)
+
+	api.Get("/trash", goalHandler.GetTrash)
+
+	api.Get("/plan/today", planHandler.GetTodayPlan)
+
+	api.Put("/plan/today/tasks/:id", planHandler.UpdatePlanTask)
+
+	api.Post("/plan/today/accept", planHandler.AcceptTodayPlan)
+
+	api.Get("/plan/history", planHandler.GetPlanHistory)
+
+	api.Get("/schedule", planHandler.GetSchedule)
+
+	api.Get("/busy-blocks", planHandler.GetBusyBlocks)
+
+	api.Post("/busy-blocks", planHandler.CreateBusyBlock)
+
+	api.Put("/busy-blocks/:id", planHandler.UpdateBusyBlock)
+
+	api.Delete("/busy-blocks/:id", planHandler.DeleteBusyBlock)
+
 	api.Post("/chat", chatHandler.Chat)
 
 	api.Post("/chat/execute", chatHandler.ExecuteActions)
 
+	api.Post("/chat/regenerate", chatHandler.RegenerateReply)
+
+	api.Put("/chat/:id", chatHandler.EditMessage)
+
+	api.Put("/chat/:id/feedback", chatHandler.SubmitFeedback)
+
+	api.Post("/chat/:id/discard", chatHandler.DiscardActions)
+
+	api.Get("/chat/feedback/export", chatHandler.ExportFeedback)
+
 	api.Get("/chat", chatHandler.GetChatHistory)
 
+	api.Get("/reviews/weekly", chatHandler.GetWeeklyReview)
+
+	api.Post("/reviews/weekly/narrative", chatHandler.WriteWeeklyReview)
+
 	api.Get("/me", authHandler.Me)
 
-	app.Listen(":3000")
+	api.Get("/preferences", preferencesHandler.GetPreferences)
+
+	api.Put("/preferences", preferencesHandler.UpdatePreferences)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	urgencyService.Start(ctx)
+	trashService.Start(ctx)
+	briefingService.Start(ctx)
+
+	go func() {
+		if err := app.Listen(":3000"); err != nil {
+			log.Printf("Server stopped: %v", err)
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down...")
+
+	if err := app.ShutdownWithTimeout(10 * time.Second); err != nil {
+		log.Printf("Failed to shut down server cleanly: %v", err)
+	}
+
+	urgencyService.Wait()
+	trashService.Wait()
+	briefingService.Wait()
+	log.Println("Shutdown complete")
 }
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +14,7 @@ type User struct {
 	LastName     string    `json:"last_name"`
 	Email        string    `json:"email" gorm:"uniqueIndex"`
 	PasswordHash string    `json:"-"`
+	Timezone     string    `json:"timezone" gorm:"not null;default:'UTC'"` // IANA zone name, e.g. "America/New_York"
 	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 }
@@ -24,40 +26,305 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// UserPreferences controls how the assistant plans and talks to a user
+type UserPreferences struct {
+	UserID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	WorkStartHour int       `gorm:"not null" json:"work_start_hour"`                                       // 0-23, in the user's timezone
+	WorkEndHour   int       `gorm:"not null" json:"work_end_hour"`                                         // 1-24, in the user's timezone
+	WorkDays      string    `gorm:"not null" json:"work_days"`                                             // Comma separated: "mon,tue,wed,thu,fri"
+	Tone          string    `gorm:"not null" json:"tone"`                                                  // friendly, direct, motivational
+	Verbosity     string    `gorm:"not null" json:"verbosity"`                                             // brief, balanced, detailed
+	TasksPerGoal  int       `gorm:"not null" json:"tasks_per_goal"`                                        // How many tasks the assistant creates per new goal
+	Language      string    `gorm:"not null" json:"language"`                                              // Language the assistant replies in
+	BoardColumns  string    `gorm:"not null;default:'todo,in_progress,waiting,done'" json:"board_columns"` // Comma separated task statuses shown on the board, in order
+	Briefing      bool      `gorm:"not null;default:false" json:"briefing"`                                // Whether the assistant writes a daily briefing
+	BriefingHour  int       `gorm:"not null;default:8" json:"briefing_hour"`                               // 0-23, in the user's timezone
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// DefaultUserPreferences returns the preferences used until a user saves their own
+func DefaultUserPreferences(userID uuid.UUID) UserPreferences {
+	return UserPreferences{
+		UserID:        userID,
+		WorkStartHour: 9,
+		WorkEndHour:   17,
+		WorkDays:      "mon,tue,wed,thu,fri",
+		Tone:          "friendly",
+		Verbosity:     "balanced",
+		TasksPerGoal:  4,
+		Language:      "English",
+		BoardColumns:  "todo,in_progress,waiting,done",
+		BriefingHour:  8,
+	}
+}
+
+// UrgencySettings customizes how the urgency engine scores a user's tasks
+type UrgencySettings struct {
+	UserID               uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Strategy             string    `gorm:"not null" json:"strategy"` // "default" or "wsjf"
+	PriorityWeight       float64   `gorm:"not null" json:"priority_weight"`
+	DeadlineWeight       float64   `gorm:"not null" json:"deadline_weight"`
+	GoalLagWeight        float64   `gorm:"not null" json:"goal_lag_weight"`
+	StalenessWeight      float64   `gorm:"not null" json:"staleness_weight"`
+	ElapsedThreshold1    float64   `gorm:"not null" json:"elapsed_threshold_1"` // Share of the timeline used before deadline pressure reaches 1
+	ElapsedThreshold2    float64   `gorm:"not null" json:"elapsed_threshold_2"`
+	ElapsedThreshold3    float64   `gorm:"not null" json:"elapsed_threshold_3"`
+	ElapsedThreshold4    float64   `gorm:"not null" json:"elapsed_threshold_4"`
+	CriticalDays         int       `gorm:"not null" json:"critical_days"` // Days left at or below which deadline pressure is maxed
+	WarningDays          int       `gorm:"not null" json:"warning_days"`
+	StaleIdleRatio       float64   `gorm:"not null" json:"stale_idle_ratio"`
+	DefaultEstimateHours float64   `gorm:"not null" json:"default_estimate_hours"` // Used by wsjf for tasks without an estimate
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
 type Task struct {
-	ID             uuid.UUID `json:"id"`
-	UserID         uuid.UUID `json:"userID"`
-	GoalID         uuid.UUID `json:"goal_id"`
-	Title          string    `json:"title"`
-	Description    string    `json:"description"`
-	Deadline       time.Time `json:"deadline"`
-	EstimatedHours *float64  `json:"estimated_hours" gorm:"default:null"`
-	UserPriority   int       `json:"user_priority"` // 1-3: Low, Med, High
-	AIUrgency      int       `json:"ai_urgency"`    // 1-10: Calculated engine pressure
-	IsCompleted    bool      `json:"is_completed"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	CreatedAt      time.Time `json:"created_at"`
+	ID                uuid.UUID      `json:"id"`
+	UserID            uuid.UUID      `json:"userID"`
+	GoalID            uuid.UUID      `json:"goal_id"`
+	Title             string         `json:"title"`
+	Description       string         `json:"description"`
+	Deadline          time.Time      `json:"deadline"`
+	EstimatedHours    *float64       `json:"estimated_hours" gorm:"default:null"`
+	TrackedHours      *float64       `json:"tracked_hours" gorm:"default:null"` // Time actually spent, when the user logs it
+	UserPriority      int            `json:"user_priority"`                     // 1-3: Low, Med, High
+	AIUrgency         int            `json:"ai_urgency"`                        // 1-10: Calculated engine pressure
+	UrgencyComputedAt *time.Time     `json:"urgency_computed_at"`               // When the background service last scored the task
+	IsCompleted       bool           `json:"is_completed"`
+	Status            string         `gorm:"default:'todo';index" json:"status"` // todo, in_progress, waiting, done, cancelled
+	Position          int            `json:"position"`                           // 0-based order within the task's board column
+	StartedAt         *time.Time     `json:"started_at"`                         // First moved to in progress
+	CompletedAt       *time.Time     `json:"completed_at"`                       // Set while the task is done
+	UpdatedAt         time.Time      `json:"updated_at"`
+	CreatedAt         time.Time      `json:"created_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"deleted_at"`          // Set while the task is in the trash
+	SeriesID          *uuid.UUID     `gorm:"type:uuid;index" json:"series_id"` // Recurring series this task is an occurrence of
+	Occurrence        int            `json:"occurrence,omitempty"`             // 1-based position in the series
 }
 
 func (u *Task) BeforeCreate(tx *gorm.DB) error {
 	if u.ID == uuid.Nil {
 		u.ID = uuid.New()
 	}
+	if u.Status == "" && u.IsCompleted {
+		u.Status = "done"
+	} else if u.Status == "" {
+		u.Status = "todo"
+	}
+	return nil
+}
+
+// TaskSeries is the template the occurrences of a recurring task are generated from.
+// Its fields apply to every future occurrence; editing a single task leaves it alone.
+type TaskSeries struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	GoalID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"goal_id"`
+	Title          string     `gorm:"not null" json:"title"`
+	Description    string     `json:"description"`
+	UserPriority   int        `json:"user_priority"`
+	EstimatedHours *float64   `json:"estimated_hours"`
+	Rule           string     `gorm:"not null" json:"rule"`                  // RRULE subset, e.g. FREQ=WEEKLY;BYDAY=FR
+	StartsAt       time.Time  `gorm:"not null" json:"starts_at"`             // Due time of the first occurrence
+	StartIndex     int        `gorm:"not null;default:1" json:"start_index"` // Occurrence number of StartsAt; above 1 once the series was restarted part way
+	LastDueAt      time.Time  `gorm:"not null" json:"last_due_at"`           // Due time of the latest generated occurrence
+	EndedAt        *time.Time `json:"ended_at"`                              // Set once the rule runs out or the series is stopped
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (s *TaskSeries) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// TaskDependency says a task cannot start until the task it depends on is completed
+type TaskDependency struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	TaskID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_task_dependency" json:"task_id"`
+	DependsOnID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_task_dependency;index" json:"depends_on_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (d *TaskDependency) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// ChecklistItem is one step of a task, kept in the order the user arranged them
+type ChecklistItem struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	TaskID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"task_id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Title       string     `gorm:"not null" json:"title"`
+	Position    int        `gorm:"not null" json:"position"` // 0-based order within the task
+	IsCompleted bool       `gorm:"default:false" json:"is_completed"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (i *ChecklistItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// TaskCompletionEvent records each time a task was completed, reopened or cancelled once done, and from where
+type TaskCompletionEvent struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	TaskID     uuid.UUID `gorm:"type:uuid;not null;index" json:"task_id"`
+	GoalID     uuid.UUID `gorm:"type:uuid;not null;index" json:"goal_id"`
+	Event      string    `gorm:"not null" json:"event"`  // completed, reopened or cancelled
+	Source     string    `gorm:"not null" json:"source"` // rest, chat or quick_add (as claimed by the client)
+	OccurredAt time.Time `gorm:"not null;index" json:"occurred_at"`
+}
+
+func (e *TaskCompletionEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// UrgencyHistory is an append-only log of every urgency change the engine makes to a task
+type UrgencyHistory struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TaskID          uuid.UUID `gorm:"type:uuid;not null;index" json:"task_id"`
+	GoalID          uuid.UUID `gorm:"type:uuid;not null;index" json:"goal_id"`
+	UserID          uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
+	PreviousUrgency int       `gorm:"not null" json:"previous_urgency"`
+	Urgency         int       `gorm:"not null" json:"urgency"`
+	RecordedAt      time.Time `gorm:"not null;index" json:"recorded_at"`
+}
+
+func (u *UrgencyHistory) BeforeCreate(tx *gorm.DB) error {
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	return nil
+}
+
+// DailyPlan is the set of tasks a user plans to work on during one local day.
+// Until it is accepted its items only hold the user's pins and skips.
+type DailyPlan struct {
+	ID             uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID         uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_daily_plan_user_date" json:"user_id"`
+	Date           string          `gorm:"not null;uniqueIndex:idx_daily_plan_user_date" json:"date"` // YYYY-MM-DD in the user's timezone
+	AvailableHours float64         `json:"available_hours"`
+	PlannedHours   float64         `json:"planned_hours"`
+	AcceptedAt     *time.Time      `json:"accepted_at"`
+	Items          []DailyPlanItem `gorm:"foreignKey:PlanID;constraint:OnDelete:CASCADE" json:"items"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+func (p *DailyPlan) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+type DailyPlanItem struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	PlanID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_plan_item_task" json:"plan_id"`
+	TaskID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_plan_item_task" json:"task_id"`
+	Position int       `json:"position"`
+	Hours    float64   `json:"hours"`
+	Reason   string    `json:"reason"`
+	Pinned   bool      `gorm:"default:false" json:"pinned"`
+	Partial  bool      `gorm:"default:false" json:"partial"` // Only part of the task's estimate fits in the day
+	Skipped  bool      `gorm:"default:false" json:"skipped"` // Excluded by the user, never part of the plan
+}
+
+func (i *DailyPlanItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// BusyBlock is a fixed period, such as a meeting, during which no tasks are scheduled
+type BusyBlock struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Title     string    `gorm:"not null" json:"title"`
+	StartsAt  time.Time `gorm:"not null;index" json:"starts_at"`
+	EndsAt    time.Time `gorm:"not null" json:"ends_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (b *BusyBlock) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
+
+// HabitCheckIn records that the user did their habit on a given local day
+type HabitCheckIn struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	GoalID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_checkin_goal_date" json:"goal_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Date      string    `gorm:"not null;uniqueIndex:idx_checkin_goal_date" json:"date"` // YYYY-MM-DD in the user's timezone
+	Note      string    `json:"note"`
+	CheckedAt time.Time `gorm:"not null" json:"checked_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (h *HabitCheckIn) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return nil
+}
+
+// LearningLogEntry is a note or link recorded while working on an exploration goal
+type LearningLogEntry struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	GoalID     uuid.UUID `gorm:"type:uuid;not null;index" json:"goal_id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Kind       string    `gorm:"not null" json:"kind"` // note or link
+	Content    string    `json:"content"`
+	URL        string    `json:"url"`
+	HoursSpent float64   `json:"hours_spent"`
+	LoggedAt   time.Time `gorm:"not null;index" json:"logged_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (l *LearningLogEntry) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
 	return nil
 }
 
 type Goal struct {
-	ID           uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
-	UserID       uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
-	Title        string     `gorm:"not null" json:"title"`
-	Description  string     `json:"description"`
-	GoalType     string     `gorm:"column:goal_type;not null" json:"goal_type"`
-	Status       string     `gorm:"not null;default:'active'" json:"status"`
-	Deadline     *time.Time `json:"deadline"`
-	Frequency    *int       `json:"frequency"`
-	LastActiveAt *time.Time `json:"last_active_at"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
+	ID                   uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID               uuid.UUID      `gorm:"type:uuid;not null" json:"user_id"`
+	Title                string         `gorm:"not null" json:"title"`
+	Description          string         `json:"description"`
+	GoalType             string         `gorm:"column:goal_type;not null" json:"goal_type"`
+	Status               string         `gorm:"not null;default:'not_started'" json:"status"`
+	Deadline             *time.Time     `json:"deadline"`
+	Frequency            *int           `json:"frequency"`
+	LastActiveAt         *time.Time     `json:"last_active_at"`
+	WeeklyHoursBudget    *float64       `json:"weekly_hours_budget"` // Time box for exploration goals
+	StatusChangedAt      *time.Time     `json:"status_changed_at"`
+	CompletionProposedAt *time.Time     `json:"completion_proposed_at"` // Set while every task is done but the goal is still open
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"deleted_at"` // Set while the goal is in the trash
 }
 
 func (u *Goal) BeforeCreate(tx *gorm.DB) error {
@@ -67,15 +334,71 @@ func (u *Goal) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// GoalStatusTransition records one change of a goal's status and what caused it
+type GoalStatusTransition struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	GoalID         uuid.UUID `gorm:"type:uuid;not null;index" json:"goal_id"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	FromStatus     string    `gorm:"not null" json:"from_status"`
+	ToStatus       string    `gorm:"not null" json:"to_status"`
+	Trigger        string    `gorm:"not null" json:"trigger"` // user, chat, task_activity, deadline_passed or deadline_moved
+	TransitionedAt time.Time `gorm:"not null;index" json:"transitioned_at"`
+}
+
+func (t *GoalStatusTransition) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
 type ChatMessage struct {
-	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	UserID    uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
-	Message   string    `gorm:"not null" json:"message"`
-	Role      string    `gorm:"not null" json:"role"` // "user" or "assistant"
-	CreatedAt time.Time `json:"created_at"`
+	ID        uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    uuid.UUID       `gorm:"type:uuid;not null" json:"user_id"`
+	Message   string          `gorm:"not null" json:"message"`
+	Role      string          `gorm:"not null" json:"role"`                // "user" or "assistant"
+	Kind      string          `gorm:"index" json:"kind,omitempty"`         // Set on messages the assistant wrote unprompted, e.g. "briefing"
+	Actions   json.RawMessage `gorm:"type:jsonb" json:"actions,omitempty"` // Actions proposed alongside an assistant reply
+	CreatedAt time.Time       `json:"created_at"`
 }
 
 func (u *ChatMessage) BeforeCreate(tx *gorm.DB) error {
 	u.ID = uuid.New()
 	return nil
 }
+
+type MessageFeedback struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
+	ChatMessageID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"chat_message_id"`
+	Rating        int       `gorm:"not null" json:"rating"` // 1: thumbs up, -1: thumbs down
+	Comment       string    `json:"comment"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (u *MessageFeedback) BeforeCreate(tx *gorm.DB) error {
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	return nil
+}
+
+// ActionOutcome records whether the user accepted or discarded one proposed action of an assistant reply
+type ActionOutcome struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
+	ChatMessageID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_action_outcome" json:"chat_message_id"`
+	ActionIndex   int       `gorm:"not null;uniqueIndex:idx_action_outcome" json:"action_index"`
+	ActionType    string    `gorm:"not null" json:"action_type"`
+	Accepted      bool      `gorm:"not null" json:"accepted"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (u *ActionOutcome) BeforeCreate(tx *gorm.DB) error {
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	return nil
+}
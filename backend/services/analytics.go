@@ -0,0 +1,79 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CompletionAnalytics loads the user's tasks completed since a time and measures them
+// with engine.CompletionRates
+func CompletionAnalytics(db *gorm.DB, userID uuid.UUID, since time.Time) (engine.CompletionStats, error) {
+	var tasks []models.Task
+	err := db.Where("user_id = ? AND is_completed = ?", userID, true).
+		Where("completed_at >= ? OR (completed_at IS NULL AND updated_at >= ?)", since, since).
+		Find(&tasks).Error
+	if err != nil {
+		return engine.CompletionStats{}, fmt.Errorf("failed to load completed tasks: %w", err)
+	}
+
+	var goals []models.Goal
+	if err := db.Where("user_id = ?", userID).Find(&goals).Error; err != nil {
+		return engine.CompletionStats{}, fmt.Errorf("failed to load goals: %w", err)
+	}
+	goalsByID := make(map[uuid.UUID]models.Goal, len(goals))
+	for _, goal := range goals {
+		goalsByID[goal.ID] = goal
+	}
+
+	taskIDs := make([]uuid.UUID, 0, len(tasks))
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+	var samples []engine.UrgencySample
+	if len(taskIDs) > 0 {
+		var history []models.UrgencyHistory
+		if err := db.Where("task_id IN ?", taskIDs).Find(&history).Error; err != nil {
+			return engine.CompletionStats{}, fmt.Errorf("failed to load urgency history: %w", err)
+		}
+		for _, entry := range history {
+			samples = append(samples, engine.UrgencySample{TaskID: entry.TaskID, Urgency: entry.Urgency, At: entry.RecordedAt})
+		}
+	}
+
+	return engine.CompletionRates(tasks, goalsByID, samples), nil
+}
+
+// GoalBurndown loads a goal's tasks, leaving out cancelled ones, and replays them with
+// engine.GoalBurndown
+func GoalBurndown(db *gorm.DB, goal models.Goal, now time.Time, days int, loc *time.Location) ([]engine.BurndownPoint, error) {
+	var tasks []models.Task
+	if err := db.Scopes(CountedTasks).Where("goal_id = ?", goal.ID).Find(&tasks).Error; err != nil {
+		return nil, fmt.Errorf("failed to load tasks: %w", err)
+	}
+	return engine.GoalBurndown(goal, tasks, now, days, loc), nil
+}
+
+// LoadGoalCompletions returns when each of the user's completed tasks was finished,
+// keyed by goal. Cancelled tasks are left out.
+func LoadGoalCompletions(db *gorm.DB, userID uuid.UUID) (map[uuid.UUID][]time.Time, error) {
+	var tasks []models.Task
+	err := db.Scopes(CountedTasks).Select("goal_id", "is_completed", "completed_at", "updated_at").
+		Where("user_id = ? AND is_completed = ?", userID, true).
+		Find(&tasks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load completed tasks: %w", err)
+	}
+
+	completions := make(map[uuid.UUID][]time.Time)
+	for _, task := range tasks {
+		if at, ok := engine.CompletionTime(task); ok {
+			completions[task.GoalID] = append(completions[task.GoalID], at)
+		}
+	}
+	return completions, nil
+}
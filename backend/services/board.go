@@ -0,0 +1,93 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BoardColumn is one status column of the task board with its tasks in order
+type BoardColumn struct {
+	Status string
+	Tasks  []models.Task
+}
+
+// LoadBoard groups the user's tasks, optionally of one goal, into the given status
+// columns. Tasks whose status has no column are left off the board.
+func LoadBoard(db *gorm.DB, userID uuid.UUID, columns []string, goalID *uuid.UUID) ([]BoardColumn, error) {
+	query := db.Where("user_id = ?", userID)
+	if goalID != nil {
+		query = query.Where("goal_id = ?", *goalID)
+	}
+
+	var tasks []models.Task
+	if err := query.Order("position, created_at").Find(&tasks).Error; err != nil {
+		return nil, fmt.Errorf("failed to load tasks: %w", err)
+	}
+
+	byStatus := make(map[string][]models.Task)
+	for _, task := range tasks {
+		status := engine.NormalizeTaskStatus(task.Status, task.IsCompleted)
+		byStatus[status] = append(byStatus[status], task)
+	}
+
+	board := make([]BoardColumn, 0, len(columns))
+	for _, status := range columns {
+		board = append(board, BoardColumn{Status: status, Tasks: byStatus[status]})
+	}
+	return board, nil
+}
+
+// MoveTask puts a task into a status column at position, or at the end of it when
+// position is nil or past it, and renumbers the column. A task leaving its column
+// closes the gap it leaves behind.
+func MoveTask(db *gorm.DB, task *models.Task, status string, position *int, now time.Time) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if from := engine.NormalizeTaskStatus(task.Status, task.IsCompleted); from != status {
+			err := tx.Model(&models.Task{}).Scopes(TasksWithStatus(from)).
+				Where("user_id = ? AND id <> ? AND position > ?", task.UserID, task.ID, task.Position).
+				UpdateColumn("position", gorm.Expr("position - 1")).Error
+			if err != nil {
+				return fmt.Errorf("failed to reorder tasks: %w", err)
+			}
+		}
+
+		var column []models.Task
+		err := tx.Scopes(TasksWithStatus(status)).
+			Where("user_id = ? AND id <> ?", task.UserID, task.ID).
+			Order("position, created_at").
+			Find(&column).Error
+		if err != nil {
+			return fmt.Errorf("failed to load tasks: %w", err)
+		}
+
+		at := len(column)
+		if position != nil && *position >= 0 && *position < at {
+			at = *position
+		}
+
+		for i, t := range column {
+			want := i
+			if i >= at {
+				want = i + 1
+			}
+			if t.Position == want {
+				continue
+			}
+			if err := tx.Model(&models.Task{}).Where("id = ?", t.ID).UpdateColumn("position", want).Error; err != nil {
+				return fmt.Errorf("failed to reorder tasks: %w", err)
+			}
+		}
+
+		engine.SetTaskStatus(task, status, now)
+		task.Position = at
+		if err := tx.Save(task).Error; err != nil {
+			return fmt.Errorf("failed to move task: %w", err)
+		}
+		return nil
+	})
+}
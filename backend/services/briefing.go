@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BriefingKind marks the chat messages that hold a daily briefing
+const BriefingKind = "briefing"
+
+// maxBriefingAttempts caps how many times a user's briefing is tried on one local day
+const maxBriefingAttempts = 3
+
+// BriefFunc writes a user's daily briefing to their chat
+type BriefFunc func(ctx context.Context, userID uuid.UUID) error
+
+// DueBriefing is a user whose briefing is due, with their local date
+type DueBriefing struct {
+	UserID uuid.UUID
+	Date   string // YYYY-MM-DD in the user's timezone
+}
+
+// DueBriefings lists the users who turned the daily briefing on, whose briefing hour
+// has come round in their timezone and who have not had a briefing yet today
+func DueBriefings(db *gorm.DB, now time.Time) ([]DueBriefing, error) {
+	var subscribers []struct {
+		UserID       uuid.UUID
+		BriefingHour int
+		Timezone     string
+	}
+	err := db.Model(&models.UserPreferences{}).
+		Select("user_preferences.user_id, user_preferences.briefing_hour, users.timezone").
+		Joins("JOIN users ON users.id = user_preferences.user_id").
+		Where("user_preferences.briefing = ?", true).
+		Scan(&subscribers).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list briefing subscribers: %w", err)
+	}
+
+	var due []DueBriefing
+	for _, s := range subscribers {
+		loc := utils.LoadLocation(s.Timezone)
+		if now.In(loc).Hour() < s.BriefingHour {
+			continue
+		}
+
+		var briefed int64
+		err := db.Model(&models.ChatMessage{}).
+			Where("user_id = ? AND kind = ? AND created_at >= ?", s.UserID, BriefingKind, utils.StartOfDay(now, loc)).
+			Count(&briefed).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to check today's briefing: %w", err)
+		}
+		if briefed == 0 {
+			due = append(due, DueBriefing{UserID: s.UserID, Date: utils.LocalDate(now, loc)})
+		}
+	}
+	return due, nil
+}
+
+// BriefingService has the assistant write each subscribed user a briefing once a day,
+// at their chosen hour, so they open the app to a plan for the day
+type BriefingService struct {
+	DB       *gorm.DB
+	Clock    engine.Clock
+	Interval time.Duration
+	Brief    BriefFunc
+
+	failures map[uuid.UUID]briefingFailure // Failed attempts at today's briefing, by user
+	wg       sync.WaitGroup
+}
+
+// briefingFailure tracks the failed attempts at a user's briefing on one local day
+type briefingFailure struct {
+	date     string
+	attempts int
+	retryAt  time.Time
+}
+
+func NewBriefingService(db *gorm.DB, clock engine.Clock, interval time.Duration, brief BriefFunc) *BriefingService {
+	return &BriefingService{DB: db, Clock: clock, Interval: interval, Brief: brief}
+}
+
+// Start writes the briefings that are due now and then periodically until ctx is cancelled
+func (s *BriefingService) Start(ctx context.Context) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.Interval)
+		defer ticker.Stop()
+
+		s.RunDue(ctx)
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("[BriefingService] Stopping")
+				return
+			case <-ticker.C:
+				s.RunDue(ctx)
+			}
+		}
+	}()
+}
+
+// Wait blocks until the scheduler goroutine has exited
+func (s *BriefingService) Wait() {
+	s.wg.Wait()
+}
+
+// RunDue writes every briefing that is due. A briefing that fails waits twice as long
+// before each retry and gets at most maxBriefingAttempts tries a day. Failures are only
+// kept in memory, so a restart tries again straight away.
+func (s *BriefingService) RunDue(ctx context.Context) {
+	now := time.Now()
+	if s.Clock != nil {
+		now = s.Clock.Now()
+	}
+
+	due, err := DueBriefings(s.DB, now)
+	if err != nil {
+		log.Printf("[BriefingService] %v", err)
+		return
+	}
+	if s.failures == nil {
+		s.failures = make(map[uuid.UUID]briefingFailure)
+	}
+
+	written := 0
+	for _, briefing := range due {
+		if ctx.Err() != nil {
+			break
+		}
+
+		failure, failed := s.failures[briefing.UserID]
+		if failed && failure.date != briefing.Date {
+			failure, failed = briefingFailure{}, false
+		}
+		if failed && (failure.attempts >= maxBriefingAttempts || now.Before(failure.retryAt)) {
+			continue
+		}
+
+		if err := s.Brief(ctx, briefing.UserID); err != nil {
+			failure.date = briefing.Date
+			failure.attempts++
+			failure.retryAt = now.Add(s.Interval << failure.attempts)
+			s.failures[briefing.UserID] = failure
+			log.Printf("[BriefingService] Failed to brief user %s (attempt %d of %d): %v", briefing.UserID, failure.attempts, maxBriefingAttempts, err)
+			continue
+		}
+		delete(s.failures, briefing.UserID)
+		written++
+	}
+	if written > 0 {
+		log.Printf("[BriefingService] Wrote briefings for %d user(s)", written)
+	}
+}
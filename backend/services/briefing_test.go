@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+)
+
+func TestBriefingServiceWritesOncePerLocalDay(t *testing.T) {
+	db := setupTestDB(t)
+
+	// 08:30 in Berlin, 07:30 UTC, 02:30 in New York
+	clock := &engine.FixedClock{Time: time.Date(2026, 3, 11, 7, 30, 0, 0, time.UTC)}
+
+	subscribe := func(email, timezone string, enabled bool, hour int) models.User {
+		user := models.User{Name: "Test", Email: email, Timezone: timezone}
+		db.Create(&user)
+		prefs := models.DefaultUserPreferences(user.ID)
+		prefs.Briefing = enabled
+		prefs.BriefingHour = hour
+		db.Create(&prefs)
+		return user
+	}
+	berlin := subscribe("berlin@example.com", "Europe/Berlin", true, 8)
+	newYork := subscribe("newyork@example.com", "America/New_York", true, 8)
+	subscribe("off@example.com", "Europe/Berlin", false, 8)
+
+	var briefed []uuid.UUID
+	brief := func(ctx context.Context, userID uuid.UUID) error {
+		briefed = append(briefed, userID)
+		return db.Create(&models.ChatMessage{UserID: userID, Role: "assistant", Kind: BriefingKind, Message: "Today", CreatedAt: clock.Now()}).Error
+	}
+	service := NewBriefingService(db, clock, time.Hour, brief)
+
+	service.RunDue(context.Background())
+	if len(briefed) != 1 || briefed[0] != berlin.ID {
+		t.Fatalf("briefed %v, want only the Berlin user whose hour has come", briefed)
+	}
+
+	// A reply from the assistant does not count as the day's briefing, its own briefing does
+	db.Create(&models.ChatMessage{UserID: newYork.ID, Role: "assistant", Message: "Hi", CreatedAt: clock.Now()})
+	clock.Time = clock.Time.Add(6 * time.Hour)
+	briefed = nil
+	service.RunDue(context.Background())
+	if len(briefed) != 1 || briefed[0] != newYork.ID {
+		t.Fatalf("briefed %v later that day, want only the New York user", briefed)
+	}
+
+	// The next local day both are due again
+	clock.Time = clock.Time.Add(24 * time.Hour)
+	briefed = nil
+	service.RunDue(context.Background())
+	if len(briefed) != 2 {
+		t.Errorf("briefed %v the next morning, want both subscribers", briefed)
+	}
+}
+
+func TestBriefingServiceBacksOffFailures(t *testing.T) {
+	db := setupTestDB(t)
+	clock := &engine.FixedClock{Time: time.Date(2026, 3, 11, 8, 0, 0, 0, time.UTC)}
+
+	user := models.User{Name: "Test", Email: "failing@example.com", Timezone: "UTC"}
+	db.Create(&user)
+	prefs := models.DefaultUserPreferences(user.ID)
+	prefs.Briefing = true
+	prefs.BriefingHour = 8
+	db.Create(&prefs)
+
+	attempts := 0
+	failing := true
+	brief := func(ctx context.Context, userID uuid.UUID) error {
+		attempts++
+		if failing {
+			return errors.New("llm unavailable")
+		}
+		return db.Create(&models.ChatMessage{UserID: userID, Role: "assistant", Kind: BriefingKind, Message: "Today", CreatedAt: clock.Now()}).Error
+	}
+	service := NewBriefingService(db, clock, 10*time.Minute, brief)
+
+	start := clock.Time
+	for _, step := range []struct {
+		after time.Duration
+		want  int
+	}{
+		{0, 1},                // First attempt fails
+		{10 * time.Minute, 1}, // Waits twice the interval
+		{20 * time.Minute, 2}, // Then four times it
+		{50 * time.Minute, 2},
+		{60 * time.Minute, 3}, // The third failure is the last for the day
+		{5 * time.Hour, 3},
+	} {
+		clock.Time = start.Add(step.after)
+		service.RunDue(context.Background())
+		if attempts != step.want {
+			t.Fatalf("after %s: %d attempts, want %d", step.after, attempts, step.want)
+		}
+	}
+
+	// The next day starts over
+	failing = false
+	clock.Time = start.AddDate(0, 0, 1)
+	service.RunDue(context.Background())
+	if attempts != 4 {
+		t.Fatalf("the next day: %d attempts, want 4", attempts)
+	}
+	var briefings int64
+	db.Model(&models.ChatMessage{}).Where("user_id = ? AND kind = ?", user.ID, BriefingKind).Count(&briefings)
+	if briefings != 1 {
+		t.Errorf("Expected the next day's briefing to be written, got %d", briefings)
+	}
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChecklistProgress counts the ticked and total checklist items of a task
+type ChecklistProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// ChecklistChanges are edits to a checklist item; nil fields are left alone
+type ChecklistChanges struct {
+	Title     *string
+	Completed *bool
+	Position  *int
+}
+
+// AddChecklistItem inserts an item into a task's checklist at position, or at the end
+// when position is nil or past it, shifting later items down
+func AddChecklistItem(db *gorm.DB, task models.Task, title string, position *int, now time.Time) (models.ChecklistItem, error) {
+	item := models.ChecklistItem{TaskID: task.ID, UserID: task.UserID, Title: title}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.ChecklistItem{}).Where("task_id = ?", task.ID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count checklist items: %w", err)
+		}
+
+		item.Position = int(count)
+		if position != nil && *position >= 0 && *position < item.Position {
+			item.Position = *position
+			err := tx.Model(&models.ChecklistItem{}).
+				Where("task_id = ? AND position >= ?", task.ID, item.Position).
+				Update("position", gorm.Expr("position + 1")).Error
+			if err != nil {
+				return fmt.Errorf("failed to shift checklist items: %w", err)
+			}
+		}
+
+		if err := tx.Create(&item).Error; err != nil {
+			return fmt.Errorf("failed to create checklist item: %w", err)
+		}
+		return touchTask(tx, task.ID, now)
+	})
+	return item, err
+}
+
+// UpdateChecklistItem renames, ticks or moves an item. Moving it shifts the items
+// between its old and new position.
+func UpdateChecklistItem(db *gorm.DB, item *models.ChecklistItem, changes ChecklistChanges, now time.Time) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if changes.Title != nil {
+			item.Title = *changes.Title
+		}
+		if changes.Completed != nil && *changes.Completed != item.IsCompleted {
+			item.IsCompleted = *changes.Completed
+			item.CompletedAt = nil
+			if item.IsCompleted {
+				item.CompletedAt = &now
+			}
+		}
+
+		if changes.Position != nil && *changes.Position != item.Position {
+			var count int64
+			if err := tx.Model(&models.ChecklistItem{}).Where("task_id = ?", item.TaskID).Count(&count).Error; err != nil {
+				return fmt.Errorf("failed to count checklist items: %w", err)
+			}
+			to := min(max(*changes.Position, 0), int(count)-1)
+
+			shift := tx.Model(&models.ChecklistItem{}).Where("task_id = ? AND id != ?", item.TaskID, item.ID)
+			var err error
+			if to < item.Position {
+				err = shift.Where("position >= ? AND position < ?", to, item.Position).Update("position", gorm.Expr("position + 1")).Error
+			} else {
+				err = shift.Where("position > ? AND position <= ?", item.Position, to).Update("position", gorm.Expr("position - 1")).Error
+			}
+			if err != nil {
+				return fmt.Errorf("failed to shift checklist items: %w", err)
+			}
+			item.Position = to
+		}
+
+		if err := tx.Save(item).Error; err != nil {
+			return fmt.Errorf("failed to save checklist item: %w", err)
+		}
+		return touchTask(tx, item.TaskID, now)
+	})
+}
+
+// DeleteChecklistItem removes an item and closes the gap it leaves in the order
+func DeleteChecklistItem(db *gorm.DB, item models.ChecklistItem, now time.Time) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&item).Error; err != nil {
+			return fmt.Errorf("failed to delete checklist item: %w", err)
+		}
+		err := tx.Model(&models.ChecklistItem{}).
+			Where("task_id = ? AND position > ?", item.TaskID, item.Position).
+			Update("position", gorm.Expr("position - 1")).Error
+		if err != nil {
+			return fmt.Errorf("failed to shift checklist items: %w", err)
+		}
+		return touchTask(tx, item.TaskID, now)
+	})
+}
+
+// touchTask marks a task as worked on, so checklist progress keeps it from going stale
+func touchTask(tx *gorm.DB, taskID uuid.UUID, now time.Time) error {
+	if err := tx.Model(&models.Task{}).Where("id = ?", taskID).UpdateColumn("updated_at", now).Error; err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+	return nil
+}
+
+// LoadChecklists returns the user's checklist items in order, keyed by task ID
+func LoadChecklists(db *gorm.DB, userID uuid.UUID) (map[uuid.UUID][]models.ChecklistItem, error) {
+	var items []models.ChecklistItem
+	if err := db.Where("user_id = ?", userID).Order("position").Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to load checklist items: %w", err)
+	}
+
+	checklists := make(map[uuid.UUID][]models.ChecklistItem)
+	for _, item := range items {
+		checklists[item.TaskID] = append(checklists[item.TaskID], item)
+	}
+	return checklists, nil
+}
+
+// LoadChecklistProgress counts ticked and total checklist items for every task of the user that has any
+func LoadChecklistProgress(db *gorm.DB, userID uuid.UUID) (map[uuid.UUID]ChecklistProgress, error) {
+	var rows []struct {
+		TaskID uuid.UUID
+		Done   int
+		Total  int
+	}
+	err := db.Model(&models.ChecklistItem{}).
+		Select("task_id, COUNT(CASE WHEN is_completed = true THEN 1 END) as done, COUNT(*) as total").
+		Where("user_id = ?", userID).
+		Group("task_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count checklist items: %w", err)
+	}
+
+	progress := make(map[uuid.UUID]ChecklistProgress, len(rows))
+	for _, r := range rows {
+		progress[r.TaskID] = ChecklistProgress{Done: r.Done, Total: r.Total}
+	}
+	return progress, nil
+}
+
+// LoadGoalProgress returns the share of each goal's work that is done, counting
+// ticked checklist items of open tasks as partial progress
+func LoadGoalProgress(db *gorm.DB, userID uuid.UUID) (map[uuid.UUID]float64, error) {
+	var tasks []models.Task
+	if err := db.Scopes(CountedTasks).Select("id", "goal_id", "is_completed").Where("user_id = ?", userID).Find(&tasks).Error; err != nil {
+		return nil, fmt.Errorf("failed to load tasks: %w", err)
+	}
+
+	checklists, err := LoadChecklistProgress(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byGoal := make(map[uuid.UUID][]float64)
+	for _, task := range tasks {
+		c := checklists[task.ID]
+		byGoal[task.GoalID] = append(byGoal[task.GoalID], engine.TaskProgress(task.IsCompleted, c.Done, c.Total))
+	}
+
+	progress := make(map[uuid.UUID]float64, len(byGoal))
+	for goalID, tasks := range byGoal {
+		progress[goalID] = engine.GoalProgress(tasks)
+	}
+	return progress, nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecordCompletionChange stores a completion event when a task's completion differs
+// from wasCompleted, the state it had before the change. The task carries its new
+// status, so a done task that was cancelled is not mistaken for a reopened one.
+func RecordCompletionChange(db *gorm.DB, task models.Task, wasCompleted bool, source string, now time.Time) error {
+	if task.IsCompleted == wasCompleted {
+		return nil
+	}
+
+	event := models.TaskCompletionEvent{
+		UserID:     task.UserID,
+		TaskID:     task.ID,
+		GoalID:     task.GoalID,
+		Event:      engine.CompletionReopened,
+		Source:     source,
+		OccurredAt: now,
+	}
+	switch {
+	case task.IsCompleted:
+		event.Event = engine.CompletionCompleted
+	case task.Status == engine.TaskCancelled:
+		event.Event = engine.CompletionCancelled
+	}
+
+	if err := db.Create(&event).Error; err != nil {
+		return fmt.Errorf("failed to record task completion: %w", err)
+	}
+	return nil
+}
+
+// LoadCompletionEvents returns the user's completion events since a time, oldest first
+func LoadCompletionEvents(db *gorm.DB, userID uuid.UUID, since time.Time) ([]models.TaskCompletionEvent, error) {
+	var events []models.TaskCompletionEvent
+	err := db.Where("user_id = ? AND occurred_at >= ?", userID, since).Order("occurred_at").Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load completion events: %w", err)
+	}
+	return events, nil
+}
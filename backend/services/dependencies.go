@@ -0,0 +1,115 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrDependencyCycle is returned when a new dependency would make a task wait on itself
+	ErrDependencyCycle = errors.New("dependency would create a cycle")
+	// ErrDependencyExists is returned when the task already depends on the other one
+	ErrDependencyExists = errors.New("dependency already exists")
+)
+
+// AddDependency records that a task cannot start until another of the user's tasks is
+// completed. Either task missing returns gorm.ErrRecordNotFound.
+func AddDependency(db *gorm.DB, userID, taskID, dependsOnID uuid.UUID) (models.TaskDependency, error) {
+	if taskID == dependsOnID {
+		return models.TaskDependency{}, ErrDependencyCycle
+	}
+
+	var found int64
+	if err := db.Model(&models.Task{}).Where("id IN ? AND user_id = ?", []uuid.UUID{taskID, dependsOnID}, userID).Count(&found).Error; err != nil {
+		return models.TaskDependency{}, fmt.Errorf("failed to load tasks: %w", err)
+	}
+	if found < 2 {
+		return models.TaskDependency{}, gorm.ErrRecordNotFound
+	}
+
+	edges, err := LoadDependencyEdges(db, userID)
+	if err != nil {
+		return models.TaskDependency{}, err
+	}
+	for _, e := range edges {
+		if e.TaskID == taskID && e.DependsOnID == dependsOnID {
+			return models.TaskDependency{}, ErrDependencyExists
+		}
+	}
+	if engine.CreatesCycle(edges, taskID, dependsOnID) {
+		return models.TaskDependency{}, ErrDependencyCycle
+	}
+
+	dependency := models.TaskDependency{UserID: userID, TaskID: taskID, DependsOnID: dependsOnID}
+	if err := db.Create(&dependency).Error; err != nil {
+		return models.TaskDependency{}, fmt.Errorf("failed to create dependency: %w", err)
+	}
+	return dependency, nil
+}
+
+// RemoveDependency deletes a dependency, returning gorm.ErrRecordNotFound if there was none
+func RemoveDependency(db *gorm.DB, userID, taskID, dependsOnID uuid.UUID) error {
+	result := db.Where("user_id = ? AND task_id = ? AND depends_on_id = ?", userID, taskID, dependsOnID).Delete(&models.TaskDependency{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete dependency: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// LoadDependencyEdges returns every dependency of the user, including those of
+// completed tasks and tasks in the trash
+func LoadDependencyEdges(db *gorm.DB, userID uuid.UUID) ([]engine.DependencyEdge, error) {
+	var dependencies []models.TaskDependency
+	if err := db.Where("user_id = ?", userID).Find(&dependencies).Error; err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+
+	edges := make([]engine.DependencyEdge, 0, len(dependencies))
+	for _, d := range dependencies {
+		edges = append(edges, engine.DependencyEdge{TaskID: d.TaskID, DependsOnID: d.DependsOnID})
+	}
+	return edges, nil
+}
+
+// LoadOpenBlockers loads just what OpenBlockers needs: the user's dependencies and the
+// IDs of their open tasks
+func LoadOpenBlockers(db *gorm.DB, userID uuid.UUID) (map[uuid.UUID][]uuid.UUID, error) {
+	edges, err := LoadDependencyEdges(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(edges) == 0 {
+		return map[uuid.UUID][]uuid.UUID{}, nil
+	}
+
+	var openTasks []models.Task
+	if err := db.Scopes(OpenTasks).Select("id").Where("user_id = ?", userID).Find(&openTasks).Error; err != nil {
+		return nil, fmt.Errorf("failed to load open tasks: %w", err)
+	}
+	return OpenBlockers(edges, openTasks), nil
+}
+
+// OpenBlockers maps each open task to the open tasks it is still waiting on. A task
+// with an entry is blocked; dependencies on completed or deleted tasks are satisfied.
+func OpenBlockers(edges []engine.DependencyEdge, openTasks []models.Task) map[uuid.UUID][]uuid.UUID {
+	open := make(map[uuid.UUID]bool, len(openTasks))
+	for _, task := range openTasks {
+		open[task.ID] = true
+	}
+
+	blockers := make(map[uuid.UUID][]uuid.UUID)
+	for _, e := range edges {
+		if open[e.TaskID] && open[e.DependsOnID] {
+			blockers[e.TaskID] = append(blockers[e.TaskID], e.DependsOnID)
+		}
+	}
+	return blockers
+}
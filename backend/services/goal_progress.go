@@ -0,0 +1,61 @@
+package services
+
+import (
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LoadCheckInDates returns the check-in dates of the user's habit goals, keyed by goal.
+// The whole history is needed for the longest streak.
+func LoadCheckInDates(db *gorm.DB, userID uuid.UUID) (map[uuid.UUID][]string, error) {
+	var checkIns []models.HabitCheckIn
+	if err := db.Select("goal_id", "date").Where("user_id = ?", userID).Order("date asc").Find(&checkIns).Error; err != nil {
+		return nil, err
+	}
+
+	dates := make(map[uuid.UUID][]string)
+	for _, checkIn := range checkIns {
+		dates[checkIn.GoalID] = append(dates[checkIn.GoalID], checkIn.Date)
+	}
+	return dates, nil
+}
+
+// HabitStats computes a habit goal's progress, or returns nil for other goal types
+func HabitStats(goal models.Goal, dates []string, now time.Time, loc *time.Location) *engine.HabitStats {
+	if goal.GoalType != "habit" || goal.Frequency == nil {
+		return nil
+	}
+	stats := engine.HabitProgress(*goal.Frequency, dates, goal.CreatedAt, now, loc)
+	return &stats
+}
+
+// LoadLearningLog returns the learning log of the user's exploration goals as engine entries, keyed by goal
+func LoadLearningLog(db *gorm.DB, userID uuid.UUID) (map[uuid.UUID][]engine.ExplorationEntry, error) {
+	var entries []models.LearningLogEntry
+	if err := db.Select("goal_id", "logged_at", "hours_spent").Where("user_id = ?", userID).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	log := make(map[uuid.UUID][]engine.ExplorationEntry)
+	for _, entry := range entries {
+		log[entry.GoalID] = append(log[entry.GoalID], engine.ExplorationEntry{At: entry.LoggedAt, Hours: entry.HoursSpent})
+	}
+	return log, nil
+}
+
+// ExplorationStats computes an exploration goal's progress, or returns nil for other goal types
+func ExplorationStats(goal models.Goal, entries []engine.ExplorationEntry, now time.Time, loc *time.Location) *engine.ExplorationStats {
+	if goal.GoalType != "exploration" {
+		return nil
+	}
+	lastActive := goal.CreatedAt
+	if goal.LastActiveAt != nil && goal.LastActiveAt.After(lastActive) {
+		lastActive = *goal.LastActiveAt
+	}
+	stats := engine.ExplorationProgress(goal.WeeklyHoursBudget, entries, lastActive, now, loc)
+	return &stats
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TransitionGoal moves a goal to a new status and records the transition. Completing
+// or abandoning a goal withdraws any pending completion proposal.
+func TransitionGoal(tx *gorm.DB, goal *models.Goal, to, trigger string, now time.Time) error {
+	from := engine.NormalizeGoalStatus(goal.Status)
+	if from == to {
+		return nil
+	}
+
+	updates := map[string]any{"status": to, "status_changed_at": now}
+	if to == engine.GoalCompleted || to == engine.GoalAbandoned {
+		updates["completion_proposed_at"] = nil
+		goal.CompletionProposedAt = nil
+	}
+	if err := tx.Model(goal).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update goal status: %w", err)
+	}
+	goal.Status = to
+	goal.StatusChangedAt = &now
+
+	transition := models.GoalStatusTransition{
+		GoalID:         goal.ID,
+		UserID:         goal.UserID,
+		FromStatus:     from,
+		ToStatus:       to,
+		Trigger:        trigger,
+		TransitionedAt: now,
+	}
+	if err := tx.Create(&transition).Error; err != nil {
+		return fmt.Errorf("failed to record goal status transition: %w", err)
+	}
+	return nil
+}
+
+// SyncGoalStatuses applies the automatic lifecycle to the user's open goals: starting,
+// flagging overdue and proposing completion based on their tasks and deadlines
+func SyncGoalStatuses(db *gorm.DB, userID uuid.UUID, now time.Time) error {
+	type taskCounts struct {
+		GoalID    uuid.UUID
+		Total     int
+		Completed int
+		Started   int
+	}
+
+	var goals []models.Goal
+	if err := db.Where("user_id = ? AND status NOT IN ?", userID, []string{engine.GoalCompleted, engine.GoalAbandoned}).Find(&goals).Error; err != nil {
+		return fmt.Errorf("failed to load goals: %w", err)
+	}
+	if len(goals) == 0 {
+		return nil
+	}
+
+	var counts []taskCounts
+	err := db.Model(&models.Task{}).Scopes(CountedTasks).
+		Select("goal_id, COUNT(*) AS total, SUM(CASE WHEN is_completed THEN 1 ELSE 0 END) AS completed, "+
+			"SUM(CASE WHEN NOT is_completed AND (started_at IS NOT NULL OR status IN ?) THEN 1 ELSE 0 END) AS started",
+			[]string{engine.TaskInProgress, engine.TaskWaiting}).
+		Where("user_id = ?", userID).
+		Group("goal_id").
+		Scan(&counts).Error
+	if err != nil {
+		return fmt.Errorf("failed to count tasks: %w", err)
+	}
+	countsByGoal := make(map[uuid.UUID]taskCounts, len(counts))
+	for _, count := range counts {
+		countsByGoal[count.GoalID] = count
+	}
+
+	// Overdue goals go back to the status they had once their deadline moves out
+	var overdueIDs []uuid.UUID
+	for _, goal := range goals {
+		if goal.Status == engine.GoalOverdue {
+			overdueIDs = append(overdueIDs, goal.ID)
+		}
+	}
+	beforeOverdue := make(map[uuid.UUID]string, len(overdueIDs))
+	if len(overdueIDs) > 0 {
+		var transitions []models.GoalStatusTransition
+		err := db.Where("goal_id IN ? AND to_status = ?", overdueIDs, engine.GoalOverdue).Order("transitioned_at asc").Find(&transitions).Error
+		if err != nil {
+			return fmt.Errorf("failed to load goal status transitions: %w", err)
+		}
+		for _, transition := range transitions {
+			beforeOverdue[transition.GoalID] = transition.FromStatus
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i := range goals {
+			goal := &goals[i]
+			count := countsByGoal[goal.ID]
+			step := engine.NextGoalStatus(engine.GoalState{
+				Status:         goal.Status,
+				Deadline:       goal.Deadline,
+				TotalTasks:     count.Total,
+				CompletedTasks: count.Completed,
+				StartedTasks:   count.Started,
+				Active:         goal.LastActiveAt != nil,
+				BeforeOverdue:  beforeOverdue[goal.ID],
+			}, now)
+
+			switch {
+			case step.Trigger != "":
+				if err := TransitionGoal(tx, goal, step.Status, step.Trigger, now); err != nil {
+					return err
+				}
+			case goal.Status != step.Status:
+				// Legacy "active" goals are rewritten without recording a transition
+				if err := tx.Model(goal).Update("status", step.Status).Error; err != nil {
+					return fmt.Errorf("failed to normalize goal status: %w", err)
+				}
+			}
+
+			switch {
+			case step.ProposeCompletion && goal.CompletionProposedAt == nil:
+				if err := tx.Model(goal).Update("completion_proposed_at", now).Error; err != nil {
+					return fmt.Errorf("failed to propose goal completion: %w", err)
+				}
+			case !step.ProposeCompletion && goal.CompletionProposedAt != nil:
+				if err := tx.Model(goal).Update("completion_proposed_at", nil).Error; err != nil {
+					return fmt.Errorf("failed to withdraw goal completion proposal: %w", err)
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SeriesChanges are edits to one occurrence that also apply to every later one
+type SeriesChanges struct {
+	Title        *string
+	Description  *string
+	UserPriority *int
+	Deadline     *time.Time         // New deadline for this occurrence; the series restarts from it
+	Rule         *engine.Recurrence // New rule, or nil to keep the current one; the series restarts from this occurrence
+}
+
+// StartSeries makes a task the first occurrence of a new recurring series. The task's
+// deadline, or the end of today if it has none, is moved to the first day the rule
+// allows and becomes the series start. The caller saves the task.
+func StartSeries(tx *gorm.DB, task *models.Task, rule engine.Recurrence, now time.Time, loc *time.Location) error {
+	start := task.Deadline
+	if start.IsZero() {
+		start = utils.EndOfDay(now, loc)
+	}
+	start = rule.Align(start, loc)
+
+	series := models.TaskSeries{
+		UserID:         task.UserID,
+		GoalID:         task.GoalID,
+		Title:          task.Title,
+		Description:    task.Description,
+		UserPriority:   task.UserPriority,
+		EstimatedHours: task.EstimatedHours,
+		Rule:           rule.String(),
+		StartsAt:       start,
+		StartIndex:     1,
+		LastDueAt:      start,
+	}
+	if err := tx.Create(&series).Error; err != nil {
+		return fmt.Errorf("failed to create task series: %w", err)
+	}
+
+	task.Deadline = start
+	task.SeriesID = &series.ID
+	task.Occurrence = 1
+	return nil
+}
+
+// AdvanceSeries creates the series' next occurrence, due after both its latest occurrence
+// and now, so missed occurrences are skipped rather than piled up. It returns nil and
+// ends the series once COUNT or UNTIL has been reached.
+func AdvanceSeries(tx *gorm.DB, series *models.TaskSeries, now time.Time, loc *time.Location) (*models.Task, error) {
+	rule, err := engine.ParseRRule(series.Rule, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule on series %s: %w", series.ID, err)
+	}
+
+	after := series.LastDueAt
+	if now.After(after) {
+		after = now
+	}
+
+	// A series restarted part way keeps numbering its occurrences from where it was,
+	// so COUNT still covers the whole series
+	offset := max(series.StartIndex, 1) - 1
+	var due time.Time
+	var index int
+	ok := rule.Count == 0 || rule.Count > offset
+	if ok {
+		if rule.Count > 0 {
+			rule.Count -= offset
+		}
+		due, index, ok = rule.OccurrenceAfter(series.StartsAt, after, loc)
+		index += offset
+	}
+	if !ok {
+		if err := tx.Model(series).Update("ended_at", now).Error; err != nil {
+			return nil, fmt.Errorf("failed to end task series: %w", err)
+		}
+		return nil, nil
+	}
+
+	task := models.Task{
+		UserID:         series.UserID,
+		GoalID:         series.GoalID,
+		Title:          series.Title,
+		Description:    series.Description,
+		UserPriority:   series.UserPriority,
+		EstimatedHours: series.EstimatedHours,
+		Deadline:       due,
+		SeriesID:       &series.ID,
+		Occurrence:     index,
+	}
+	if err := tx.Create(&task).Error; err != nil {
+		return nil, fmt.Errorf("failed to create occurrence: %w", err)
+	}
+	if err := tx.Model(series).Update("last_due_at", due).Error; err != nil {
+		return nil, fmt.Errorf("failed to update task series: %w", err)
+	}
+	return &task, nil
+}
+
+// AfterOccurrenceCompleted generates the next occurrence once the last open occurrence
+// of a series has been completed or cancelled
+func AfterOccurrenceCompleted(db *gorm.DB, task models.Task, now time.Time) error {
+	if task.SeriesID == nil || engine.TaskOpen(task) {
+		return nil
+	}
+
+	var series models.TaskSeries
+	err := db.Where("id = ? AND ended_at IS NULL", *task.SeriesID).First(&series).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load task series: %w", err)
+	}
+
+	var open int64
+	if err := db.Model(&models.Task{}).Scopes(OpenTasks).Where("series_id = ?", series.ID).Count(&open).Error; err != nil {
+		return fmt.Errorf("failed to count open occurrences: %w", err)
+	}
+	if open > 0 {
+		return nil
+	}
+
+	loc, err := UserLocation(db, task.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to load user timezone: %w", err)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		_, err := AdvanceSeries(tx, &series, now, loc)
+		return err
+	})
+}
+
+// GenerateDueOccurrences creates the next occurrence of every active series of the user
+// whose latest occurrence is already due and that has no open occurrence left, so
+// overdue copies do not pile up. Series of goals in the trash are left alone.
+func GenerateDueOccurrences(db *gorm.DB, userID uuid.UUID, now time.Time) error {
+	var due []models.TaskSeries
+	if err := db.Where("user_id = ? AND ended_at IS NULL AND last_due_at <= ?", userID, now).Find(&due).Error; err != nil {
+		return fmt.Errorf("failed to load task series: %w", err)
+	}
+	if len(due) == 0 {
+		return nil
+	}
+
+	var openSeries []uuid.UUID
+	if err := db.Model(&models.Task{}).Scopes(OpenTasks).Where("user_id = ? AND series_id IS NOT NULL", userID).Distinct().Pluck("series_id", &openSeries).Error; err != nil {
+		return fmt.Errorf("failed to load open occurrences: %w", err)
+	}
+	hasOpen := make(map[uuid.UUID]bool, len(openSeries))
+	for _, id := range openSeries {
+		hasOpen[id] = true
+	}
+
+	var goalIDs []uuid.UUID
+	if err := db.Model(&models.Goal{}).Where("user_id = ?", userID).Pluck("id", &goalIDs).Error; err != nil {
+		return fmt.Errorf("failed to load goals: %w", err)
+	}
+	liveGoals := make(map[uuid.UUID]bool, len(goalIDs))
+	for _, id := range goalIDs {
+		liveGoals[id] = true
+	}
+
+	loc, err := UserLocation(db, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load user timezone: %w", err)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i := range due {
+			if !liveGoals[due[i].GoalID] || hasOpen[due[i].ID] {
+				continue
+			}
+			if _, err := AdvanceSeries(tx, &due[i], now, loc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ApplyToFutureOccurrences copies edits made to one occurrence onto the occurrence, its
+// series and the open occurrences after it. A new deadline or rule restarts the series
+// from this occurrence, aligned to the rule and keeping its occurrence number, and
+// deletes the later occurrences so they are regenerated. The caller saves the task.
+func ApplyToFutureOccurrences(tx *gorm.DB, task *models.Task, changes SeriesChanges, now time.Time, loc *time.Location) error {
+	var series models.TaskSeries
+	if err := tx.Where("id = ?", *task.SeriesID).First(&series).Error; err != nil {
+		return fmt.Errorf("failed to load task series: %w", err)
+	}
+
+	template := map[string]any{}
+	if changes.Title != nil {
+		template["title"] = *changes.Title
+		task.Title = *changes.Title
+	}
+	if changes.Description != nil {
+		template["description"] = *changes.Description
+		task.Description = *changes.Description
+	}
+	if changes.UserPriority != nil {
+		template["user_priority"] = *changes.UserPriority
+		task.UserPriority = *changes.UserPriority
+	}
+
+	reanchor := changes.Deadline != nil || changes.Rule != nil
+	later := tx.Model(&models.Task{}).Scopes(OpenTasks).Where("series_id = ? AND id != ? AND deadline > ?", series.ID, task.ID, task.Deadline)
+	if reanchor {
+		if err := deleteLaterOccurrences(tx, *task); err != nil {
+			return err
+		}
+	} else if len(template) > 0 {
+		if err := later.Updates(template).Error; err != nil {
+			return fmt.Errorf("failed to update later occurrences: %w", err)
+		}
+	}
+
+	if reanchor {
+		rule, err := engine.ParseRRule(series.Rule, loc)
+		if err != nil {
+			return fmt.Errorf("invalid rule on series %s: %w", series.ID, err)
+		}
+		if changes.Rule != nil {
+			rule = *changes.Rule
+		}
+
+		start := task.Deadline
+		if changes.Deadline != nil {
+			start = *changes.Deadline
+		}
+		if start.IsZero() {
+			start = utils.EndOfDay(now, loc)
+		}
+		start = rule.Align(start, loc)
+		task.Deadline = start
+
+		template["rule"] = rule.String()
+		template["starts_at"] = start
+		template["start_index"] = max(task.Occurrence, 1)
+		template["last_due_at"] = start
+		template["ended_at"] = nil
+	}
+
+	if len(template) == 0 {
+		return nil
+	}
+	if err := tx.Model(&series).Updates(template).Error; err != nil {
+		return fmt.Errorf("failed to update task series: %w", err)
+	}
+	return nil
+}
+
+// StopSeries ends a series so no further occurrences are generated. With withLater,
+// open occurrences due after the given task are deleted as well.
+func StopSeries(tx *gorm.DB, task models.Task, withLater bool, now time.Time) error {
+	if task.SeriesID == nil {
+		return nil
+	}
+
+	if err := tx.Model(&models.TaskSeries{}).Where("id = ?", *task.SeriesID).Update("ended_at", now).Error; err != nil {
+		return fmt.Errorf("failed to stop task series: %w", err)
+	}
+	if !withLater {
+		return nil
+	}
+
+	return deleteLaterOccurrences(tx, task)
+}
+
+// deleteLaterOccurrences permanently deletes the open occurrences due after task. They
+// are regenerated or no longer wanted, so they skip the trash where restoring one would
+// bring back a duplicate.
+func deleteLaterOccurrences(tx *gorm.DB, task models.Task) error {
+	var ids []uuid.UUID
+	err := tx.Model(&models.Task{}).Scopes(OpenTasks).
+		Where("series_id = ? AND id != ? AND deadline > ?", *task.SeriesID, task.ID, task.Deadline).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return fmt.Errorf("failed to list later occurrences: %w", err)
+	}
+	if _, err := deleteTasks(tx, ids); err != nil {
+		return fmt.Errorf("failed to remove later occurrences: %w", err)
+	}
+	return nil
+}
+
+// LoadSeriesRules returns the rules of the user's active series, keyed by series ID
+func LoadSeriesRules(db *gorm.DB, userID uuid.UUID) (map[uuid.UUID]string, error) {
+	var series []models.TaskSeries
+	if err := db.Select("id", "rule").Where("user_id = ? AND ended_at IS NULL", userID).Find(&series).Error; err != nil {
+		return nil, err
+	}
+
+	rules := make(map[uuid.UUID]string, len(series))
+	for _, s := range series {
+		rules[s.ID] = s.Rule
+	}
+	return rules, nil
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"gorm.io/gorm"
+)
+
+func TestGenerateDueOccurrences(t *testing.T) {
+	db := setupTestDB(t)
+	start := time.Date(2026, 3, 2, 23, 59, 59, 0, time.UTC) // Monday
+
+	user := models.User{Name: "Test", Email: "series@example.com"}
+	db.Create(&user)
+	goal := models.Goal{UserID: user.ID, Title: "Fitness", GoalType: "deadline", Status: "in_progress"}
+	db.Create(&goal)
+
+	rule, _ := engine.ParseRRule("FREQ=DAILY;COUNT=3", time.UTC)
+	task := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Stretch", UserPriority: 1, Deadline: start}
+	if err := StartSeries(db, &task, rule, start, time.UTC); err != nil {
+		t.Fatal("StartSeries failed:", err)
+	}
+	db.Create(&task)
+
+	// Nothing is due before the first occurrence has passed
+	if err := GenerateDueOccurrences(db, user.ID, start.Add(-time.Hour)); err != nil {
+		t.Fatal("GenerateDueOccurrences failed:", err)
+	}
+	var count int64
+	db.Model(&models.Task{}).Where("series_id = ?", task.SeriesID).Count(&count)
+	if count != 1 {
+		t.Fatalf("Expected 1 occurrence before the first is due, got %d", count)
+	}
+
+	// An overdue occurrence still open holds the next one back
+	later := start.AddDate(0, 0, 1).Add(-time.Hour)
+	if err := GenerateDueOccurrences(db, user.ID, later); err != nil {
+		t.Fatal("GenerateDueOccurrences failed:", err)
+	}
+	db.Model(&models.Task{}).Where("series_id = ?", task.SeriesID).Count(&count)
+	if count != 1 {
+		t.Fatalf("Expected no new occurrence while the first is open, got %d occurrences", count)
+	}
+
+	// Once it is done, the next one is generated
+	db.Model(&task).Update("is_completed", true)
+	if err := GenerateDueOccurrences(db, user.ID, later); err != nil {
+		t.Fatal("GenerateDueOccurrences failed:", err)
+	}
+	var next models.Task
+	db.Where("series_id = ? AND occurrence = ?", task.SeriesID, 2).First(&next)
+	if !next.Deadline.Equal(start.AddDate(0, 0, 1)) || next.Title != "Stretch" {
+		t.Errorf("Expected the second occurrence a day later, got %+v", next)
+	}
+
+	// Missed occurrences are skipped but still count, so a week later COUNT has run out
+	db.Model(&next).Update("is_completed", true)
+	if err := GenerateDueOccurrences(db, user.ID, start.AddDate(0, 0, 7)); err != nil {
+		t.Fatal("GenerateDueOccurrences failed:", err)
+	}
+	var series models.TaskSeries
+	db.First(&series, "id = ?", task.SeriesID)
+	db.Model(&models.Task{}).Where("series_id = ?", task.SeriesID).Count(&count)
+	if count != 2 || series.EndedAt == nil {
+		t.Errorf("Expected the series to end once COUNT passed, got %d occurrences, ended %v", count, series.EndedAt)
+	}
+}
+
+func TestRestartedSeriesKeepsCounting(t *testing.T) {
+	db := setupTestDB(t)
+	start := time.Date(2026, 3, 2, 23, 59, 59, 0, time.UTC) // Monday
+
+	user := models.User{Name: "Test", Email: "restart@example.com"}
+	db.Create(&user)
+	goal := models.Goal{UserID: user.ID, Title: "Fitness", GoalType: "deadline", Status: "in_progress"}
+	db.Create(&goal)
+
+	rule, _ := engine.ParseRRule("FREQ=DAILY;COUNT=3", time.UTC)
+	first := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Stretch", UserPriority: 1, Deadline: start}
+	if err := StartSeries(db, &first, rule, start, time.UTC); err != nil {
+		t.Fatal("StartSeries failed:", err)
+	}
+	first.IsCompleted = true
+	db.Create(&first)
+
+	if err := AfterOccurrenceCompleted(db, first, start.Add(time.Hour)); err != nil {
+		t.Fatal("AfterOccurrenceCompleted failed:", err)
+	}
+	var second models.Task
+	db.Where("series_id = ? AND occurrence = ?", first.SeriesID, 2).First(&second)
+
+	// Moving the second occurrence restarts the series from it without resetting its number
+	moved := start.AddDate(0, 0, 5)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := ApplyToFutureOccurrences(tx, &second, SeriesChanges{Deadline: &moved}, start, time.UTC); err != nil {
+			return err
+		}
+		return tx.Save(&second).Error
+	})
+	if err != nil {
+		t.Fatal("ApplyToFutureOccurrences failed:", err)
+	}
+	if second.Occurrence != 2 || !second.Deadline.Equal(moved) {
+		t.Fatalf("Expected occurrence 2 moved to %s, got occurrence %d due %s", moved, second.Occurrence, second.Deadline)
+	}
+
+	// So only one more occurrence is left under COUNT=3
+	second.IsCompleted = true
+	db.Save(&second)
+	if err := AfterOccurrenceCompleted(db, second, moved.Add(time.Hour)); err != nil {
+		t.Fatal("AfterOccurrenceCompleted failed:", err)
+	}
+	var third models.Task
+	if err := db.Where("series_id = ? AND occurrence = ?", first.SeriesID, 3).First(&third).Error; err != nil || !third.Deadline.Equal(moved.AddDate(0, 0, 1)) {
+		t.Fatalf("Expected occurrence 3 the day after the moved one, got %+v (%v)", third, err)
+	}
+
+	third.IsCompleted = true
+	db.Save(&third)
+	if err := AfterOccurrenceCompleted(db, third, third.Deadline.Add(time.Hour)); err != nil {
+		t.Fatal("AfterOccurrenceCompleted failed:", err)
+	}
+	var series models.TaskSeries
+	db.First(&series, "id = ?", first.SeriesID)
+	var count int64
+	db.Model(&models.Task{}).Where("series_id = ?", first.SeriesID).Count(&count)
+	if count != 3 || series.EndedAt == nil {
+		t.Errorf("Expected the series to end after 3 occurrences, got %d, ended %v", count, series.EndedAt)
+	}
+}
+
+func TestStopSeriesDeletesLaterOccurrences(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	user := models.User{Name: "Test", Email: "stop@example.com"}
+	db.Create(&user)
+	goal := models.Goal{UserID: user.ID, Title: "Fitness", GoalType: "deadline", Status: "in_progress"}
+	db.Create(&goal)
+
+	rule, _ := engine.ParseRRule("FREQ=DAILY", time.UTC)
+	first := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Stretch", UserPriority: 1, Deadline: now}
+	if err := StartSeries(db, &first, rule, now, time.UTC); err != nil {
+		t.Fatal("StartSeries failed:", err)
+	}
+	db.Create(&first)
+	later := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Stretch", UserPriority: 1, Deadline: first.Deadline.AddDate(0, 0, 1), SeriesID: first.SeriesID, Occurrence: 2}
+	db.Create(&later)
+
+	if err := StopSeries(db, first, true, now); err != nil {
+		t.Fatal("StopSeries failed:", err)
+	}
+
+	// Gone for good, not sitting in the trash to be restored as a duplicate
+	var count int64
+	db.Unscoped().Model(&models.Task{}).Where("id = ?", later.ID).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected the later occurrence to be deleted permanently")
+	}
+}
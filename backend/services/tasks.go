@@ -0,0 +1,32 @@
+package services
+
+import (
+	"github.com/Pranay0205/velo/backend/engine"
+	"gorm.io/gorm"
+)
+
+// OpenTasks is a query scope for tasks that still need doing: neither completed nor cancelled
+func OpenTasks(db *gorm.DB) *gorm.DB {
+	return db.Where("is_completed = ? AND status <> ?", false, engine.TaskCancelled)
+}
+
+// CountedTasks is a query scope for the tasks that count towards a goal's completion,
+// which leaves out cancelled ones
+func CountedTasks(db *gorm.DB) *gorm.DB {
+	return db.Where("status <> ?", engine.TaskCancelled)
+}
+
+// TasksWithStatus is a query scope for the tasks engine.NormalizeTaskStatus puts in
+// status, so tasks saved before statuses existed land in the same column as on the board
+func TasksWithStatus(status string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		switch status {
+		case engine.TaskDone:
+			return db.Where("is_completed = ?", true)
+		case engine.TaskTodo:
+			return db.Where("is_completed = ? AND (status IS NULL OR status NOT IN ?)", false, []string{engine.TaskInProgress, engine.TaskWaiting, engine.TaskCancelled})
+		default:
+			return db.Where("is_completed = ? AND status = ?", false, status)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+)
+
+func TestTasksWithStatusMatchesBoard(t *testing.T) {
+	db := setupTestDB(t)
+
+	user := models.User{Name: "Test", Email: "columns@example.com"}
+	db.Create(&user)
+	goal := models.Goal{UserID: user.ID, Title: "Launch", GoalType: "deadline", Status: "in_progress"}
+	db.Create(&goal)
+
+	tasks := []models.Task{
+		{Title: "todo", Status: engine.TaskTodo},
+		{Title: "in progress", Status: engine.TaskInProgress},
+		{Title: "waiting", Status: engine.TaskWaiting},
+		{Title: "done", Status: engine.TaskDone, IsCompleted: true},
+		{Title: "cancelled", Status: engine.TaskCancelled},
+		{Title: "legacy done flag", Status: engine.TaskTodo, IsCompleted: true},
+		{Title: "legacy done status", Status: engine.TaskDone},
+		{Title: "legacy unknown status", Status: "someday"},
+	}
+	for i := range tasks {
+		tasks[i].UserID = user.ID
+		tasks[i].GoalID = goal.ID
+		tasks[i].UserPriority = 1
+		db.Create(&tasks[i])
+	}
+
+	for _, status := range []string{engine.TaskTodo, engine.TaskInProgress, engine.TaskWaiting, engine.TaskDone, engine.TaskCancelled} {
+		want := map[string]bool{}
+		for _, task := range tasks {
+			if engine.NormalizeTaskStatus(task.Status, task.IsCompleted) == status {
+				want[task.Title] = true
+			}
+		}
+
+		var got []models.Task
+		if err := db.Scopes(TasksWithStatus(status)).Where("user_id = ?", user.ID).Find(&got).Error; err != nil {
+			t.Fatal("Query failed:", err)
+		}
+		if len(got) != len(want) {
+			t.Errorf("%s: expected %d tasks, got %d", status, len(want), len(got))
+		}
+		for _, task := range got {
+			if !want[task.Title] {
+				t.Errorf("%s: did not expect %q", status, task.Title)
+			}
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// trashPurgeInterval is how often the trash service looks for expired items
+const trashPurgeInterval = 6 * time.Hour
+
+// ErrGoalInTrash is returned when restoring a task whose goal is still deleted
+var ErrGoalInTrash = errors.New("the task's goal is in the trash")
+
+// SoftDeleteGoal moves a goal and its remaining tasks to the trash with one shared
+// timestamp, so restoring the goal brings back exactly the tasks deleted with it
+func SoftDeleteGoal(db *gorm.DB, userID, goalID uuid.UUID, now time.Time) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Goal{}).Where("id = ? AND user_id = ?", goalID, userID).Update("deleted_at", now)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete goal: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Model(&models.Task{}).Where("goal_id = ? AND user_id = ?", goalID, userID).Update("deleted_at", now).Error; err != nil {
+			return fmt.Errorf("failed to delete associated tasks: %w", err)
+		}
+		return nil
+	})
+}
+
+// SoftDeleteTask moves a single task to the trash
+func SoftDeleteTask(db *gorm.DB, userID, taskID uuid.UUID, now time.Time) error {
+	result := db.Model(&models.Task{}).Where("id = ? AND user_id = ?", taskID, userID).Update("deleted_at", now)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete task: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RestoreGoal brings a goal back from the trash together with the tasks deleted with
+// it. Tasks that were deleted on their own before the goal stay in the trash.
+func RestoreGoal(db *gorm.DB, userID, goalID uuid.UUID) error {
+	var goal models.Goal
+	if err := db.Unscoped().Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", goalID, userID).First(&goal).Error; err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&models.Task{}).
+			Where("goal_id = ? AND user_id = ? AND deleted_at >= ?", goal.ID, userID, goal.DeletedAt.Time).
+			Update("deleted_at", nil).Error
+		if err != nil {
+			return fmt.Errorf("failed to restore tasks: %w", err)
+		}
+
+		if err := tx.Unscoped().Model(&goal).Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("failed to restore goal: %w", err)
+		}
+		return nil
+	})
+}
+
+// RestoreTask brings a single task back from the trash. Its goal has to be restored first.
+func RestoreTask(db *gorm.DB, userID, taskID uuid.UUID) error {
+	var task models.Task
+	if err := db.Unscoped().Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", taskID, userID).First(&task).Error; err != nil {
+		return err
+	}
+
+	var goals int64
+	if err := db.Model(&models.Goal{}).Where("id = ?", task.GoalID).Count(&goals).Error; err != nil {
+		return fmt.Errorf("failed to check the task's goal: %w", err)
+	}
+	if goals == 0 {
+		return ErrGoalInTrash
+	}
+
+	if err := db.Unscoped().Model(&task).Update("deleted_at", nil).Error; err != nil {
+		return fmt.Errorf("failed to restore task: %w", err)
+	}
+	return nil
+}
+
+// PurgeTrash permanently deletes goals and tasks that were moved to the trash before
+// the cutoff, along with the records that hang off them. It returns how many goals
+// and tasks were removed.
+func PurgeTrash(db *gorm.DB, cutoff time.Time) (goals int64, tasks int64, err error) {
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var goalIDs []uuid.UUID
+		if err := tx.Unscoped().Model(&models.Goal{}).Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Pluck("id", &goalIDs).Error; err != nil {
+			return fmt.Errorf("failed to list expired goals: %w", err)
+		}
+
+		// Tasks of an expired goal go with it even if they were deleted later
+		taskQuery := tx.Unscoped().Model(&models.Task{}).Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff)
+		if len(goalIDs) > 0 {
+			taskQuery = taskQuery.Or("goal_id IN ?", goalIDs)
+		}
+		var taskIDs []uuid.UUID
+		if err := taskQuery.Pluck("id", &taskIDs).Error; err != nil {
+			return fmt.Errorf("failed to list expired tasks: %w", err)
+		}
+
+		purged, err := deleteTasks(tx, taskIDs)
+		if err != nil {
+			return err
+		}
+		tasks = purged
+
+		if len(goalIDs) > 0 {
+			for _, dependent := range []any{&models.GoalStatusTransition{}, &models.HabitCheckIn{}, &models.LearningLogEntry{}, &models.UrgencyHistory{}, &models.TaskSeries{}} {
+				if err := tx.Where("goal_id IN ?", goalIDs).Delete(dependent).Error; err != nil {
+					return fmt.Errorf("failed to purge goal records: %w", err)
+				}
+			}
+			result := tx.Unscoped().Where("id IN ?", goalIDs).Delete(&models.Goal{})
+			if result.Error != nil {
+				return fmt.Errorf("failed to purge goals: %w", result.Error)
+			}
+			goals = result.RowsAffected
+		}
+		return nil
+	})
+	return goals, tasks, err
+}
+
+// deleteTasks permanently deletes tasks along with the records that hang off them and
+// returns how many tasks were removed
+func deleteTasks(tx *gorm.DB, taskIDs []uuid.UUID) (int64, error) {
+	if len(taskIDs) == 0 {
+		return 0, nil
+	}
+
+	for _, dependent := range []any{&models.UrgencyHistory{}, &models.DailyPlanItem{}, &models.ChecklistItem{}, &models.TaskCompletionEvent{}} {
+		if err := tx.Where("task_id IN ?", taskIDs).Delete(dependent).Error; err != nil {
+			return 0, fmt.Errorf("failed to purge task records: %w", err)
+		}
+	}
+	if err := tx.Where("task_id IN ? OR depends_on_id IN ?", taskIDs, taskIDs).Delete(&models.TaskDependency{}).Error; err != nil {
+		return 0, fmt.Errorf("failed to purge task dependencies: %w", err)
+	}
+	result := tx.Unscoped().Where("id IN ?", taskIDs).Delete(&models.Task{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge tasks: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// TrashService permanently removes goals and tasks once they have spent the
+// retention period in the trash
+type TrashService struct {
+	DB        *gorm.DB
+	Clock     engine.Clock
+	Retention time.Duration
+
+	wg sync.WaitGroup
+}
+
+func NewTrashService(db *gorm.DB, clock engine.Clock, retention time.Duration) *TrashService {
+	return &TrashService{DB: db, Clock: clock, Retention: retention}
+}
+
+// Start purges expired items now and then periodically until ctx is cancelled
+func (s *TrashService) Start(ctx context.Context) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+
+		s.purge()
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("[TrashService] Stopping")
+				return
+			case <-ticker.C:
+				s.purge()
+			}
+		}
+	}()
+}
+
+// Wait blocks until the purge goroutine has exited
+func (s *TrashService) Wait() {
+	s.wg.Wait()
+}
+
+func (s *TrashService) purge() {
+	now := time.Now()
+	if s.Clock != nil {
+		now = s.Clock.Now()
+	}
+
+	goals, tasks, err := PurgeTrash(s.DB, now.Add(-s.Retention))
+	if err != nil {
+		log.Printf("[TrashService] Purge failed: %v", err)
+		return
+	}
+	if goals > 0 || tasks > 0 {
+		log.Printf("[TrashService] Purged %d goal(s) and %d task(s) older than %s", goals, tasks, s.Retention)
+	}
+}
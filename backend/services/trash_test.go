@@ -0,0 +1,93 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/models"
+)
+
+func TestSoftDeleteAndRestoreGoal(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	user := models.User{Name: "Test", Email: "trash@example.com"}
+	db.Create(&user)
+	goal := models.Goal{UserID: user.ID, Title: "Garden", GoalType: "deadline", Status: "in_progress"}
+	db.Create(&goal)
+	earlier := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Deleted earlier", UserPriority: 1}
+	kept := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Deleted with the goal", UserPriority: 1}
+	db.Create(&earlier)
+	db.Create(&kept)
+
+	if err := SoftDeleteTask(db, user.ID, earlier.ID, now.Add(-time.Hour)); err != nil {
+		t.Fatal("SoftDeleteTask failed:", err)
+	}
+	if err := SoftDeleteGoal(db, user.ID, goal.ID, now); err != nil {
+		t.Fatal("SoftDeleteGoal failed:", err)
+	}
+
+	var visible int64
+	db.Model(&models.Task{}).Where("goal_id = ?", goal.ID).Count(&visible)
+	if visible != 0 {
+		t.Errorf("Expected the goal's tasks to be hidden, %d still visible", visible)
+	}
+
+	if err := RestoreTask(db, user.ID, kept.ID); err != ErrGoalInTrash {
+		t.Errorf("Expected restoring a task of a deleted goal to fail with ErrGoalInTrash, got %v", err)
+	}
+
+	if err := RestoreGoal(db, user.ID, goal.ID); err != nil {
+		t.Fatal("RestoreGoal failed:", err)
+	}
+
+	var restored []models.Task
+	db.Where("goal_id = ?", goal.ID).Find(&restored)
+	if len(restored) != 1 || restored[0].ID != kept.ID {
+		t.Errorf("Expected only the task deleted with the goal to come back, got %+v", restored)
+	}
+
+	if err := RestoreTask(db, user.ID, earlier.ID); err != nil {
+		t.Errorf("Expected the earlier task to be restorable once its goal is back, got %v", err)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	user := models.User{Name: "Test", Email: "purge@example.com"}
+	db.Create(&user)
+	old := models.Goal{UserID: user.ID, Title: "Old", GoalType: "deadline", Status: "in_progress"}
+	recent := models.Goal{UserID: user.ID, Title: "Recent", GoalType: "deadline", Status: "in_progress"}
+	db.Create(&old)
+	db.Create(&recent)
+	oldTask := models.Task{UserID: user.ID, GoalID: old.ID, Title: "Old task", UserPriority: 1}
+	recentTask := models.Task{UserID: user.ID, GoalID: recent.ID, Title: "Recent task", UserPriority: 1}
+	db.Create(&oldTask)
+	db.Create(&recentTask)
+	db.Create(&models.UrgencyHistory{TaskID: oldTask.ID, GoalID: old.ID, UserID: user.ID, Urgency: 5, RecordedAt: now})
+
+	SoftDeleteGoal(db, user.ID, old.ID, now.AddDate(0, 0, -40))
+	SoftDeleteGoal(db, user.ID, recent.ID, now.AddDate(0, 0, -5))
+
+	goals, tasks, err := PurgeTrash(db, now.AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatal("PurgeTrash failed:", err)
+	}
+	if goals != 1 || tasks != 1 {
+		t.Errorf("Expected 1 goal and 1 task purged, got %d and %d", goals, tasks)
+	}
+
+	var remaining int64
+	db.Unscoped().Model(&models.Goal{}).Count(&remaining)
+	if remaining != 1 {
+		t.Errorf("Expected the recently deleted goal to stay in the trash, %d goals left", remaining)
+	}
+
+	var history int64
+	db.Model(&models.UrgencyHistory{}).Count(&history)
+	if history != 0 {
+		t.Errorf("Expected the purged task's history to go with it, %d rows left", history)
+	}
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/Pranay0205/velo/backend/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GoalMetrics holds per-goal task counts used by the urgency engine
+type GoalMetrics struct {
+	GoalID         uuid.UUID `json:"goal_id"`
+	TotalTasks     int       `json:"total_tasks"`
+	CompletedTasks int       `json:"completed_tasks"`
+}
+
+// UrgencyContext is what the engine needs beyond the task itself: its goal,
+// the goal's task counts, the user's scorer and their open workload
+type UrgencyContext struct {
+	Goals                map[uuid.UUID]models.Goal
+	Metrics              map[uuid.UUID]GoalMetrics
+	Scorer               engine.Scorer
+	OpenTasks            []models.Task
+	Hours                engine.WorkingHours
+	DefaultEstimateHours float64
+	CheckIns             map[uuid.UUID][]string                  // Habit check-in dates by goal
+	LearningLog          map[uuid.UUID][]engine.ExplorationEntry // Exploration log entries by goal
+	Blockers             map[uuid.UUID][]uuid.UUID               // Open tasks each open task is waiting on
+	Completions          map[uuid.UUID][]time.Time               // When each goal's completed tasks were finished
+
+	capacity    map[uuid.UUID]engine.CapacityLoad
+	capacityAt  time.Time
+	forecasts   map[uuid.UUID]engine.GoalForecast
+	forecastsAt time.Time
+}
+
+// LoadUrgencyContext loads the user's goals, their task counts and scoring settings
+func LoadUrgencyContext(db *gorm.DB, userID uuid.UUID) (*UrgencyContext, error) {
+	var goals []models.Goal
+	if err := db.Where("user_id = ?", userID).Find(&goals).Error; err != nil {
+		return nil, err
+	}
+
+	// Get task counts for each goal
+	var metrics []GoalMetrics
+	if err := db.Model(&models.Task{}).Scopes(CountedTasks).
+		Select("goal_id, COUNT(*) as total_tasks, COUNT(CASE WHEN is_completed = true THEN 1 END) as completed_tasks").
+		Where("user_id = ?", userID).
+		Group("goal_id").
+		Scan(&metrics).Error; err != nil {
+		return nil, err
+	}
+
+	settings, err := LoadUrgencySettings(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs, err := LoadPreferences(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var openTasks []models.Task
+	if err := db.Scopes(OpenTasks).Where("user_id = ?", userID).Find(&openTasks).Error; err != nil {
+		return nil, err
+	}
+
+	checkIns, err := LoadCheckInDates(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	learningLog, err := LoadLearningLog(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	edges, err := LoadDependencyEdges(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	completions, err := LoadGoalCompletions(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc := &UrgencyContext{
+		Goals:                make(map[uuid.UUID]models.Goal),
+		Metrics:              make(map[uuid.UUID]GoalMetrics),
+		Scorer:               engine.NewScorer(settings),
+		OpenTasks:            openTasks,
+		Hours:                engine.WorkingHoursFromPreferences(prefs),
+		DefaultEstimateHours: settings.DefaultEstimateHours,
+		CheckIns:             checkIns,
+		LearningLog:          learningLog,
+		Blockers:             OpenBlockers(edges, openTasks),
+		Completions:          completions,
+	}
+	for _, g := range goals {
+		uc.Goals[g.ID] = g
+	}
+	for _, m := range metrics {
+		uc.Metrics[m.GoalID] = m
+	}
+
+	return uc, nil
+}
+
+// Input builds the engine input for a task, reporting false when the task's goal is missing
+func (uc *UrgencyContext) Input(task models.Task, now time.Time, loc *time.Location) (engine.Input, bool) {
+	goal, exists := uc.Goals[task.GoalID]
+	if !exists {
+		return engine.Input{}, false
+	}
+	m := uc.Metrics[task.GoalID]
+
+	in := engine.Input{
+		Task:           task,
+		Goal:           goal,
+		TotalTasks:     m.TotalTasks,
+		CompletedTasks: m.CompletedTasks,
+		Now:            now,
+		Location:       loc,
+	}
+	if load, ok := uc.Capacity(now, loc)[task.ID]; ok {
+		in.Capacity = &load
+	}
+	in.Habit = HabitStats(goal, uc.CheckIns[goal.ID], now, loc)
+	in.Exploration = ExplorationStats(goal, uc.LearningLog[goal.ID], now, loc)
+	if forecast, ok := uc.Forecasts(now, loc)[goal.ID]; ok {
+		in.Forecast = &forecast
+	}
+	return in, true
+}
+
+// Capacity returns the capacity load of every open task with a deadline as of now,
+// reusing the last result when asked again for the same moment
+func (uc *UrgencyContext) Capacity(now time.Time, loc *time.Location) map[uuid.UUID]engine.CapacityLoad {
+	if uc.capacity == nil || !uc.capacityAt.Equal(now) {
+		uc.capacity = engine.PlanCapacity(uc.OpenTasks, uc.Goals, now, loc, uc.Hours, uc.DefaultEstimateHours)
+		uc.capacityAt = now
+	}
+	return uc.capacity
+}
+
+// Forecasts projects the completion of every deadline goal with a deadline as of now,
+// reusing the last result when asked again for the same moment
+func (uc *UrgencyContext) Forecasts(now time.Time, loc *time.Location) map[uuid.UUID]engine.GoalForecast {
+	if uc.forecasts != nil && uc.forecastsAt.Equal(now) {
+		return uc.forecasts
+	}
+
+	remaining := make(map[uuid.UUID][]models.Task)
+	for _, task := range uc.OpenTasks {
+		remaining[task.GoalID] = append(remaining[task.GoalID], task)
+	}
+
+	// Every goal with open tasks competes for the same working hours, so each gets an even share
+	competing := 0
+	for goalID := range remaining {
+		if _, ok := uc.Goals[goalID]; ok {
+			competing++
+		}
+	}
+	share := 1.0
+	if competing > 1 {
+		share = 1 / float64(competing)
+	}
+
+	uc.forecasts = make(map[uuid.UUID]engine.GoalForecast)
+	for _, goal := range uc.Goals {
+		if goal.GoalType != "deadline" || goal.Deadline == nil {
+			continue
+		}
+		uc.forecasts[goal.ID] = engine.ForecastGoal(engine.ForecastInput{
+			Goal:         goal,
+			Completions:  uc.Completions[goal.ID],
+			Remaining:    remaining[goal.ID],
+			Now:          now,
+			Location:     loc,
+			Hours:        uc.Hours,
+			DefaultHours: uc.DefaultEstimateHours,
+			Share:        share,
+		})
+	}
+	uc.forecastsAt = now
+	return uc.forecasts
+}
+
+// ExplainTasks returns the urgency breakdown of every task whose goal is known. Open
+// tasks that hold up more urgent ones take on their urgency.
+func (uc *UrgencyContext) ExplainTasks(tasks []models.Task, now time.Time, loc *time.Location) map[uuid.UUID]engine.Breakdown {
+	breakdowns := make(map[uuid.UUID]engine.Breakdown, len(tasks))
+	for _, task := range tasks {
+		if in, ok := uc.Input(task, now, loc); ok {
+			breakdowns[task.ID] = uc.Scorer.Explain(in)
+		}
+	}
+
+	for taskID, urgency := range uc.blockingUrgency(now, loc) {
+		if b, ok := breakdowns[taskID]; ok {
+			b.ApplyBlocking(urgency)
+			breakdowns[taskID] = b
+		}
+	}
+	return breakdowns
+}
+
+// blockingUrgency scores every open task on its own and returns the urgency each
+// blocker inherits from the tasks waiting on it
+func (uc *UrgencyContext) blockingUrgency(now time.Time, loc *time.Location) map[uuid.UUID]int {
+	if len(uc.Blockers) == 0 {
+		return nil
+	}
+
+	urgency := make(map[uuid.UUID]int, len(uc.OpenTasks))
+	for _, task := range uc.OpenTasks {
+		if in, ok := uc.Input(task, now, loc); ok {
+			urgency[task.ID] = uc.Scorer.Score(in)
+		}
+	}
+	return engine.BlockingUrgency(urgency, uc.Blockers)
+}
+
+// LoadUrgencySettings returns the user's saved scoring settings or the engine defaults
+func LoadUrgencySettings(db *gorm.DB, userID uuid.UUID) (models.UrgencySettings, error) {
+	var settings models.UrgencySettings
+	err := db.Where("user_id = ?", userID).First(&settings).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return engine.DefaultSettings(userID), nil
+	}
+	if err != nil {
+		return models.UrgencySettings{}, err
+	}
+	return settings, nil
+}
+
+// LoadPreferences returns the user's saved preferences or the defaults if they never saved any
+func LoadPreferences(db *gorm.DB, userID uuid.UUID) (models.UserPreferences, error) {
+	var prefs models.UserPreferences
+	err := db.Where("user_id = ?", userID).First(&prefs).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DefaultUserPreferences(userID), nil
+	}
+	if err != nil {
+		return models.UserPreferences{}, err
+	}
+	return prefs, nil
+}
+
+// UserLocation returns the time.Location of the user's configured timezone
+func UserLocation(db *gorm.DB, userID uuid.UUID) (*time.Location, error) {
+	var timezone string
+	if err := db.Model(&models.User{}).Where("id = ?", userID).Pluck("timezone", &timezone).Error; err != nil {
+		return nil, err
+	}
+	return utils.LoadLocation(timezone), nil
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// updateBatchSize caps how many tasks are written by a single UPDATE statement
+const updateBatchSize = 500
+
+// UrgencyService recomputes task urgency in the background: for every user on a
+// fixed cadence, and for a single user whenever their tasks or goals change
+type UrgencyService struct {
+	DB       *gorm.DB
+	Clock    engine.Clock
+	Interval time.Duration
+
+	events chan uuid.UUID
+	wg     sync.WaitGroup
+}
+
+func NewUrgencyService(db *gorm.DB, clock engine.Clock, interval time.Duration) *UrgencyService {
+	return &UrgencyService{
+		DB:       db,
+		Clock:    clock,
+		Interval: interval,
+		events:   make(chan uuid.UUID, 256),
+	}
+}
+
+// Notify schedules a recomputation for the user. It never blocks: if the queue is
+// full the next periodic run picks the change up instead.
+func (s *UrgencyService) Notify(userID uuid.UUID) {
+	select {
+	case s.events <- userID:
+	default:
+		log.Printf("[UrgencyService] Event queue full, user %s will be recomputed on the next tick", userID)
+	}
+}
+
+// Start runs the scheduler until ctx is cancelled. Call Wait to block until it has stopped.
+func (s *UrgencyService) Start(ctx context.Context) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.Interval)
+		defer ticker.Stop()
+
+		s.runAll()
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("[UrgencyService] Stopping")
+				return
+			case <-ticker.C:
+				s.runAll()
+			case userID := <-s.events:
+				if err := s.RecomputeUser(userID); err != nil {
+					log.Printf("[UrgencyService] Failed to recompute urgency for user %s: %v", userID, err)
+				}
+			}
+		}
+	}()
+}
+
+// Wait blocks until the scheduler goroutine has exited
+func (s *UrgencyService) Wait() {
+	s.wg.Wait()
+}
+
+func (s *UrgencyService) runAll() {
+	start := time.Now()
+	if err := s.RecomputeAll(); err != nil {
+		log.Printf("[UrgencyService] Periodic recomputation failed: %v", err)
+		return
+	}
+	log.Printf("[UrgencyService] Recomputed urgency for all users in %s", time.Since(start))
+}
+
+// RecomputeAll recomputes urgency for every user that has open tasks, goal statuses
+// for every user with an open goal whose deadline may have passed, and generates
+// occurrences of recurring tasks that have come due
+func (s *UrgencyService) RecomputeAll() error {
+	var userIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	collect := func(query *gorm.DB, what string) error {
+		var ids []uuid.UUID
+		if err := query.Distinct().Pluck("user_id", &ids).Error; err != nil {
+			return fmt.Errorf("failed to list users with %s: %w", what, err)
+		}
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				userIDs = append(userIDs, id)
+			}
+		}
+		return nil
+	}
+
+	if err := collect(s.DB.Model(&models.Task{}).Scopes(OpenTasks), "open tasks"); err != nil {
+		return err
+	}
+	openGoals := s.DB.Model(&models.Goal{}).Where("deadline IS NOT NULL AND status NOT IN ?", []string{engine.GoalCompleted, engine.GoalAbandoned})
+	if err := collect(openGoals, "open goals"); err != nil {
+		return err
+	}
+	dueSeries := s.DB.Model(&models.TaskSeries{}).Where("ended_at IS NULL AND last_due_at <= ?", s.now())
+	if err := collect(dueSeries, "recurring tasks"); err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		if err := s.RecomputeUser(userID); err != nil {
+			log.Printf("[UrgencyService] Failed to recompute urgency for user %s: %v", userID, err)
+		}
+	}
+	return nil
+}
+
+// RecomputeUser generates due recurring tasks and brings the user's goal statuses up
+// to date, then scores all of their open tasks and stores the results in batched updates
+func (s *UrgencyService) RecomputeUser(userID uuid.UUID) error {
+	now := s.now()
+	if err := GenerateDueOccurrences(s.DB, userID, now); err != nil {
+		return fmt.Errorf("failed to generate recurring tasks: %w", err)
+	}
+	if err := SyncGoalStatuses(s.DB, userID, now); err != nil {
+		return fmt.Errorf("failed to sync goal statuses: %w", err)
+	}
+
+	loc, err := UserLocation(s.DB, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load user timezone: %w", err)
+	}
+
+	var tasks []models.Task
+	if err := s.DB.Scopes(OpenTasks).Where("user_id = ?", userID).Find(&tasks).Error; err != nil {
+		return fmt.Errorf("failed to load tasks: %w", err)
+	}
+
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	uc, err := LoadUrgencyContext(s.DB, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load urgency context: %w", err)
+	}
+
+	breakdowns := uc.ExplainTasks(tasks, now, loc)
+	scores := make(map[uuid.UUID]int, len(breakdowns))
+	var history []models.UrgencyHistory
+	for _, task := range tasks {
+		breakdown, ok := breakdowns[task.ID]
+		if !ok {
+			continue
+		}
+		score := breakdown.Urgency
+		scores[task.ID] = score
+		if score != task.AIUrgency {
+			history = append(history, models.UrgencyHistory{
+				TaskID:          task.ID,
+				GoalID:          task.GoalID,
+				UserID:          userID,
+				PreviousUrgency: task.AIUrgency,
+				Urgency:         score,
+				RecordedAt:      now,
+			})
+		}
+	}
+
+	return s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := applyScores(tx, scores, now); err != nil {
+			return err
+		}
+		if len(history) > 0 {
+			if err := tx.CreateInBatches(&history, updateBatchSize).Error; err != nil {
+				return fmt.Errorf("failed to record urgency history: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// applyScores writes urgency values and the computation timestamp with one UPDATE per batch
+func applyScores(tx *gorm.DB, scores map[uuid.UUID]int, computedAt time.Time) error {
+	ids := make([]uuid.UUID, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+
+	for start := 0; start < len(ids); start += updateBatchSize {
+		end := min(start+updateBatchSize, len(ids))
+		batch := ids[start:end]
+
+		var sql strings.Builder
+		args := make([]any, 0, len(batch)*2+2)
+		sql.WriteString("UPDATE tasks SET ai_urgency = CASE id")
+		for _, id := range batch {
+			sql.WriteString(" WHEN ? THEN CAST(? AS INTEGER)")
+			args = append(args, id, scores[id])
+		}
+		sql.WriteString(" ELSE ai_urgency END, urgency_computed_at = ? WHERE id IN ?")
+		args = append(args, computedAt, batch)
+
+		if err := tx.Exec(sql.String(), args...).Error; err != nil {
+			return fmt.Errorf("failed to store urgency batch: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *UrgencyService) now() time.Time {
+	if s.Clock == nil {
+		return time.Now()
+	}
+	return s.Clock.Now()
+}
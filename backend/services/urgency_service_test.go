@@ -0,0 +1,93 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pranay0205/velo/backend/database"
+	"github.com/Pranay0205/velo/backend/engine"
+	"github.com/Pranay0205/velo/backend/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal("Failed to connect test DB:", err)
+	}
+	if err := database.Migrate(db); err != nil {
+		t.Fatal("Failed to migrate test DB:", err)
+	}
+	return db
+}
+
+func TestRecomputeUserStoresScores(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	user := models.User{Name: "Test", Email: "urgency@example.com"}
+	db.Create(&user)
+
+	goal := models.Goal{UserID: user.ID, Title: "Ship it", GoalType: "deadline", Status: "in_progress", CreatedAt: now.AddDate(0, 0, -10)}
+	db.Create(&goal)
+
+	overdue := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Overdue", UserPriority: 3, Deadline: now.AddDate(0, 0, -1), CreatedAt: now.AddDate(0, 0, -5), UpdatedAt: now}
+	relaxed := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Relaxed", UserPriority: 1, CreatedAt: now, UpdatedAt: now}
+	done := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Done", UserPriority: 3, IsCompleted: true, AIUrgency: 7}
+	db.Create(&overdue)
+	db.Create(&relaxed)
+	db.Create(&done)
+
+	service := NewUrgencyService(db, engine.FixedClock{Time: now}, time.Hour)
+	if err := service.RecomputeUser(user.ID); err != nil {
+		t.Fatal("RecomputeUser returned error:", err)
+	}
+
+	var tasks []models.Task
+	db.Find(&tasks)
+
+	want := map[string]int{"Overdue": 9, "Relaxed": 1, "Done": 7}
+	for _, task := range tasks {
+		if task.AIUrgency != want[task.Title] {
+			t.Errorf("%s: ai_urgency = %d, want %d", task.Title, task.AIUrgency, want[task.Title])
+		}
+		if task.IsCompleted && task.UrgencyComputedAt != nil {
+			t.Errorf("%s: completed tasks should not be rescored", task.Title)
+		}
+		if !task.IsCompleted && (task.UrgencyComputedAt == nil || !task.UrgencyComputedAt.Equal(now)) {
+			t.Errorf("%s: urgency_computed_at = %v, want %v", task.Title, task.UrgencyComputedAt, now)
+		}
+	}
+}
+
+func TestRecomputeUserRecordsHistoryOnChange(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	user := models.User{Name: "Test", Email: "history@example.com"}
+	db.Create(&user)
+
+	goal := models.Goal{UserID: user.ID, Title: "Ship it", GoalType: "deadline", Status: "in_progress", CreatedAt: now.AddDate(0, 0, -10)}
+	db.Create(&goal)
+
+	task := models.Task{UserID: user.ID, GoalID: goal.ID, Title: "Overdue", UserPriority: 3, Deadline: now.AddDate(0, 0, -1), CreatedAt: now.AddDate(0, 0, -5), UpdatedAt: now}
+	db.Create(&task)
+
+	service := NewUrgencyService(db, engine.FixedClock{Time: now}, time.Hour)
+	for range 2 {
+		if err := service.RecomputeUser(user.ID); err != nil {
+			t.Fatal("RecomputeUser returned error:", err)
+		}
+	}
+
+	var history []models.UrgencyHistory
+	db.Where("task_id = ?", task.ID).Find(&history)
+
+	if len(history) != 1 {
+		t.Fatalf("got %d history rows, want 1 (unchanged scores should not be recorded)", len(history))
+	}
+	if history[0].PreviousUrgency != 0 || history[0].Urgency != 9 || history[0].GoalID != goal.ID {
+		t.Errorf("history row = %+v, want 0 -> 9 for goal %s", history[0], goal.ID)
+	}
+}
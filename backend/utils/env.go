@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+// DurationFromEnv reads a duration such as "15m" or "24h" from the environment, falling back to def
+func DurationFromEnv(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s=%q, using default %s", key, value, def)
+		return def
+	}
+	return duration
+}
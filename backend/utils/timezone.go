@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+const DateLayout = "2006-01-02"
+
+// LoadLocation resolves an IANA zone name, falling back to UTC for empty or unknown names
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ParseDeadline accepts either a date ("2006-01-02"), interpreted as the end of that day in loc,
+// or a full RFC 3339 timestamp which is kept as is
+func ParseDeadline(value string, loc *time.Location) (time.Time, error) {
+	if date, err := time.ParseInLocation(DateLayout, value, loc); err == nil {
+		return EndOfDay(date, loc), nil
+	}
+
+	deadline, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("deadline must be a date (YYYY-MM-DD) or an RFC 3339 timestamp")
+	}
+	return deadline, nil
+}
+
+// EndOfDay returns the last second of t's calendar day in loc
+func EndOfDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 23, 59, 59, 0, loc)
+}
+
+// StartOfDay returns midnight of t's calendar day in loc
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// LocalDate formats t as a calendar date in loc, or "" for the zero time
+func LocalDate(t time.Time, loc *time.Location) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(loc).Format(DateLayout)
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDeadlineDateOnly(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("tzdata not available:", err)
+	}
+
+	deadline, err := ParseDeadline("2026-08-01", loc)
+	if err != nil {
+		t.Fatal("ParseDeadline returned error:", err)
+	}
+
+	want := time.Date(2026, 8, 1, 23, 59, 59, 0, loc)
+	if !deadline.Equal(want) {
+		t.Fatalf("ParseDeadline() = %v, want %v", deadline, want)
+	}
+
+	if got := LocalDate(deadline, loc); got != "2026-08-01" {
+		t.Fatalf("LocalDate() = %s, want 2026-08-01", got)
+	}
+}
+
+func TestParseDeadlineTimestamp(t *testing.T) {
+	deadline, err := ParseDeadline("2026-08-01T15:04:05Z", time.UTC)
+	if err != nil {
+		t.Fatal("ParseDeadline returned error:", err)
+	}
+
+	if !deadline.Equal(time.Date(2026, 8, 1, 15, 4, 5, 0, time.UTC)) {
+		t.Fatalf("ParseDeadline() kept the wrong instant: %v", deadline)
+	}
+}
+
+func TestParseDeadlineInvalid(t *testing.T) {
+	if _, err := ParseDeadline("next friday", time.UTC); err == nil {
+		t.Fatal("ParseDeadline should reject free-form text")
+	}
+}
@@ -16,7 +16,13 @@ type ActionReviewProps = {
 function getActionInfo(action: AIAction) {
   const verb = action.type.split("_")[0] || "update";
   const entity = action.type.split("_").slice(1).join("_") || "unknown";
-  const kind = entity.includes("goal") ? "Goal" : entity.includes("task") ? "Task" : "Unknown";
+  const kind = entity.includes("goal")
+    ? "Goal"
+    : entity.includes("task")
+      ? "Task"
+      : entity.includes("checklist")
+        ? "Checklist Item"
+        : "Unknown";
 
   // Pull title from whichever sub-object exists
   let title: string | undefined;
@@ -50,6 +56,9 @@ function getActionInfo(action: AIAction) {
     targetId = action.reprioritize.task_id;
     priority = action.reprioritize.new_priority;
     description = action.reprioritize.reason;
+  } else if (action.checklist_item) {
+    title = action.checklist_item.title;
+    targetId = action.checklist_item.item_id ?? action.checklist_item.task_id;
   }
 
   const displayTitle =
@@ -79,7 +88,7 @@ function getVerbConfig(verb: string) {
 
 function getEntityIcon(kind: string) {
   if (kind === "Goal") return Target;
-  if (kind === "Task") return CheckSquare;
+  if (kind === "Task" || kind === "Checklist Item") return CheckSquare;
   return HelpCircle;
 }
 
@@ -8,9 +8,13 @@ import ActionReview from "./ActionReview";
 import type { AIAction } from "@/types";
 
 export default function ChatPanel() {
-  const { getMessages, sendMessage, isMessagesLoading, isSending, executeActions, isExecuting } = useMessages();
+  const { getMessages, sendMessage, isMessagesLoading, isSending, executeActions, isExecuting, discardActions } =
+    useMessages();
   const [open, setOpen] = useState(false);
   const [pendingActions, setPendingActions] = useState<AIAction[]>([]);
+  const [pendingMessageId, setPendingMessageId] = useState<string | undefined>();
+  // Position of each pending action in the assistant's proposal, so feedback lines up after some are handled
+  const [pendingIndexes, setPendingIndexes] = useState<number[]>([]);
   const panelRef = useRef<HTMLDivElement>(null);
 
   useEffect(() => {
@@ -42,25 +46,36 @@ export default function ChatPanel() {
     const result = await sendMessage(content);
     if (result?.actions?.length) {
       setPendingActions(result.actions);
+      setPendingIndexes(result.actions.map((_, i) => i));
+      setPendingMessageId(result.id);
     }
   };
 
+  const removePending = (index: number) => {
+    setPendingActions((prev) => prev.filter((_, i) => i !== index));
+    setPendingIndexes((prev) => prev.filter((_, i) => i !== index));
+  };
+
   const handleApproveAll = async (actions: AIAction[]) => {
-    await executeActions(actions);
+    await executeActions({ actions, actionIndexes: pendingIndexes, messageId: pendingMessageId });
     setPendingActions([]);
+    setPendingIndexes([]);
   };
 
   const handleRejectAll = () => {
+    if (pendingMessageId) void discardActions({ actionIndexes: pendingIndexes, messageId: pendingMessageId });
     setPendingActions([]);
+    setPendingIndexes([]);
   };
 
   const handleApproveAction = async (action: AIAction, index: number) => {
-    await executeActions([action]);
-    setPendingActions((prev) => prev.filter((_, i) => i !== index));
+    await executeActions({ actions: [action], actionIndexes: [pendingIndexes[index]], messageId: pendingMessageId });
+    removePending(index);
   };
 
   const handleRejectAction = (_action: AIAction, index: number) => {
-    setPendingActions((prev) => prev.filter((_, i) => i !== index));
+    if (pendingMessageId) void discardActions({ actionIndexes: [pendingIndexes[index]], messageId: pendingMessageId });
+    removePending(index);
   };
 
   return (
@@ -1,5 +1,5 @@
 import { logger } from "@/lib/logger";
-import type { AIAction } from "@/types";
+import type { AIAction, ChatResponse } from "@/types";
 import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 
 export function useMessages() {
@@ -35,8 +35,8 @@ export function useMessages() {
       }
       const result = await response.json();
       logger.log(`[useMessages] Message sent successfully`);
-      // result.data has { message, actions }
-      return result.data as { message: string; actions: AIAction[] };
+      // result.data has { id, message, actions }
+      return result.data as ChatResponse;
     },
     onSuccess: () => {
       queryClient.invalidateQueries({ queryKey: ["chat"] });
@@ -44,13 +44,21 @@ export function useMessages() {
   });
 
   const { mutateAsync: executeActions, isPending: isExecuting } = useMutation({
-    mutationFn: async (actions: AIAction[]) => {
+    mutationFn: async ({
+      actions,
+      actionIndexes,
+      messageId,
+    }: {
+      actions: AIAction[];
+      actionIndexes: number[]; // Position of each action in the proposal of messageId
+      messageId?: string;
+    }) => {
       logger.log(`[useMessages] Executing ${actions.length} actions`);
       const response = await fetch("/api/chat/execute", {
         method: "POST",
         headers: { "Content-Type": "application/json" },
         credentials: "include",
-        body: JSON.stringify({ actions }),
+        body: JSON.stringify({ actions, action_indexes: actionIndexes, message_id: messageId }),
       });
       if (!response.ok) {
         logger.error(`[useMessages] Failed to execute actions. Status: ${response.status}`);
@@ -64,5 +72,21 @@ export function useMessages() {
     },
   });
 
-  return { getMessages, isMessagesLoading, sendMessage, isSending, executeActions, isExecuting };
+  // Records proposals the user rejected so we can evaluate plan quality
+  const { mutateAsync: discardActions } = useMutation({
+    mutationFn: async ({ actionIndexes, messageId }: { actionIndexes: number[]; messageId: string }) => {
+      logger.log(`[useMessages] Discarding ${actionIndexes.length} actions`);
+      const response = await fetch(`/api/chat/${messageId}/discard`, {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        credentials: "include",
+        body: JSON.stringify({ action_indexes: actionIndexes }),
+      });
+      if (!response.ok) {
+        logger.error(`[useMessages] Failed to record discarded actions. Status: ${response.status}`);
+      }
+    },
+  });
+
+  return { getMessages, isMessagesLoading, sendMessage, isSending, executeActions, isExecuting, discardActions };
 }
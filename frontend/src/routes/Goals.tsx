@@ -32,7 +32,7 @@ const goalFormScheme = z.object({
   description: z.string().optional(),
   goal_type: z.enum(["deadline", "habit", "exploration"]),
   deadline: z.string().optional(),
-  frequency: z.number().optional(),
+  frequency: z.number().min(1).max(7).optional(),
 });
 
 type GoalForm = z.infer<typeof goalFormScheme>;
@@ -4,14 +4,32 @@ export type Goal = {
   title: string;
   description: string;
   goal_type: "deadline" | "habit" | "exploration";
-  status: "not_started" | "in_progress" | "completed" | "abandoned";
+  status: "not_started" | "in_progress" | "overdue" | "completed" | "abandoned";
   deadline: string | null;
   frequency: number | null;
   last_active_at: string | null;
+  status_changed_at: string | null;
+  completion_proposed_at: string | null;
   created_at: string;
   updated_at: string;
   total_tasks: number;
   completed_tasks: number;
+  progress: number; // 0-1, counting ticked checklist items of open tasks
+};
+
+export type GoalForecast = {
+  goal_id: string;
+  remaining_tasks: number;
+  remaining_hours: number;
+  tasks_per_week: number;
+  projected: string | null;
+  earliest: string | null;
+  latest: string | null; // null when the pace could stall
+  deadline?: string;
+  at_risk: boolean;
+  projected_local?: string;
+  earliest_local?: string;
+  latest_local?: string;
 };
 
 export type Task = {
@@ -21,12 +39,40 @@ export type Task = {
   description: string | null;
   deadline: string | null;
   user_priority: number; // 1-3: Low, Med, High
+  estimated_hours: number | null;
+  tracked_hours: number | null; // time actually spent, when logged
   ai_urgency: number; // 1-10, calculated by backend
   is_completed: boolean;
+  status: TaskStatus;
+  position: number; // order within its board column
+  started_at: string | null;
+  completed_at: string | null;
+  series_id: string | null; // set on occurrences of a recurring task
+  occurrence?: number;
+  recurrence?: string; // RRULE of the task's series, e.g. FREQ=WEEKLY;BYDAY=FR
+  blocked?: boolean; // waiting on an open task
+  blocked_by?: string[];
+  checklist?: { done: number; total: number };
   created_at: string;
   updated_at: string;
 };
 
+export type TaskCompletionEvent = {
+  id: string;
+  task_id: string;
+  goal_id: string;
+  event: "completed" | "reopened" | "cancelled";
+  source: "rest" | "chat" | "quick_add"; // quick_add is reported by the client
+  occurred_at: string;
+};
+
+export type TaskStatus = "todo" | "in_progress" | "waiting" | "done" | "cancelled";
+
+export type BoardColumn = {
+  status: TaskStatus;
+  tasks: Task[];
+};
+
 export type AIAction = {
   type: string;
   goal?: {
@@ -60,6 +106,7 @@ export type AIAction = {
     deadline?: string | null;
     user_priority?: number;
     completed?: boolean;
+    status?: TaskStatus;
   };
   delete_task?: {
     task_id: string;
@@ -69,6 +116,21 @@ export type AIAction = {
     new_priority: number;
     reason: string;
   };
+  checklist_item?: {
+    task_id: string;
+    item_id?: string;
+    title?: string;
+    completed?: boolean;
+  };
+};
+
+export type ChecklistItem = {
+  id: string;
+  task_id: string;
+  title: string;
+  position: number;
+  is_completed: boolean;
+  completed_at: string | null;
 };
 
 export type ChatMessage = {
@@ -76,10 +138,43 @@ export type ChatMessage = {
   user_id: string;
   message: string;
   role: "user" | "assistant";
+  kind?: "briefing" | "review"; // Set on messages the assistant wrote without a chat message from the user
+  actions?: AIAction[];
   created_at: string;
 };
 
 export type ChatResponse = {
+  id: string;
   message: string;
   actions: AIAction[];
 };
+
+export type ReviewTask = {
+  task_id: string;
+  goal_id: string;
+  title: string;
+  deadline?: string;
+  completed_at?: string;
+  urgency: number;
+  idle_ratio?: number;
+};
+
+export type ReviewHabit = {
+  goal_id: string;
+  title: string;
+  check_ins: number;
+  expected: number;
+};
+
+export type WeeklyReview = {
+  review: {
+    from: string; // YYYY-MM-DD
+    to: string;
+    completed: ReviewTask[];
+    slipped: ReviewTask[];
+    stale: ReviewTask[];
+    neglected_habits: ReviewHabit[];
+    new_goals: Goal[];
+  };
+  narrative?: ChatResponse; // Only from POST /reviews/weekly/narrative
+};